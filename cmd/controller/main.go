@@ -19,6 +19,7 @@ import (
 	"github.com/google/kf/pkg/reconciler/route"
 	"github.com/google/kf/pkg/reconciler/source"
 	"github.com/google/kf/pkg/reconciler/space"
+	"github.com/google/kf/pkg/reconciler/task"
 	"knative.dev/pkg/injection/sharedmain"
 )
 
@@ -29,5 +30,6 @@ func main() {
 		source.NewController,
 		route.NewController,
 		app.NewController,
+		task.NewController,
 	)
 }
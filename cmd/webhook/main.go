@@ -24,6 +24,7 @@ import (
 	"go.uber.org/zap"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	kfclientset "github.com/google/kf/pkg/client/clientset/versioned"
 	apiconfig "github.com/knative/serving/pkg/apis/config"
 	"github.com/knative/serving/pkg/apis/serving/v1beta1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -85,6 +86,11 @@ func main() {
 		logger.Fatalw("Failed to get the istio client set", zap.Error(err))
 	}
 
+	kfClient, err := kfclientset.NewForConfig(clusterConfig)
+	if err != nil {
+		logger.Fatalw("Failed to get the kf client set", zap.Error(err))
+	}
+
 	// Watch the logging config map and dynamically update logging levels.
 	configMapWatcher := configmap.NewInformedWatcher(kubeClient, system.Namespace())
 	configMapWatcher.Watch(logging.ConfigMapName(), logging.UpdateLevelFromConfigMap(logger, atomicLevel, component))
@@ -108,9 +114,11 @@ func main() {
 		Client:  kubeClient,
 		Options: options,
 		Handlers: map[schema.GroupVersionKind]webhook.GenericCRD{
-			v1alpha1.SchemeGroupVersion.WithKind("Space"): &v1alpha1.Space{},
-			v1alpha1.SchemeGroupVersion.WithKind("App"):   &v1alpha1.App{},
-			v1alpha1.SchemeGroupVersion.WithKind("Route"): &v1alpha1.Route{},
+			v1alpha1.SchemeGroupVersion.WithKind("Space"):  &v1alpha1.Space{},
+			v1alpha1.SchemeGroupVersion.WithKind("App"):    &v1alpha1.App{},
+			v1alpha1.SchemeGroupVersion.WithKind("Route"):  &v1alpha1.Route{},
+			v1alpha1.SchemeGroupVersion.WithKind("Source"): &v1alpha1.Source{},
+			v1alpha1.SchemeGroupVersion.WithKind("Task"):   &v1alpha1.Task{},
 		},
 		Logger:                logger,
 		DisallowUnknownFields: true,
@@ -121,6 +129,10 @@ func main() {
 			// deployed.
 			ctx = v1alpha1.SetupIstioClient(ctx, istioClient)
 
+			// Sources are checked against the builder images and build
+			// template their space allows.
+			ctx = v1alpha1.SetupSpaceGetter(ctx, kfClient.KfV1alpha1().Spaces())
+
 			return v1beta1.WithUpgradeViaDefaulting(store.ToContext(ctx))
 		},
 	}
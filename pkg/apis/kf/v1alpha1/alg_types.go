@@ -15,8 +15,11 @@
 package v1alpha1
 
 import (
+	"sort"
+
 	"github.com/google/kf/pkg/kf/algorithms"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"knative.dev/pkg/apis/istio/v1alpha3"
 )
 
@@ -90,20 +93,70 @@ func (h HTTPRoutes) Len() int {
 	return len(h)
 }
 
-// Less implements Interface.
+// Less implements Interface. Istio sends a request to the first route that
+// matches it, so routes are ordered by precedence: exact paths before
+// prefixes, longer prefixes before shorter ones, routes limited to some
+// sources before the route for everyone on the same path, and routes that
+// match headers before the ones that don't. Ties are broken by the matchers
+// so the order is deterministic.
 func (h HTTPRoutes) Less(i int, j int) bool {
-	f := func(h v1alpha3.HTTPRoute) string {
-		var m string
-		for _, s := range h.Match {
-			if s.URI == nil {
-				continue
-			}
-			m += s.URI.Exact + s.URI.Prefix + s.URI.Suffix + s.URI.Regex
-		}
-		return m
+	a, b := httpRoutePrecedence(h[i]), httpRoutePrecedence(h[j])
+
+	if a.exact != b.exact {
+		return a.exact
 	}
 
-	return f(h[i]) < f(h[j])
+	if len(a.uri) != len(b.uri) {
+		return len(a.uri) > len(b.uri)
+	}
+
+	if a.sourced != b.sourced {
+		return a.sourced
+	}
+
+	if a.filtered != b.filtered {
+		return a.filtered
+	}
+
+	return a.uri+a.sources+a.headers < b.uri+b.sources+b.headers
+}
+
+type precedence struct {
+	exact    bool
+	sourced  bool
+	filtered bool
+	uri      string
+	sources  string
+	headers  string
+}
+
+func httpRoutePrecedence(h v1alpha3.HTTPRoute) precedence {
+	var p precedence
+	for _, s := range h.Match {
+		if s.URI != nil {
+			p.exact = p.exact || s.URI.Exact != ""
+			p.uri += s.URI.Exact + s.URI.Prefix + s.URI.Suffix + s.URI.Regex
+		}
+
+		// Routes that only match some sources are distinct from the route
+		// for all sources on the same URI.
+		p.sourced = p.sourced || len(s.SourceLabels) > 0
+		p.sources += labels.Set(s.SourceLabels).String()
+
+		// Routes that only match some headers, like the client's address,
+		// are distinct from the fallback route on the same URI.
+		p.filtered = p.filtered || len(s.Headers) > 0
+		var names []string
+		for name := range s.Headers {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			header := s.Headers[name]
+			p.headers += name + "=" + header.Exact + header.Prefix + header.Suffix + header.Regex
+		}
+	}
+	return p
 }
 
 // Swap implements Interface.
@@ -111,6 +164,60 @@ func (h HTTPRoutes) Swap(i int, j int) {
 	h[i], h[j] = h[j], h[i]
 }
 
+// TCPRoutes implements the necessary interfaces for the algorithms
+// package.
+type TCPRoutes []v1alpha3.TCPRoute
+
+// Set implements Interface.
+func (t TCPRoutes) Set(i int, a algorithms.Interface, j int, b algorithms.Interface) {
+	a.(TCPRoutes)[i] = b.(TCPRoutes)[j]
+}
+
+// Append implements Interface.
+func (t TCPRoutes) Append(a algorithms.Interface) algorithms.Interface {
+	return append(t, a.(TCPRoutes)...)
+}
+
+// Clone implements Interface.
+func (t TCPRoutes) Clone() algorithms.Interface {
+	return append(TCPRoutes{}, t...)
+}
+
+// Slice implements Interface.
+func (t TCPRoutes) Slice(i int, j int) algorithms.Interface {
+	return t[i:j]
+}
+
+// Len implements Interface.
+func (t TCPRoutes) Len() int {
+	return len(t)
+}
+
+// Less implements Interface.
+func (t TCPRoutes) Less(i int, j int) bool {
+	f := func(t v1alpha3.TCPRoute) []int {
+		var ports []int
+		for _, m := range t.Match {
+			ports = append(ports, m.Port)
+		}
+		return ports
+	}
+
+	pi, pj := f(t[i]), f(t[j])
+	for k := 0; k < len(pi) && k < len(pj); k++ {
+		if pi[k] != pj[k] {
+			return pi[k] < pj[k]
+		}
+	}
+
+	return len(pi) < len(pj)
+}
+
+// Swap implements Interface.
+func (t TCPRoutes) Swap(i int, j int) {
+	t[i], t[j] = t[j], t[i]
+}
+
 // SpaceDomains implements the necessary interfaces for the algorithms
 // package.
 type SpaceDomains []SpaceDomain
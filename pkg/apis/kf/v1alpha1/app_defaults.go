@@ -16,7 +16,9 @@ package v1alpha1
 
 import (
 	"context"
+	"strings"
 
+	"github.com/google/kf/pkg/kf/algorithms"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
@@ -36,6 +38,18 @@ const (
 	// DefaultHealthCheckProbeEndpoint is the default endpoint to use for HTTP
 	// Get health checks.
 	DefaultHealthCheckProbeEndpoint = "/"
+
+	// DefaultCanaryPercent is the percent of traffic sent to a canary revision
+	// if the user doesn't specify one.
+	DefaultCanaryPercent = 10
+
+	// DefaultRevisionHistoryLimit is the number of revisions kept in an App's
+	// history if the user doesn't specify a limit.
+	DefaultRevisionHistoryLimit = 10
+
+	// DefaultProcessInstances is the number of instances of a process that
+	// run if the user doesn't specify a number.
+	DefaultProcessInstances = 1
 )
 
 // SetDefaults implements apis.Defaultable
@@ -46,6 +60,48 @@ func (k *App) SetDefaults(ctx context.Context) {
 // SetDefaults implements apis.Defaultable
 func (k *AppSpec) SetDefaults(ctx context.Context) {
 	k.Template.SetDefaults(ctx)
+	k.Rollout.SetDefaults(ctx)
+	k.NetworkPolicy.SetDefaults(ctx)
+
+	if k.RevisionHistoryLimit == nil {
+		limit := DefaultRevisionHistoryLimit
+		k.RevisionHistoryLimit = &limit
+	}
+
+	for i := range k.Processes {
+		k.Processes[i].SetDefaults(ctx)
+	}
+}
+
+// SetDefaults implements apis.Defaultable
+func (k *AppSpecProcess) SetDefaults(ctx context.Context) {
+	k.Type = strings.TrimSpace(k.Type)
+	k.Command = strings.TrimSpace(k.Command)
+
+	if k.Instances == nil {
+		instances := DefaultProcessInstances
+		k.Instances = &instances
+	}
+}
+
+// SetDefaults implements apis.Defaultable
+func (k *AppSpecNetworkPolicy) SetDefaults(ctx context.Context) {
+	if len(k.AllowedSourceApps) > 0 {
+		k.AllowedSourceApps = []string(algorithms.Dedupe(
+			algorithms.Strings(k.AllowedSourceApps),
+		).(algorithms.Strings))
+	}
+}
+
+// SetDefaults implements apis.Defaultable
+func (k *AppSpecRollout) SetDefaults(ctx context.Context) {
+	if k.Strategy == "" {
+		k.Strategy = RolloutStrategyImmediate
+	}
+
+	if k.IsCanary() && k.CanaryPercent == 0 && len(k.Steps) == 0 {
+		k.CanaryPercent = DefaultCanaryPercent
+	}
 }
 
 // SetDefaults implements apis.Defaultable
@@ -33,6 +33,33 @@ func TestAppSpec_SetDefaults_BlankContainer(t *testing.T) {
 	testutil.AssertEqual(t, "spec.template.spec.containers.name", "", app.Spec.Template.Spec.Containers[0].Name)
 }
 
+func TestAppSpec_SetDefaults_RevisionHistoryLimit(t *testing.T) {
+	t.Parallel()
+
+	app := &App{}
+	app.SetDefaults(context.Background())
+	testutil.AssertEqual(t, "default limit", DefaultRevisionHistoryLimit, *app.Spec.RevisionHistoryLimit)
+
+	app = &App{Spec: AppSpec{RevisionHistoryLimit: intPtr(3)}}
+	app.SetDefaults(context.Background())
+	testutil.AssertEqual(t, "custom limit", 3, *app.Spec.RevisionHistoryLimit)
+}
+
+func TestAppSpec_SetDefaults_Processes(t *testing.T) {
+	t.Parallel()
+
+	app := &App{Spec: AppSpec{Processes: []AppSpecProcess{
+		{Type: " worker ", Command: " bin/worker "},
+		{Type: "clock", Command: "bin/clock", Instances: intPtr(0)},
+	}}}
+	app.SetDefaults(context.Background())
+
+	testutil.AssertEqual(t, "type", "worker", app.Spec.Processes[0].Type)
+	testutil.AssertEqual(t, "command", "bin/worker", app.Spec.Processes[0].Command)
+	testutil.AssertEqual(t, "default instances", DefaultProcessInstances, *app.Spec.Processes[0].Instances)
+	testutil.AssertEqual(t, "custom instances", 0, *app.Spec.Processes[1].Instances)
+}
+
 func TestAppSpec_SetDefaults_ResourceLimits_AlreadySet(t *testing.T) {
 	t.Parallel()
 
@@ -66,6 +93,64 @@ func TestAppSpec_SetDefaults_ResourceLimits_AlreadySet(t *testing.T) {
 	testutil.AssertEqual(t, "default CPU request", wantCPU, appResourceRequests[corev1.ResourceCPU])
 }
 
+func TestAppSpecRollout_SetDefaults(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		rollout  AppSpecRollout
+		expected AppSpecRollout
+	}{
+		"blank": {
+			rollout:  AppSpecRollout{},
+			expected: AppSpecRollout{Strategy: RolloutStrategyImmediate},
+		},
+		"canary gets default percent": {
+			rollout: AppSpecRollout{Strategy: RolloutStrategyCanary},
+			expected: AppSpecRollout{
+				Strategy:      RolloutStrategyCanary,
+				CanaryPercent: DefaultCanaryPercent,
+			},
+		},
+		"canary percent doesn't get overwritten": {
+			rollout: AppSpecRollout{Strategy: RolloutStrategyCanary, CanaryPercent: 25},
+			expected: AppSpecRollout{
+				Strategy:      RolloutStrategyCanary,
+				CanaryPercent: 25,
+			},
+		},
+		"canary with steps doesn't get percent": {
+			rollout: AppSpecRollout{
+				Strategy: RolloutStrategyCanary,
+				Steps:    []AppSpecRolloutStep{{Percent: 20}},
+			},
+			expected: AppSpecRollout{
+				Strategy: RolloutStrategyCanary,
+				Steps:    []AppSpecRolloutStep{{Percent: 20}},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			tc.rollout.SetDefaults(context.Background())
+
+			testutil.AssertEqual(t, "rollout", tc.expected, tc.rollout)
+		})
+	}
+}
+
+func TestAppSpecNetworkPolicy_SetDefaults(t *testing.T) {
+	t.Parallel()
+
+	policy := AppSpecNetworkPolicy{AllowedSourceApps: []string{"b", "a", "b"}}
+	policy.SetDefaults(context.Background())
+	testutil.AssertEqual(t, "deduped", []string{"a", "b"}, policy.AllowedSourceApps)
+
+	policy = AppSpecNetworkPolicy{}
+	policy.SetDefaults(context.Background())
+	testutil.AssertEqual(t, "empty", []string(nil), policy.AllowedSourceApps)
+}
+
 func TestSetKfAppContainerDefaults(t *testing.T) {
 	defaultContainer := &corev1.Container{}
 	SetKfAppContainerDefaults(context.Background(), defaultContainer)
@@ -15,7 +15,12 @@
 package v1alpha1
 
 import (
+	"time"
+
 	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"knative.dev/pkg/apis"
 )
@@ -39,6 +44,12 @@ const (
 	AppConditionSpaceReady apis.ConditionType = "SpaceReady"
 	// AppConditionRouteReady is set when route is ready.
 	AppConditionRouteReady apis.ConditionType = "RouteReady"
+	// AppConditionProcessesReady is set when the Deployments of the processes
+	// other than web are ready.
+	AppConditionProcessesReady apis.ConditionType = "ProcessesReady"
+	// AppConditionNetworkPolicyReady is set when the NetworkPolicy limiting
+	// the sources that can reach the App is ready.
+	AppConditionNetworkPolicyReady apis.ConditionType = "NetworkPolicyReady"
 )
 
 func (status *AppStatus) manage() apis.ConditionManager {
@@ -46,6 +57,8 @@ func (status *AppStatus) manage() apis.ConditionManager {
 		AppConditionSourceReady,
 		AppConditionKnativeServiceReady,
 		AppConditionSpaceReady,
+		AppConditionProcessesReady,
+		AppConditionNetworkPolicyReady,
 	).Manage(status)
 }
 
@@ -69,6 +82,17 @@ func (status *AppStatus) KnativeServiceCondition() SingleConditionManager {
 	return NewSingleConditionManager(status.manage(), AppConditionKnativeServiceReady, "Knative Service")
 }
 
+// ProcessesCondition gets a manager for the state of the Deployments running
+// the processes other than web.
+func (status *AppStatus) ProcessesCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), AppConditionProcessesReady, "Deployment")
+}
+
+// NetworkPolicyCondition gets a manager for the state of the NetworkPolicy.
+func (status *AppStatus) NetworkPolicyCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), AppConditionNetworkPolicyReady, "NetworkPolicy")
+}
+
 // RouteCondition gets a manager for the state of the kf Route.
 func (status *AppStatus) RouteCondition() SingleConditionManager {
 	return NewSingleConditionManager(status.manage(), AppConditionRouteReady, "Route")
@@ -81,7 +105,7 @@ func (status *AppStatus) PropagateSourceStatus(source *Source) {
 	cond := source.Status.GetCondition(SourceConditionSucceeded)
 	if PropagateCondition(status.manage(), AppConditionSourceReady, cond) {
 		status.LatestReadySourceName = source.Name
-		status.SourceStatusFields = source.Status.SourceStatusFields
+		source.Status.SourceStatusFields.DeepCopyInto(&status.SourceStatusFields)
 	}
 }
 
@@ -96,12 +120,210 @@ func (status *AppStatus) PropagateKnativeServiceStatus(service *serving.Service)
 	}
 }
 
+// PropagateProcessesStatus updates the processes condition to reflect the
+// Deployments running the processes other than web.
+func (status *AppStatus) PropagateProcessesStatus(deployments []*appsv1.Deployment) {
+	for _, deployment := range deployments {
+		if deployment.Generation > deployment.Status.ObservedGeneration {
+			status.manage().MarkUnknown(AppConditionProcessesReady, "DeploymentPending",
+				"Waiting for Deployment %q to be observed", deployment.Name)
+			return
+		}
+
+		for _, cond := range deployment.Status.Conditions {
+			if cond.Type == appsv1.DeploymentProgressing && cond.Status == corev1.ConditionFalse {
+				status.manage().MarkFalse(AppConditionProcessesReady, cond.Reason,
+					"Deployment %q failed: %s", deployment.Name, cond.Message)
+				return
+			}
+		}
+
+		var want int32 = 1
+		if deployment.Spec.Replicas != nil {
+			want = *deployment.Spec.Replicas
+		}
+
+		if deployment.Status.UpdatedReplicas < want || deployment.Status.AvailableReplicas < want {
+			status.manage().MarkUnknown(AppConditionProcessesReady, "DeploymentUnavailable",
+				"Deployment %q has %d of %d instances available", deployment.Name, deployment.Status.AvailableReplicas, want)
+			return
+		}
+	}
+
+	status.manage().MarkTrue(AppConditionProcessesReady)
+}
+
+// PropagateRolloutStatus updates the rollout state using the latest ready
+// revision of the Knative Service. If the rollout is paused on a timed step,
+// the duration until the step ends is returned so the App can be checked
+// again.
+func (status *AppStatus) PropagateRolloutStatus(spec *AppSpec, now time.Time) time.Duration {
+	// Stopped apps have their Knative Service deleted so any revisions we
+	// held on to are gone.
+	if spec.Instances.Stopped {
+		status.Rollout = AppStatusRollout{}
+		return 0
+	}
+
+	// Rolled back apps send all traffic to the pinned revision. It becomes
+	// the stable revision of the next rollout once the App is changed again.
+	if pinned := spec.Rollout.RollbackRevisionName; pinned != "" {
+		base := status.LatestCreatedRevisionName
+		if status.Rollout.Phase == RolloutPhaseRolledBack &&
+			status.Rollout.StableRevisionName == pinned &&
+			status.Rollout.RollbackBaseRevisionName != "" {
+			base = status.Rollout.RollbackBaseRevisionName
+		}
+
+		status.Rollout = AppStatusRollout{
+			Phase:                    RolloutPhaseRolledBack,
+			StableRevisionName:       pinned,
+			RollbackBaseRevisionName: base,
+		}
+		return 0
+	}
+
+	// Only trust revision names once the service has settled, otherwise they
+	// may be stale.
+	cond := status.GetCondition(AppConditionKnativeServiceReady)
+	if cond == nil || !cond.IsTrue() {
+		return 0
+	}
+
+	latest := status.LatestReadyRevisionName
+	if latest == "" {
+		return 0
+	}
+
+	rollout := &status.Rollout
+	if !spec.Rollout.HoldsStableRevision() || rollout.StableRevisionName == "" || rollout.StableRevisionName == latest {
+		status.Rollout = AppStatusRollout{
+			Phase:              RolloutPhaseStable,
+			StableRevisionName: latest,
+		}
+		return 0
+	}
+
+	switch latest {
+	case spec.Rollout.PromotedRevisionName:
+		rollout.CanaryRevisionName = latest
+		rollout.promote()
+		return 0
+
+	case spec.Rollout.AbortedRevisionName:
+		rollout.Phase = RolloutPhaseAborted
+		rollout.CanaryRevisionName = latest
+		rollout.CanaryPercent = 0
+		rollout.Step = 0
+		rollout.StepStartTime = nil
+		return 0
+	}
+
+	if rollout.CanaryRevisionName != latest || rollout.Phase != RolloutPhaseCanary {
+		rollout.Phase = RolloutPhaseCanary
+		rollout.CanaryRevisionName = latest
+		rollout.Step = 0
+		rollout.StepStartTime = nil
+	}
+
+	if rollout.StepStartTime == nil {
+		rollout.StepStartTime = &metav1.Time{Time: now}
+	}
+
+	if !spec.Rollout.IsCanary() {
+		// Blue/green rollouts wait to be promoted.
+		rollout.CanaryPercent = 0
+		return 0
+	}
+
+	if len(spec.Rollout.Steps) == 0 {
+		rollout.CanaryPercent = spec.Rollout.CanaryPercent
+		return 0
+	}
+
+	for ; rollout.Step < len(spec.Rollout.Steps); rollout.Step++ {
+		step := spec.Rollout.Steps[rollout.Step]
+		rollout.CanaryPercent = step.Percent
+
+		// Steps without a pause are held until the canary is promoted.
+		if step.Pause.Duration <= 0 {
+			return 0
+		}
+
+		stepEnd := rollout.StepStartTime.Add(step.Pause.Duration)
+		if now.Before(stepEnd) {
+			return stepEnd.Sub(now)
+		}
+
+		rollout.StepStartTime = &metav1.Time{Time: stepEnd}
+	}
+
+	rollout.promote()
+	return 0
+}
+
+// RollbackSuperseded returns true if the App is pinned to a revision it was
+// rolled back to but a newer revision has been created since, e.g. because
+// the App was scaled or its environment changed.
+func (status *AppStatus) RollbackSuperseded(spec *AppSpec) bool {
+	rollout := status.Rollout
+	pinned := spec.Rollout.RollbackRevisionName
+
+	return pinned != "" &&
+		rollout.Phase == RolloutPhaseRolledBack &&
+		rollout.StableRevisionName == pinned &&
+		rollout.RollbackBaseRevisionName != "" &&
+		status.LatestCreatedRevisionName != "" &&
+		status.LatestCreatedRevisionName != rollout.RollbackBaseRevisionName
+}
+
+// PropagateRevisionHistory records a ready revision in the App's history if it
+// isn't already there. Only the newest limit revisions are kept.
+func (status *AppStatus) PropagateRevisionHistory(revision AppRevision, limit int) {
+	if status.FindRevision(revision.RevisionName) == nil {
+		status.History = append([]AppRevision{revision}, status.History...)
+	}
+
+	if len(status.History) > limit {
+		status.History = status.History[:limit]
+	}
+
+	if len(status.History) == 0 {
+		status.History = nil
+	}
+}
+
+// FindRevision returns the revision with the given name from the App's
+// history or nil if it doesn't exist.
+func (status *AppStatus) FindRevision(revisionName string) *AppRevision {
+	for i := range status.History {
+		if status.History[i].RevisionName == revisionName {
+			return &status.History[i]
+		}
+	}
+
+	return nil
+}
+
+// promote makes the canary the stable revision.
+func (rollout *AppStatusRollout) promote() {
+	*rollout = AppStatusRollout{
+		Phase:              RolloutPhaseStable,
+		StableRevisionName: rollout.CanaryRevisionName,
+	}
+}
+
 // MarkSpaceHealthy notes that the space was able to be retrieved and
 // defaults can be applied from it.
 func (status *AppStatus) MarkSpaceHealthy() {
 	status.manage().MarkTrue(AppConditionSpaceReady)
 }
 
+// MarkNetworkPolicyReady notes that the App's allow list is enforced.
+func (status *AppStatus) MarkNetworkPolicyReady() {
+	status.manage().MarkTrue(AppConditionNetworkPolicyReady)
+}
+
 // MarkSpaceUnhealthy notes that the space was could not be retrieved.
 func (status *AppStatus) MarkSpaceUnhealthy(reason, message string) {
 	status.manage().MarkFalse(AppConditionSpaceReady, reason, message)
@@ -14,4 +14,430 @@
 
 package v1alpha1
 
+import (
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/kf/testutil"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
 // TODO (#403) Test Methods
+
+func TestAppStatus_PropagateSourceStatus_failed(t *testing.T) {
+	source := &Source{}
+	source.Name = "my-source"
+	source.Status.InitializeConditions()
+	source.Status.PropagateBuildStatus(failedBuild())
+
+	status := &AppStatus{}
+	status.InitializeConditions()
+	status.PropagateSourceStatus(source)
+
+	cond := status.manage().GetCondition(AppConditionSourceReady)
+	testutil.AssertEqual(t, "status", corev1.ConditionFalse, cond.Status)
+	testutil.AssertEqual(t, "message", `Build failed: step "build" exited with code 1: npm ERR! missing script: start`, cond.Message)
+	testutil.AssertEqual(t, "LatestCreatedSourceName", "my-source", status.LatestCreatedSourceName)
+	testutil.AssertEqual(t, "LatestReadySourceName", "", status.LatestReadySourceName)
+}
+
+func TestAppStatus_PropagateProcessesStatus(t *testing.T) {
+	deployment := func(replicas, available int32, conditions ...appsv1.DeploymentCondition) *appsv1.Deployment {
+		d := &appsv1.Deployment{}
+		d.Name = "my-app-worker"
+		d.Generation = 2
+		d.Spec.Replicas = &replicas
+		d.Status.ObservedGeneration = 2
+		d.Status.UpdatedReplicas = available
+		d.Status.AvailableReplicas = available
+		d.Status.Conditions = conditions
+		return d
+	}
+
+	stale := deployment(1, 1)
+	stale.Status.ObservedGeneration = 1
+
+	cases := map[string]struct {
+		deployments []*appsv1.Deployment
+		wantStatus  corev1.ConditionStatus
+		wantReason  string
+	}{
+		"no processes": {
+			wantStatus: corev1.ConditionTrue,
+		},
+		"available": {
+			deployments: []*appsv1.Deployment{deployment(2, 2), deployment(0, 0)},
+			wantStatus:  corev1.ConditionTrue,
+		},
+		"not observed": {
+			deployments: []*appsv1.Deployment{stale},
+			wantStatus:  corev1.ConditionUnknown,
+			wantReason:  "DeploymentPending",
+		},
+		"scaling up": {
+			deployments: []*appsv1.Deployment{deployment(2, 1)},
+			wantStatus:  corev1.ConditionUnknown,
+			wantReason:  "DeploymentUnavailable",
+		},
+		"failed": {
+			deployments: []*appsv1.Deployment{deployment(2, 1, appsv1.DeploymentCondition{
+				Type:    appsv1.DeploymentProgressing,
+				Status:  corev1.ConditionFalse,
+				Reason:  "ProgressDeadlineExceeded",
+				Message: "ReplicaSet has timed out progressing.",
+			})},
+			wantStatus: corev1.ConditionFalse,
+			wantReason: "ProgressDeadlineExceeded",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &AppStatus{}
+			status.InitializeConditions()
+			status.PropagateProcessesStatus(tc.deployments)
+
+			cond := status.GetCondition(AppConditionProcessesReady)
+			testutil.AssertEqual(t, "status", tc.wantStatus, cond.Status)
+			testutil.AssertEqual(t, "reason", tc.wantReason, cond.Reason)
+		})
+	}
+}
+
+func TestAppStatus_PropagateRolloutStatus(t *testing.T) {
+	now := time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+	started := &metav1.Time{Time: now.Add(-3 * time.Minute)}
+
+	canary := AppSpecRollout{Strategy: RolloutStrategyCanary, CanaryPercent: 10}
+	stepped := AppSpecRollout{
+		Strategy: RolloutStrategyCanary,
+		Steps: []AppSpecRolloutStep{
+			{Percent: 10, Pause: metav1.Duration{Duration: 2 * time.Minute}},
+			{Percent: 50, Pause: metav1.Duration{Duration: 5 * time.Minute}},
+		},
+	}
+
+	cases := map[string]struct {
+		spec            AppSpec
+		serviceReady    bool
+		latest          string
+		current         AppStatusRollout
+		expected        AppStatusRollout
+		expectedRequeue time.Duration
+	}{
+		"service not ready keeps state": {
+			spec:     AppSpec{Rollout: canary},
+			latest:   "rev-2",
+			current:  AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-1"},
+			expected: AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-1"},
+		},
+		"stopped resets state": {
+			spec:         AppSpec{Rollout: canary, Instances: AppSpecInstances{Stopped: true}},
+			serviceReady: true,
+			latest:       "rev-2",
+			current:      AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-1"},
+			expected:     AppStatusRollout{},
+		},
+		"immediate uses latest": {
+			spec:         AppSpec{Rollout: AppSpecRollout{Strategy: RolloutStrategyImmediate}},
+			serviceReady: true,
+			latest:       "rev-2",
+			current:      AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-1"},
+			expected:     AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-2"},
+		},
+		"rollback pins revision": {
+			spec:         AppSpec{Rollout: AppSpecRollout{RollbackRevisionName: "rev-1"}},
+			serviceReady: true,
+			latest:       "rev-2",
+			current:      AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-2"},
+			expected: AppStatusRollout{
+				Phase:                    RolloutPhaseRolledBack,
+				StableRevisionName:       "rev-1",
+				RollbackBaseRevisionName: "rev-2",
+			},
+		},
+		"rollback keeps base revision": {
+			spec:         AppSpec{Rollout: AppSpecRollout{RollbackRevisionName: "rev-1"}},
+			serviceReady: true,
+			latest:       "rev-3",
+			current: AppStatusRollout{
+				Phase:                    RolloutPhaseRolledBack,
+				StableRevisionName:       "rev-1",
+				RollbackBaseRevisionName: "rev-2",
+			},
+			expected: AppStatusRollout{
+				Phase:                    RolloutPhaseRolledBack,
+				StableRevisionName:       "rev-1",
+				RollbackBaseRevisionName: "rev-2",
+			},
+		},
+		"new revision after rollback starts canary": {
+			spec:         AppSpec{Rollout: canary},
+			serviceReady: true,
+			latest:       "rev-3",
+			current:      AppStatusRollout{Phase: RolloutPhaseRolledBack, StableRevisionName: "rev-1"},
+			expected: AppStatusRollout{
+				Phase:              RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-3",
+				CanaryPercent:      10,
+				StepStartTime:      &metav1.Time{Time: now},
+			},
+		},
+		"first revision becomes stable": {
+			spec:         AppSpec{Rollout: canary},
+			serviceReady: true,
+			latest:       "rev-1",
+			expected:     AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-1"},
+		},
+		"new revision starts canary": {
+			spec:         AppSpec{Rollout: canary},
+			serviceReady: true,
+			latest:       "rev-2",
+			current:      AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-1"},
+			expected: AppStatusRollout{
+				Phase:              RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+				CanaryPercent:      10,
+				StepStartTime:      &metav1.Time{Time: now},
+			},
+		},
+		"blue green holds new revision": {
+			spec:         AppSpec{Rollout: AppSpecRollout{Strategy: RolloutStrategyBlueGreen}},
+			serviceReady: true,
+			latest:       "rev-2",
+			current:      AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-1"},
+			expected: AppStatusRollout{
+				Phase:              RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+				StepStartTime:      &metav1.Time{Time: now},
+			},
+		},
+		"promoted canary becomes stable": {
+			spec: AppSpec{Rollout: AppSpecRollout{
+				Strategy:             RolloutStrategyCanary,
+				CanaryPercent:        10,
+				PromotedRevisionName: "rev-2",
+			}},
+			serviceReady: true,
+			latest:       "rev-2",
+			current: AppStatusRollout{
+				Phase:              RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+				CanaryPercent:      10,
+			},
+			expected: AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-2"},
+		},
+		"aborted canary gets no traffic": {
+			spec: AppSpec{Rollout: AppSpecRollout{
+				Strategy:            RolloutStrategyCanary,
+				CanaryPercent:       10,
+				AbortedRevisionName: "rev-2",
+			}},
+			serviceReady: true,
+			latest:       "rev-2",
+			current: AppStatusRollout{
+				Phase:              RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+				CanaryPercent:      10,
+				StepStartTime:      started,
+			},
+			expected: AppStatusRollout{
+				Phase:              RolloutPhaseAborted,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+			},
+		},
+		"step waits for pause": {
+			spec:         AppSpec{Rollout: stepped},
+			serviceReady: true,
+			latest:       "rev-2",
+			current: AppStatusRollout{
+				Phase:              RolloutPhaseStable,
+				StableRevisionName: "rev-1",
+			},
+			expected: AppStatusRollout{
+				Phase:              RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+				CanaryPercent:      10,
+				StepStartTime:      &metav1.Time{Time: now},
+			},
+			expectedRequeue: 2 * time.Minute,
+		},
+		"step advances after pause": {
+			spec:         AppSpec{Rollout: stepped},
+			serviceReady: true,
+			latest:       "rev-2",
+			current: AppStatusRollout{
+				Phase:              RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+				CanaryPercent:      10,
+				StepStartTime:      started,
+			},
+			expected: AppStatusRollout{
+				Phase:              RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+				CanaryPercent:      50,
+				Step:               1,
+				StepStartTime:      &metav1.Time{Time: now.Add(-time.Minute)},
+			},
+			expectedRequeue: 4 * time.Minute,
+		},
+		"last step promotes": {
+			spec:         AppSpec{Rollout: stepped},
+			serviceReady: true,
+			latest:       "rev-2",
+			current: AppStatusRollout{
+				Phase:              RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+				CanaryPercent:      50,
+				Step:               1,
+				StepStartTime:      &metav1.Time{Time: now.Add(-10 * time.Minute)},
+			},
+			expected: AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-2"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &AppStatus{}
+			status.InitializeConditions()
+			if tc.serviceReady {
+				status.manage().MarkTrue(AppConditionKnativeServiceReady)
+			}
+			status.LatestReadyRevisionName = tc.latest
+			status.LatestCreatedRevisionName = tc.latest
+			status.Rollout = tc.current
+
+			requeue := status.PropagateRolloutStatus(&tc.spec, now)
+
+			testutil.AssertEqual(t, "rollout", tc.expected, status.Rollout)
+			testutil.AssertEqual(t, "requeue", tc.expectedRequeue, requeue)
+		})
+	}
+}
+
+func TestAppStatus_RollbackSuperseded(t *testing.T) {
+	rolledBack := AppStatusRollout{
+		Phase:                    RolloutPhaseRolledBack,
+		StableRevisionName:       "rev-1",
+		RollbackBaseRevisionName: "rev-2",
+	}
+
+	cases := map[string]struct {
+		pinned        string
+		latestCreated string
+		rollout       AppStatusRollout
+		expected      bool
+	}{
+		"not rolled back": {
+			latestCreated: "rev-3",
+			rollout:       AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-3"},
+			expected:      false,
+		},
+		"unchanged since rollback": {
+			pinned:        "rev-1",
+			latestCreated: "rev-2",
+			rollout:       rolledBack,
+			expected:      false,
+		},
+		"changed since rollback": {
+			pinned:        "rev-1",
+			latestCreated: "rev-3",
+			rollout:       rolledBack,
+			expected:      true,
+		},
+		"pin not yet observed": {
+			pinned:        "rev-1",
+			latestCreated: "rev-3",
+			rollout:       AppStatusRollout{Phase: RolloutPhaseStable, StableRevisionName: "rev-3"},
+			expected:      false,
+		},
+		"pinned to another revision": {
+			pinned:        "rev-0",
+			latestCreated: "rev-3",
+			rollout:       rolledBack,
+			expected:      false,
+		},
+		"no base revision": {
+			pinned:        "rev-1",
+			latestCreated: "rev-3",
+			rollout:       AppStatusRollout{Phase: RolloutPhaseRolledBack, StableRevisionName: "rev-1"},
+			expected:      false,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			spec := &AppSpec{Rollout: AppSpecRollout{RollbackRevisionName: tc.pinned}}
+			status := &AppStatus{}
+			status.LatestCreatedRevisionName = tc.latestCreated
+			status.Rollout = tc.rollout
+
+			testutil.AssertEqual(t, "superseded", tc.expected, status.RollbackSuperseded(spec))
+		})
+	}
+}
+
+func TestAppStatus_PropagateRevisionHistory(t *testing.T) {
+	rev := func(name string) AppRevision {
+		return AppRevision{RevisionName: name, Image: "gcr.io/" + name}
+	}
+
+	cases := map[string]struct {
+		history  []AppRevision
+		revision AppRevision
+		limit    int
+		expected []AppRevision
+	}{
+		"first revision": {
+			revision: rev("rev-1"),
+			limit:    10,
+			expected: []AppRevision{rev("rev-1")},
+		},
+		"new revision is prepended": {
+			history:  []AppRevision{rev("rev-1")},
+			revision: rev("rev-2"),
+			limit:    10,
+			expected: []AppRevision{rev("rev-2"), rev("rev-1")},
+		},
+		"existing revision isn't duplicated": {
+			history:  []AppRevision{rev("rev-2"), rev("rev-1")},
+			revision: rev("rev-2"),
+			limit:    10,
+			expected: []AppRevision{rev("rev-2"), rev("rev-1")},
+		},
+		"oldest revisions are dropped": {
+			history:  []AppRevision{rev("rev-2"), rev("rev-1")},
+			revision: rev("rev-3"),
+			limit:    2,
+			expected: []AppRevision{rev("rev-3"), rev("rev-2")},
+		},
+		"zero limit keeps nothing": {
+			history:  []AppRevision{rev("rev-1")},
+			revision: rev("rev-2"),
+			limit:    0,
+			expected: nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := &AppStatus{History: tc.history}
+
+			status.PropagateRevisionHistory(tc.revision, tc.limit)
+
+			testutil.AssertEqual(t, "history", tc.expected, status.History)
+		})
+	}
+}
@@ -65,6 +65,12 @@ func (app *App) ComponentLabels(component string) map[string]string {
 	}
 }
 
+// BuildCacheLabels gets the labels of the PersistentVolumeClaim that caches
+// the App's buildpack builds.
+func (app *App) BuildCacheLabels() map[string]string {
+	return app.ComponentLabels("build-cache")
+}
+
 // AppSpec is the desired configuration for an App.
 type AppSpec struct {
 
@@ -83,6 +89,56 @@ type AppSpec struct {
 	// +optional
 	// +patchStrategy=merge
 	Routes []RouteSpecFields `json:"routes,omitempty"`
+
+	// Rollout defines how new revisions of the App receive traffic.
+	// +optional
+	Rollout AppSpecRollout `json:"rollout,omitempty"`
+
+	// RevisionHistoryLimit is the number of previous revisions to keep in the
+	// App's history so they can be rolled back to.
+	// +optional
+	RevisionHistoryLimit *int `json:"revisionHistoryLimit,omitempty"`
+
+	// NetworkPolicy restricts which Apps can reach this App over internal
+	// routes.
+	// +optional
+	NetworkPolicy AppSpecNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// Processes are the App's processes other than web, like workers. They
+	// run the App's image with their own command and don't receive traffic.
+	// +optional
+	Processes []AppSpecProcess `json:"processes,omitempty"`
+}
+
+const (
+	// ProcessTypeWeb is the type of the App's process that receives traffic.
+	// It's configured by the App's Template and Instances.
+	ProcessTypeWeb = "web"
+
+	// ProcessTypeLabel is set on the Deployments and Pods of an App's
+	// processes to the process type.
+	ProcessTypeLabel = "kf.dev/process-type"
+)
+
+// AppSpecProcess is a process of an App other than web.
+type AppSpecProcess struct {
+
+	// Type is the name of the process, e.g. worker. It's unique within the
+	// App and can't be web.
+	Type string `json:"type"`
+
+	// Command is the shell command that starts the process.
+	Command string `json:"command"`
+
+	// Instances is the number of instances of the process to run. Processes
+	// aren't autoscaled and have no instances while the App is stopped.
+	// +optional
+	Instances *int `json:"instances,omitempty"`
+
+	// Resources are the compute resources of each instance. Resources that
+	// aren't set are copied from the App's Template.
+	// +optional
+	Resources core.ResourceRequirements `json:"resources,omitempty"`
 }
 
 // AppSpecTemplate defines an app's runtime configuration.
@@ -92,6 +148,12 @@ type AppSpecTemplate struct {
 	// Updating sub-values will trigger a new value.
 	UpdateRequests int `json:"updateRequests"`
 
+	// Command is the shell command that starts the App instead of the
+	// image's default process. Buildpack apps run it with the buildpack
+	// launcher so it overrides the process the buildpacks detected.
+	// +optional
+	Command string `json:"command,omitempty"`
+
 	// Template is a PodSpec with additional restrictions.
 	// The image name is ignored.
 	// The Spec contains configuration for the App's Pod.
@@ -117,6 +179,84 @@ type AppSpecInstances struct {
 	Max *int `json:"max,omitempty"`
 }
 
+const (
+	// RolloutStrategyImmediate sends all traffic to a new revision as soon as
+	// it becomes ready.
+	RolloutStrategyImmediate = "Immediate"
+	// RolloutStrategyCanary sends a percentage of traffic to a new revision
+	// and holds the previous revision until the new one is promoted.
+	RolloutStrategyCanary = "Canary"
+	// RolloutStrategyBlueGreen sends no traffic to a new revision until it is
+	// promoted. The new revision can be reached using its canary tag.
+	RolloutStrategyBlueGreen = "BlueGreen"
+)
+
+// AppSpecNetworkPolicy is an allow list of Apps that can send traffic to an
+// App over internal routes.
+type AppSpecNetworkPolicy struct {
+	// AllowedSourceApps contains the names of Apps in the same space that can
+	// reach this App over internal routes. If empty, every App can.
+	// +optional
+	AllowedSourceApps []string `json:"allowedSourceApps,omitempty"`
+}
+
+// AppSpecRollout defines how traffic is shifted to new revisions of an App.
+type AppSpecRollout struct {
+
+	// Strategy is the rollout strategy, one of Immediate, Canary or
+	// BlueGreen. Defaults to Immediate.
+	// +optional
+	Strategy string `json:"strategy,omitempty"`
+
+	// CanaryPercent is the percent of traffic sent to a canary revision when
+	// no Steps are defined.
+	// +optional
+	CanaryPercent int `json:"canaryPercent,omitempty"`
+
+	// Steps is an ordered schedule used to shift traffic to a canary. When
+	// the last step completes the canary is promoted.
+	// +optional
+	Steps []AppSpecRolloutStep `json:"steps,omitempty"`
+
+	// PromotedRevisionName is the name of a canary revision that should
+	// receive all traffic.
+	// +optional
+	PromotedRevisionName string `json:"promotedRevisionName,omitempty"`
+
+	// AbortedRevisionName is the name of a canary revision that should stop
+	// receiving traffic.
+	// +optional
+	AbortedRevisionName string `json:"abortedRevisionName,omitempty"`
+
+	// RollbackRevisionName is the name of a revision from the App's history
+	// that should receive all traffic until the App is pushed or changed again.
+	// +optional
+	RollbackRevisionName string `json:"rollbackRevisionName,omitempty"`
+}
+
+// IsCanary returns true if new revisions should be rolled out as canaries.
+func (rollout *AppSpecRollout) IsCanary() bool {
+	return rollout.Strategy == RolloutStrategyCanary
+}
+
+// HoldsStableRevision returns true if traffic stays with the stable revision
+// until new revisions are promoted.
+func (rollout *AppSpecRollout) HoldsStableRevision() bool {
+	return rollout.IsCanary() || rollout.Strategy == RolloutStrategyBlueGreen
+}
+
+// AppSpecRolloutStep is a single step in a canary rollout.
+type AppSpecRolloutStep struct {
+
+	// Percent is the percent of traffic sent to the canary during the step.
+	Percent int `json:"percent"`
+
+	// Pause is how long the step lasts before advancing to the next one.
+	// A zero Pause holds the step until the canary is promoted.
+	// +optional
+	Pause metav1.Duration `json:"pause,omitempty"`
+}
+
 // MinAnnotationValue returns the value autoscaling.knative.dev/minScale should
 // be set to.
 func (instances *AppSpecInstances) MinAnnotationValue() string {
@@ -185,6 +325,79 @@ type AppStatus struct {
 	// LatestCreatedSourceName contains the name of the source that was most
 	// recently created.
 	LatestCreatedSourceName string `json:"latestSource,omitempty"`
+
+	// Rollout contains the state of the current rollout.
+	Rollout AppStatusRollout `json:"rollout,omitempty"`
+
+	// History contains the revisions that have been deployed for the App,
+	// newest first.
+	History []AppRevision `json:"history,omitempty"`
+}
+
+// AppRevision pairs a revision of an App with the Source and image that
+// produced it.
+type AppRevision struct {
+
+	// RevisionName is the name of the Knative revision.
+	RevisionName string `json:"revisionName"`
+
+	// SourceName is the name of the Source that built the image.
+	SourceName string `json:"sourceName,omitempty"`
+
+	// Image is the container image the revision ran.
+	Image string `json:"image"`
+
+	// ReadyTime is the time the revision was first seen ready.
+	ReadyTime metav1.Time `json:"readyTime,omitempty"`
+}
+
+const (
+	// RolloutPhaseStable means all traffic is going to the stable revision.
+	RolloutPhaseStable = "Stable"
+	// RolloutPhaseCanary means a canary revision is being rolled out
+	// alongside the stable revision.
+	RolloutPhaseCanary = "Canary"
+	// RolloutPhaseAborted means the canary was rolled back and all traffic is
+	// going to the stable revision.
+	RolloutPhaseAborted = "Aborted"
+	// RolloutPhaseRolledBack means all traffic is pinned to a previous
+	// revision from the App's history.
+	RolloutPhaseRolledBack = "RolledBack"
+)
+
+// AppStatusRollout is the state of an App's rollout.
+type AppStatusRollout struct {
+
+	// Phase is the phase of the rollout: Stable, Canary, Aborted or
+	// RolledBack.
+	Phase string `json:"phase,omitempty"`
+
+	// StableRevisionName is the revision that was most recently promoted.
+	StableRevisionName string `json:"stableRevisionName,omitempty"`
+
+	// CanaryRevisionName is the revision being rolled out.
+	CanaryRevisionName string `json:"canaryRevisionName,omitempty"`
+
+	// CanaryPercent is the percent of traffic going to the canary.
+	CanaryPercent int `json:"canaryPercent,omitempty"`
+
+	// Step is the index of the current step in the rollout schedule.
+	Step int `json:"step,omitempty"`
+
+	// StepStartTime is the time the current step started.
+	StepStartTime *metav1.Time `json:"stepStartTime,omitempty"`
+
+	// RollbackBaseRevisionName is the latest revision when the App was rolled
+	// back. A newer revision means the App changed after the rollback.
+	RollbackBaseRevisionName string `json:"rollbackBaseRevisionName,omitempty"`
+}
+
+// IsCanaryActive returns true if a canary revision is being rolled out
+// alongside the stable revision.
+func (rollout *AppStatusRollout) IsCanaryActive() bool {
+	return rollout.Phase == RolloutPhaseCanary &&
+		rollout.StableRevisionName != "" &&
+		rollout.CanaryRevisionName != ""
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -168,3 +168,16 @@ func ExampleApp_ComponentLabels() {
 	// managed-by: kf
 	// component: database
 }
+
+func ExampleApp_BuildCacheLabels() {
+	app := App{}
+	app.Name = "my-app"
+
+	labels := app.BuildCacheLabels()
+
+	fmt.Println("name:", labels[NameLabel])
+	fmt.Println("component:", labels[ComponentLabel])
+
+	// Output: name: my-app
+	// component: build-cache
+}
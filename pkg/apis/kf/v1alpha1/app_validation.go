@@ -19,6 +19,7 @@ import (
 
 	"github.com/knative/serving/pkg/apis/serving"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -38,7 +39,95 @@ func (app *App) Validate(ctx context.Context) (errs *apis.FieldError) {
 func (spec *AppSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
 	errs = errs.Also(ValidatePodSpec(spec.Template.Spec).ViaField("template.spec"))
+	errs = errs.Also(spec.Template.validateCommand().ViaField("template"))
 	errs = errs.Also(spec.Instances.Validate(ctx).ViaField("instances"))
+	errs = errs.Also(spec.Rollout.Validate(ctx).ViaField("rollout"))
+
+	if spec.RevisionHistoryLimit != nil && *spec.RevisionHistoryLimit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*spec.RevisionHistoryLimit, "revisionHistoryLimit"))
+	}
+
+	seenTypes := make(map[string]bool)
+	for i, process := range spec.Processes {
+		errs = errs.Also(process.Validate(ctx).ViaFieldIndex("processes", i))
+
+		if seenTypes[process.Type] {
+			errs = errs.Also((&apis.FieldError{
+				Message: "duplicate process type",
+				Paths:   []string{"type"},
+			}).ViaFieldIndex("processes", i))
+		}
+		seenTypes[process.Type] = true
+	}
+
+	return errs
+}
+
+// validateCommand checks that the start command isn't combined with a
+// command or args on the container, which it replaces.
+func (template *AppSpecTemplate) validateCommand() (errs *apis.FieldError) {
+	if template.Command == "" || len(template.Spec.Containers) == 0 {
+		return nil
+	}
+
+	container := template.Spec.Containers[0]
+	if len(container.Command) > 0 || len(container.Args) > 0 {
+		errs = errs.Also(&apis.FieldError{
+			Message: "the command can't be used with the container's command or args",
+			Paths:   []string{"command"},
+		})
+	}
+
+	return errs
+}
+
+// Validate checks that the process can be run next to the App's web process.
+func (process *AppSpecProcess) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch {
+	case process.Type == "":
+		errs = errs.Also(apis.ErrMissingField("type"))
+	case process.Type == ProcessTypeWeb:
+		errs = errs.Also(&apis.FieldError{
+			Message: "the web process is configured by the App's template",
+			Paths:   []string{"type"},
+		})
+	case len(validation.IsDNS1123Label(process.Type)) > 0:
+		errs = errs.Also(apis.ErrInvalidValue(process.Type, "type"))
+	}
+
+	if process.Command == "" {
+		errs = errs.Also(apis.ErrMissingField("command"))
+	}
+
+	if process.Instances != nil && *process.Instances < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*process.Instances, "instances"))
+	}
+
+	return errs
+}
+
+// Validate checks that the rollout strategy and its traffic percentages are
+// valid.
+func (rollout *AppSpecRollout) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch rollout.Strategy {
+	case "", RolloutStrategyImmediate, RolloutStrategyCanary, RolloutStrategyBlueGreen:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(rollout.Strategy, "strategy"))
+	}
+
+	if rollout.CanaryPercent < 0 || rollout.CanaryPercent >= 100 {
+		errs = errs.Also(apis.ErrOutOfBoundsValue(rollout.CanaryPercent, 0, 99, "canaryPercent"))
+	}
+
+	for i, step := range rollout.Steps {
+		if step.Percent <= 0 || step.Percent >= 100 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(step.Percent, 1, 99, "percent").ViaFieldIndex("steps", i))
+		}
+
+		if step.Pause.Duration < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(step.Pause.Duration.String(), "pause").ViaFieldIndex("steps", i))
+		}
+	}
 
 	return errs
 }
@@ -17,6 +17,7 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/kf/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
@@ -71,6 +72,98 @@ func TestApp_Validate(t *testing.T) {
 			},
 			want: apis.ErrMissingField("spec.template.spec.containers"),
 		},
+		"invalid revision history limit": {
+			spec: App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: AppSpec{
+					Template:             goodTemplate,
+					Instances:            goodInstances,
+					RevisionHistoryLimit: intPtr(-1),
+				},
+			},
+			want: apis.ErrInvalidValue(-1, "spec.revisionHistoryLimit"),
+		},
+		"valid processes": {
+			spec: App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: AppSpec{
+					Template:  goodTemplate,
+					Instances: goodInstances,
+					Processes: []AppSpecProcess{
+						{Type: "worker", Command: "bin/worker", Instances: intPtr(2)},
+						{Type: "clock", Command: "bin/clock"},
+					},
+				},
+			},
+		},
+		"invalid processes": {
+			spec: App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: AppSpec{
+					Template:  goodTemplate,
+					Instances: goodInstances,
+					Processes: []AppSpecProcess{
+						{Type: "worker", Command: "bin/worker", Instances: intPtr(-1)},
+						{Type: "worker", Command: "bin/other-worker"},
+						{Type: "web", Command: "bin/web"},
+						{Type: "Big_Worker"},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue(-1, "spec.processes[0].instances").
+				Also(&apis.FieldError{
+					Message: "duplicate process type",
+					Paths:   []string{"spec.processes[1].type"},
+				}).
+				Also(&apis.FieldError{
+					Message: "the web process is configured by the App's template",
+					Paths:   []string{"spec.processes[2].type"},
+				}).
+				Also(apis.ErrInvalidValue("Big_Worker", "spec.processes[3].type")).
+				Also(apis.ErrMissingField("spec.processes[3].command")),
+		},
+		"valid command": {
+			spec: App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: AppSpec{
+					Template: AppSpecTemplate{
+						Command: "bundle exec rails server",
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{}},
+						},
+					},
+					Instances: goodInstances,
+				},
+			},
+		},
+		"command with container args": {
+			spec: App{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: AppSpec{
+					Template: AppSpecTemplate{
+						Command: "bundle exec rails server",
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{{Args: []string{"--port", "8080"}}},
+						},
+					},
+					Instances: goodInstances,
+				},
+			},
+			want: &apis.FieldError{
+				Message: "the command can't be used with the container's command or args",
+				Paths:   []string{"spec.template.command"},
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -138,6 +231,68 @@ func TestAppSpecInstances_Validate(t *testing.T) {
 	}
 }
 
+func TestAppSpecRollout_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec AppSpecRollout
+		want *apis.FieldError
+	}{
+		"blank": {
+			spec: AppSpecRollout{},
+		},
+		"immediate": {
+			spec: AppSpecRollout{Strategy: RolloutStrategyImmediate},
+		},
+		"canary with steps": {
+			spec: AppSpecRollout{
+				Strategy: RolloutStrategyCanary,
+				Steps: []AppSpecRolloutStep{
+					{Percent: 10, Pause: metav1.Duration{Duration: time.Minute}},
+					{Percent: 50},
+				},
+			},
+		},
+		"blue green": {
+			spec: AppSpecRollout{Strategy: RolloutStrategyBlueGreen},
+		},
+		"unknown strategy": {
+			spec: AppSpecRollout{Strategy: "Shadow"},
+			want: apis.ErrInvalidValue("Shadow", "strategy"),
+		},
+		"canary percent too high": {
+			spec: AppSpecRollout{Strategy: RolloutStrategyCanary, CanaryPercent: 100},
+			want: apis.ErrOutOfBoundsValue(100, 0, 99, "canaryPercent"),
+		},
+		"canary percent negative": {
+			spec: AppSpecRollout{Strategy: RolloutStrategyCanary, CanaryPercent: -1},
+			want: apis.ErrOutOfBoundsValue(-1, 0, 99, "canaryPercent"),
+		},
+		"step percent zero": {
+			spec: AppSpecRollout{
+				Strategy: RolloutStrategyCanary,
+				Steps:    []AppSpecRolloutStep{{Percent: 0}},
+			},
+			want: apis.ErrOutOfBoundsValue(0, 1, 99, "percent").ViaFieldIndex("steps", 0),
+		},
+		"step pause negative": {
+			spec: AppSpecRollout{
+				Strategy: RolloutStrategyCanary,
+				Steps: []AppSpecRolloutStep{
+					{Percent: 10, Pause: metav1.Duration{Duration: -time.Second}},
+				},
+			},
+			want: apis.ErrInvalidValue("-1s", "pause").ViaFieldIndex("steps", 0),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.spec.Validate(context.Background())
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
 func TestValidatePodSpec(t *testing.T) {
 	cases := map[string]struct {
 		spec corev1.PodSpec
@@ -54,6 +54,8 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		&SpaceList{},
 		&Route{},
 		&RouteList{},
+		&Task{},
+		&TaskList{},
 		&metav1.Status{},
 	)
 
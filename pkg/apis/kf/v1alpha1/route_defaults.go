@@ -17,18 +17,44 @@ package v1alpha1
 import (
 	"context"
 	"path"
+	"strconv"
 
 	"github.com/google/kf/pkg/kf/algorithms"
 )
 
+const (
+	// DefaultRouteWeight is the weight given to Apps bound to a Route that
+	// don't have an explicit weight.
+	DefaultRouteWeight = 1
+
+	// WildcardHostname is the hostname of routes that match every subdomain
+	// of their domain.
+	WildcardHostname = "*"
+)
+
 // GenerateRouteName creates the deterministic name for a Route.
 func GenerateRouteName(hostname, domain, urlPath string) string {
 	return GenerateName(hostname, domain, path.Join("/", urlPath))
 }
 
+// GenerateTCPRouteName creates the deterministic name for a TCP Route.
+func GenerateTCPRouteName(domain string, port int32) string {
+	return GenerateName("tcp", domain, strconv.Itoa(int(port)))
+}
+
 // GenerateRouteNameFromSpec creates the deterministic name for a Route.
 func GenerateRouteNameFromSpec(spec RouteSpecFields) string {
-	return GenerateName(spec.Hostname, spec.Domain, spec.Path)
+	if spec.IsTCP() {
+		return GenerateTCPRouteName(spec.Domain, spec.Port)
+	}
+
+	// Exact and prefix routes on the same path can be bound to different
+	// Apps.
+	if spec.IsExactPath() {
+		return GenerateName(spec.Hostname, spec.Domain, path.Join("/", spec.Path), "exact")
+	}
+
+	return GenerateRouteName(spec.Hostname, spec.Domain, spec.Path)
 }
 
 // SetDefaults implements apis.Defaultable
@@ -42,12 +68,36 @@ func (k *RouteSpec) SetDefaults(ctx context.Context) {
 		algorithms.Strings(k.AppNames),
 	).(algorithms.Strings))
 
+	// Drop weights for Apps that are no longer bound to the Route.
+	bound := make(map[string]bool)
+	for _, appName := range k.AppNames {
+		bound[appName] = true
+	}
+	for appName := range k.AppWeights {
+		if !bound[appName] {
+			delete(k.AppWeights, appName)
+		}
+	}
+
+	if len(k.AppWeights) == 0 {
+		k.AppWeights = nil
+	}
+
 	k.RouteSpecFields.SetDefaults(ctx)
 }
 
 // SetDefaults implements apis.Defaultable
 func (k *RouteSpecFields) SetDefaults(ctx context.Context) {
+	// TCP routes don't have a path.
+	if k.IsTCP() {
+		return
+	}
+
 	k.Path = path.Join("/", k.Path)
+
+	if k.PathMatch == "" {
+		k.PathMatch = PathMatchPrefix
+	}
 }
 
 // SetSpaceDefaults sets the default values for the Route based on the space's
@@ -75,4 +125,12 @@ func (k *RouteSpecFields) SetSpaceDefaults(space *Space) {
 			break
 		}
 	}
+
+	// Routes on an internal domain are always internal.
+	for _, domain := range space.Spec.Execution.Domains {
+		if domain.Domain == k.Domain && domain.Internal {
+			k.Internal = true
+			break
+		}
+	}
 }
@@ -40,12 +40,126 @@ func TestRoute_SetDefaults_DedupeAppNames(t *testing.T) {
 	testutil.AssertContainsAll(t, strings.Join(r.Spec.AppNames, ""), []string{"a", "b", "c", "d"})
 }
 
+func TestRoute_SetDefaults_AppWeights(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		spec     RouteSpec
+		expected map[string]int
+	}{
+		"no weights": {
+			spec: RouteSpec{AppNames: []string{"a", "b"}},
+		},
+		"weights for bound apps are kept": {
+			spec: RouteSpec{
+				AppNames:   []string{"a", "b"},
+				AppWeights: map[string]int{"a": 90, "b": 10},
+			},
+			expected: map[string]int{"a": 90, "b": 10},
+		},
+		"weights for unbound apps are removed": {
+			spec: RouteSpec{
+				AppNames:   []string{"a"},
+				AppWeights: map[string]int{"a": 90, "b": 10},
+			},
+			expected: map[string]int{"a": 90},
+		},
+		"empty weights are removed": {
+			spec: RouteSpec{
+				AppWeights: map[string]int{"b": 10},
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			tc.spec.SetDefaults(context.Background())
+
+			testutil.AssertEqual(t, "weights", tc.expected, tc.spec.AppWeights)
+		})
+	}
+}
+
+func ExampleRouteSpec_WeightFor() {
+	spec := RouteSpec{
+		AppNames:   []string{"blue", "green"},
+		AppWeights: map[string]int{"green": 3},
+	}
+
+	fmt.Println("blue:", spec.WeightFor("blue"))
+	fmt.Println("green:", spec.WeightFor("green"))
+
+	// Output: blue: 1
+	// green: 3
+}
+
 func ExampleRoute_SetDefaults_prefixRoutes() {
 	r := &Route{}
 	r.Spec.Path = "some-path"
 	r.SetDefaults(context.Background())
 
 	fmt.Println("Route:", r.Spec.Path)
+	fmt.Println("PathMatch:", r.Spec.PathMatch)
 
 	// Output: Route: /some-path
+	// PathMatch: Prefix
+}
+
+func ExampleGenerateRouteNameFromSpec_exactPath() {
+	prefix := RouteSpecFields{Domain: "example.com", Path: "/some-path"}
+	exact := prefix
+	exact.PathMatch = PathMatchExact
+
+	fmt.Println("Same name:", GenerateRouteNameFromSpec(prefix) == GenerateRouteNameFromSpec(exact))
+
+	// Output: Same name: false
+}
+
+func TestRouteSpecFields_SetSpaceDefaults(t *testing.T) {
+	t.Parallel()
+
+	space := &Space{}
+	space.Spec.Execution.Domains = []SpaceDomain{
+		{Domain: "example.com", Default: true},
+		{Domain: "apps.internal", Internal: true},
+	}
+
+	cases := map[string]struct {
+		fields   RouteSpecFields
+		expected RouteSpecFields
+	}{
+		"default domain": {
+			fields:   RouteSpecFields{Hostname: "some-host"},
+			expected: RouteSpecFields{Hostname: "some-host", Domain: "example.com"},
+		},
+		"internal domain": {
+			fields:   RouteSpecFields{Hostname: "some-host", Domain: "apps.internal"},
+			expected: RouteSpecFields{Hostname: "some-host", Domain: "apps.internal", Internal: true},
+		},
+		"internal route on public domain": {
+			fields:   RouteSpecFields{Domain: "example.com", Internal: true},
+			expected: RouteSpecFields{Domain: "example.com", Internal: true},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			tc.fields.SetSpaceDefaults(space)
+
+			testutil.AssertEqual(t, "fields", tc.expected, tc.fields)
+		})
+	}
+}
+
+func ExampleRoute_SetDefaults_tcpRoutes() {
+	r := &Route{}
+	r.Spec.Domain = "tcp.example.com"
+	r.Spec.Port = 1234
+	r.SetDefaults(context.Background())
+
+	fmt.Printf("Path: %q\n", r.Spec.Path)
+	fmt.Printf("PathMatch: %q\n", r.Spec.PathMatch)
+
+	// Output: Path: ""
+	// PathMatch: ""
 }
@@ -15,10 +15,163 @@
 package v1alpha1
 
 import (
+	"fmt"
+	"strings"
+
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
 )
 
 // GetGroupVersionKind returns the GroupVersionKind.
 func (r *Route) GetGroupVersionKind() schema.GroupVersionKind {
 	return SchemeGroupVersion.WithKind("Route")
 }
+
+// ConditionType represents a Route condition value
+const (
+	// RouteConditionReady is set when the route is configured
+	// and is serving traffic to its apps.
+	RouteConditionReady = apis.ConditionReady
+	// RouteConditionVirtualServiceReady is set when the VirtualService is
+	// ready.
+	RouteConditionVirtualServiceReady apis.ConditionType = "VirtualServiceReady"
+	// RouteConditionAppsBound is set when at least one App is bound to the
+	// route and all of the bound Apps exist.
+	RouteConditionAppsBound apis.ConditionType = "AppsBound"
+	// RouteConditionServicesReady is set when the Services TCP routes forward
+	// connections to are ready. Internal routes need them for Apps that only
+	// allow some sources, other HTTP routes don't need any.
+	RouteConditionServicesReady apis.ConditionType = "ServicesReady"
+	// RouteConditionServiceEntryReady is set when the ServiceEntry that lets
+	// Apps resolve an internal route's host is ready. Other routes don't
+	// need one.
+	RouteConditionServiceEntryReady apis.ConditionType = "ServiceEntryReady"
+	// RouteConditionAccessPolicyReady is set when the EnvoyFilter that
+	// enforces the access policies of the routes on the host is ready.
+	RouteConditionAccessPolicyReady apis.ConditionType = "AccessPolicyReady"
+)
+
+func (status *RouteStatus) manage() apis.ConditionManager {
+	return apis.NewLivingConditionSet(
+		RouteConditionVirtualServiceReady,
+		RouteConditionAppsBound,
+		RouteConditionServicesReady,
+		RouteConditionServiceEntryReady,
+		RouteConditionAccessPolicyReady,
+	).Manage(status)
+}
+
+// IsReady returns if the route is ready to serve traffic.
+func (status *RouteStatus) IsReady() bool {
+	return status.manage().IsHappy()
+}
+
+// GetCondition returns the condition by name.
+func (status *RouteStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return status.manage().GetCondition(t)
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (status *RouteStatus) InitializeConditions() {
+	status.manage().InitializeConditions()
+}
+
+// VirtualServiceCondition gets a manager for the state of the VirtualService.
+func (status *RouteStatus) VirtualServiceCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), RouteConditionVirtualServiceReady, "VirtualService")
+}
+
+// ServicesCondition gets a manager for the state of the Services backing a
+// TCP or internal route.
+func (status *RouteStatus) ServicesCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), RouteConditionServicesReady, "Services")
+}
+
+// MarkServicesReady notes that the Services backing the route are ready.
+func (status *RouteStatus) MarkServicesReady() {
+	status.manage().MarkTrue(RouteConditionServicesReady)
+}
+
+// ServiceEntryCondition gets a manager for the state of the ServiceEntry of
+// an internal route.
+func (status *RouteStatus) ServiceEntryCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), RouteConditionServiceEntryReady, "ServiceEntry")
+}
+
+// MarkServiceEntryReady notes that the route's host can be resolved.
+func (status *RouteStatus) MarkServiceEntryReady() {
+	status.manage().MarkTrue(RouteConditionServiceEntryReady)
+}
+
+// MarkPortNotReserved notes that the space doesn't allow TCP routes on the
+// route's domain and port.
+func (status *RouteStatus) MarkPortNotReserved(fields RouteSpecFields) {
+	status.manage().MarkFalse(RouteConditionVirtualServiceReady, "PortNotReserved",
+		fmt.Sprintf("Port %d isn't reserved for TCP routes on domain %q.", fields.Port, fields.Domain))
+}
+
+// PropagateVirtualServiceStatus updates the readiness of the Route based on
+// the VirtualService. VirtualServices are shared by every Route with the
+// same hostname and domain, so one reserved by another space is a conflict.
+func (status *RouteStatus) PropagateVirtualServiceStatus(route *Route, vs *networking.VirtualService) {
+	if space := vs.Annotations["space"]; space != route.GetNamespace() {
+		status.manage().MarkFalse(RouteConditionVirtualServiceReady, "Conflict",
+			fmt.Sprintf("The VirtualService %q is reserved for space %q.", vs.Name, space))
+		return
+	}
+
+	status.manage().MarkTrue(RouteConditionVirtualServiceReady)
+}
+
+// PropagateBoundApps updates the readiness of the Route based on the Apps
+// bound to it. missingApps contains the names of bound Apps that don't exist.
+func (status *RouteStatus) PropagateBoundApps(spec RouteSpec, missingApps []string) {
+	switch {
+	case len(missingApps) > 0:
+		status.manage().MarkFalse(RouteConditionAppsBound, "AppNotFound",
+			fmt.Sprintf("The bound Apps don't exist: %s", strings.Join(missingApps, ", ")))
+	case len(spec.AppNames) == 0:
+		status.manage().MarkFalse(RouteConditionAppsBound, "NoApps",
+			"No Apps are bound to the Route, requests will receive a 503.")
+	case !spec.HasTraffic():
+		status.manage().MarkFalse(RouteConditionAppsBound, "NoTraffic",
+			"All bound Apps have a weight of 0, requests will receive a 503.")
+	default:
+		status.manage().MarkTrue(RouteConditionAppsBound)
+	}
+}
+
+// AccessPolicyCondition gets a manager for the state of the EnvoyFilter that
+// enforces the route's access policy.
+func (status *RouteStatus) AccessPolicyCondition() SingleConditionManager {
+	return NewSingleConditionManager(status.manage(), RouteConditionAccessPolicyReady, "EnvoyFilter")
+}
+
+// MarkAccessPolicyReady notes that the route's access policy is enforced.
+func (status *RouteStatus) MarkAccessPolicyReady() {
+	status.manage().MarkTrue(RouteConditionAccessPolicyReady)
+}
+
+// PropagateAccessPolicy updates the readiness of the Route once the
+// EnvoyFilter enforcing its source CIDRs is ready. The ingress gateway's
+// Envoy has no local rate limit filter, so rate limits can't be enforced.
+func (status *RouteStatus) PropagateAccessPolicy(spec RouteSpec) {
+	if spec.AccessPolicy != nil && spec.AccessPolicy.RequestsPerSecond > 0 {
+		status.manage().MarkFalse(RouteConditionAccessPolicyReady, "RateLimitUnsupported",
+			fmt.Sprintf("The limit of %d requests per second can't be enforced by the ingress gateway.", spec.AccessPolicy.RequestsPerSecond))
+		return
+	}
+
+	status.MarkAccessPolicyReady()
+}
+
+// PropagateURL sets the address the Route serves traffic on.
+func (status *RouteStatus) PropagateURL(fields RouteSpecFields) {
+	status.URL = fields.URL()
+}
+
+func (status *RouteStatus) duck() *duckv1beta1.Status {
+	return &status.Status
+}
@@ -18,8 +18,37 @@ import (
 	"testing"
 
 	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/apis/duck"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+	apitesting "knative.dev/pkg/apis/testing"
 )
 
+func TestRouteDuckTypes(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		t    duck.Implementable
+	}{
+		{
+			name: "conditions",
+			t:    &duckv1beta1.Conditions{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := duck.VerifyType(&Route{}, test.t)
+			if err != nil {
+				t.Errorf("VerifyType(Route, %T) = %v", test.t, err)
+			}
+		})
+	}
+}
+
 func TestRouteGeneration(t *testing.T) {
 	route := Route{}
 	testutil.AssertEqual(t, "empty route generation", int64(0), route.GetGeneration())
@@ -28,3 +57,180 @@ func TestRouteGeneration(t *testing.T) {
 	route.SetGeneration(answer)
 	testutil.AssertEqual(t, "GetGeneration", answer, route.GetGeneration())
 }
+
+func initRouteTestStatus(t *testing.T) *RouteStatus {
+	t.Helper()
+	status := &RouteStatus{}
+	status.InitializeConditions()
+
+	// sanity check
+	apitesting.CheckConditionOngoing(status.duck(), RouteConditionReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), RouteConditionVirtualServiceReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), RouteConditionAppsBound, t)
+	apitesting.CheckConditionOngoing(status.duck(), RouteConditionServicesReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), RouteConditionServiceEntryReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), RouteConditionAccessPolicyReady, t)
+
+	return status
+}
+
+func TestRouteHappyPath(t *testing.T) {
+	t.Parallel()
+
+	route := &Route{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "some-space"},
+		Spec:       RouteSpec{AppNames: []string{"some-app"}},
+	}
+	vs := &networking.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{"space": "some-space"},
+		},
+	}
+
+	status := initRouteTestStatus(t)
+	status.PropagateVirtualServiceStatus(route, vs)
+	status.PropagateBoundApps(route.Spec, nil)
+	status.MarkServicesReady()
+	status.MarkServiceEntryReady()
+	status.MarkAccessPolicyReady()
+
+	apitesting.CheckConditionSucceeded(status.duck(), RouteConditionReady, t)
+	apitesting.CheckConditionSucceeded(status.duck(), RouteConditionVirtualServiceReady, t)
+	apitesting.CheckConditionSucceeded(status.duck(), RouteConditionAppsBound, t)
+	apitesting.CheckConditionSucceeded(status.duck(), RouteConditionServicesReady, t)
+	apitesting.CheckConditionSucceeded(status.duck(), RouteConditionServiceEntryReady, t)
+	apitesting.CheckConditionSucceeded(status.duck(), RouteConditionAccessPolicyReady, t)
+	testutil.AssertEqual(t, "IsReady", true, status.IsReady())
+}
+
+func TestRouteStatus_PropagateVirtualServiceStatus_conflict(t *testing.T) {
+	t.Parallel()
+
+	route := &Route{ObjectMeta: metav1.ObjectMeta{Namespace: "some-space"}}
+	vs := &networking.VirtualService{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "some-vs",
+			Annotations: map[string]string{"space": "other-space"},
+		},
+	}
+
+	status := initRouteTestStatus(t)
+	status.PropagateVirtualServiceStatus(route, vs)
+
+	apitesting.CheckConditionFailed(status.duck(), RouteConditionReady, t)
+	apitesting.CheckConditionFailed(status.duck(), RouteConditionVirtualServiceReady, t)
+	testutil.AssertEqual(t, "reason", "Conflict", status.GetCondition(RouteConditionVirtualServiceReady).Reason)
+}
+
+func TestRouteStatus_MarkPortNotReserved(t *testing.T) {
+	t.Parallel()
+
+	status := initRouteTestStatus(t)
+	status.MarkPortNotReserved(RouteSpecFields{Domain: "tcp.example.com", Port: 1024})
+
+	apitesting.CheckConditionFailed(status.duck(), RouteConditionReady, t)
+	apitesting.CheckConditionFailed(status.duck(), RouteConditionVirtualServiceReady, t)
+	testutil.AssertEqual(t, "reason", "PortNotReserved", status.GetCondition(RouteConditionVirtualServiceReady).Reason)
+}
+
+func TestRouteStatus_PropagateBoundApps(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		spec        RouteSpec
+		missingApps []string
+		wantStatus  bool
+		wantReason  string
+	}{
+		"bound apps": {
+			spec:       RouteSpec{AppNames: []string{"a", "b"}},
+			wantStatus: true,
+		},
+		"no apps": {
+			spec:       RouteSpec{},
+			wantReason: "NoApps",
+		},
+		"missing apps": {
+			spec:        RouteSpec{AppNames: []string{"a", "b"}},
+			missingApps: []string{"b"},
+			wantReason:  "AppNotFound",
+		},
+		"no traffic": {
+			spec: RouteSpec{
+				AppNames:   []string{"a"},
+				AppWeights: map[string]int{"a": 0},
+			},
+			wantReason: "NoTraffic",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := initRouteTestStatus(t)
+			status.PropagateBoundApps(tc.spec, tc.missingApps)
+
+			cond := status.GetCondition(RouteConditionAppsBound)
+			testutil.AssertEqual(t, "status", tc.wantStatus, cond.IsTrue())
+			testutil.AssertEqual(t, "reason", tc.wantReason, cond.Reason)
+		})
+	}
+}
+
+func TestRouteStatus_PropagateAccessPolicy(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		spec       RouteSpec
+		wantStatus bool
+		wantReason string
+	}{
+		"no policy": {
+			spec:       RouteSpec{},
+			wantStatus: true,
+		},
+		"source CIDRs": {
+			spec: RouteSpec{
+				AccessPolicy: &RouteSpecAccessPolicy{
+					AllowedSourceCIDRs: []string{"10.0.0.0/8"},
+				},
+			},
+			wantStatus: true,
+		},
+		"rate limit": {
+			spec: RouteSpec{
+				AccessPolicy: &RouteSpecAccessPolicy{
+					RequestsPerSecond: 10,
+				},
+			},
+			wantReason: "RateLimitUnsupported",
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := initRouteTestStatus(t)
+			status.PropagateAccessPolicy(tc.spec)
+
+			cond := status.GetCondition(RouteConditionAccessPolicyReady)
+			testutil.AssertEqual(t, "status", tc.wantStatus, cond.IsTrue())
+			testutil.AssertEqual(t, "reason", tc.wantReason, cond.Reason)
+		})
+	}
+}
+
+func TestRouteStatus_PropagateURL(t *testing.T) {
+	t.Parallel()
+
+	status := &RouteStatus{}
+	status.PropagateURL(RouteSpecFields{
+		Hostname: "some-host",
+		Domain:   "example.com",
+		Path:     "some-path",
+	})
+
+	testutil.AssertEqual(t, "url", &apis.URL{
+		Scheme: "http",
+		Host:   "some-host.example.com",
+		Path:   "/some-path",
+	}, status.URL)
+}
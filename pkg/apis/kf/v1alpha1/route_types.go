@@ -15,14 +15,16 @@
 package v1alpha1
 
 import (
+	"fmt"
 	"path"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
 )
 
 // +genclient
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
-// +genclient:noStatus
 
 // Route is a high level structure that encompasses an Istio VirtualService
 // and configuration applied to it.
@@ -33,6 +35,9 @@ type Route struct {
 
 	// +optional
 	Spec RouteSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status RouteStatus `json:"status,omitempty"`
 }
 
 // RouteSpec contains the specification for a route.
@@ -42,14 +47,120 @@ type RouteSpec struct {
 	// +patchStrategy=merge
 	AppNames []string `json:"appNames,omitempty"`
 
+	// AppWeights contains the relative amount of traffic each App in
+	// AppNames receives. Apps without a weight get DefaultRouteWeight. An App
+	// with a weight of 0 receives no traffic.
+	// +optional
+	AppWeights map[string]int `json:"appWeights,omitempty"`
+
+	// Timeout is the longest a request on the route can take, including
+	// retries. Requests that take longer get a 504.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retries controls how requests that fail are retried.
+	// +optional
+	Retries *RouteSpecRetries `json:"retries,omitempty"`
+
+	// Headers modifies the headers of the requests sent to the Apps and of
+	// the responses they send back.
+	// +optional
+	Headers *RouteSpecHeaders `json:"headers,omitempty"`
+
+	// AccessPolicy limits the clients that can reach the route and how
+	// often.
+	// +optional
+	AccessPolicy *RouteSpecAccessPolicy `json:"accessPolicy,omitempty"`
+
 	// RouteSpecFields contains the fields of a route.
 	RouteSpecFields `json:",inline"`
 }
 
+// WeightFor returns the relative weight of traffic sent to the given App.
+func (r *RouteSpec) WeightFor(appName string) int {
+	if weight, ok := r.AppWeights[appName]; ok {
+		return weight
+	}
+	return DefaultRouteWeight
+}
+
+// HasTraffic returns true if any of the bound Apps receive traffic.
+func (r *RouteSpec) HasTraffic() bool {
+	for _, appName := range r.AppNames {
+		if r.WeightFor(appName) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// RouteSpecRetries is the retry policy of a route.
+type RouteSpecRetries struct {
+	// Attempts is the number of times a failed request is retried.
+	Attempts int `json:"attempts"`
+
+	// PerTryTimeout is the longest each attempt can take.
+	// +optional
+	PerTryTimeout *metav1.Duration `json:"perTryTimeout,omitempty"`
+}
+
+// RouteSpecHeaders modifies the headers of a route's requests and responses.
+type RouteSpecHeaders struct {
+	// Request is applied to requests before they're sent to the Apps.
+	// +optional
+	Request *RouteSpecHeaderOperations `json:"request,omitempty"`
+
+	// Response is applied to responses before they're sent to the client.
+	// +optional
+	Response *RouteSpecHeaderOperations `json:"response,omitempty"`
+}
+
+// RouteSpecHeaderOperations contains changes made to a set of headers.
+type RouteSpecHeaderOperations struct {
+	// Set overwrites the headers with the given values.
+	// +optional
+	Set map[string]string `json:"set,omitempty"`
+
+	// Add appends the given values to the headers.
+	// +optional
+	Add map[string]string `json:"add,omitempty"`
+
+	// Remove deletes the headers.
+	// +optional
+	Remove []string `json:"remove,omitempty"`
+}
+
+// RouteSpecAccessPolicy limits the clients that can reach a route and how
+// often.
+type RouteSpecAccessPolicy struct {
+	// AllowedSourceCIDRs contains the IPv4 ranges of the clients that can
+	// reach the route. Requests from other clients get a 403. If empty, all
+	// clients are allowed.
+	// +optional
+	AllowedSourceCIDRs []string `json:"allowedSourceCIDRs,omitempty"`
+
+	// DeniedSourceCIDRs contains the IPv4 ranges of the clients that get a
+	// 403, even if they're in AllowedSourceCIDRs.
+	// +optional
+	DeniedSourceCIDRs []string `json:"deniedSourceCIDRs,omitempty"`
+
+	// RequestsPerSecond is the most requests per second the route accepts.
+	// If 0, requests aren't limited.
+	// +optional
+	RequestsPerSecond int `json:"requestsPerSecond,omitempty"`
+}
+
+// HasSourceCIDRs returns true if the policy limits the clients that can
+// reach the route.
+func (p *RouteSpecAccessPolicy) HasSourceCIDRs() bool {
+	return p != nil && (len(p.AllowedSourceCIDRs) > 0 || len(p.DeniedSourceCIDRs) > 0)
+}
+
 // RouteSpecFields contains the fields of a route.
 type RouteSpecFields struct {
 	// Hostname is the hostname or subdomain of the route (e.g, in
-	// hostname.example.com it would be hostname).
+	// hostname.example.com it would be hostname). A Hostname of "*" matches
+	// every subdomain of Domain that doesn't have a more specific route.
 	// +optional
 	Hostname string `json:"hostname,omitempty"`
 
@@ -61,10 +172,55 @@ type RouteSpecFields struct {
 	// Path is the URL path of the route.
 	// +optional
 	Path string `json:"path,omitempty"`
+
+	// PathMatch controls how requests are matched against Path. It defaults
+	// to PathMatchPrefix.
+	// +optional
+	PathMatch PathMatchType `json:"pathMatch,omitempty"`
+
+	// Internal routes are only reachable by Apps inside the cluster's service
+	// mesh.
+	// +optional
+	Internal bool `json:"internal,omitempty"`
+
+	// Port is the port on the ingress gateway reserved by a TCP route. Routes
+	// with a port are TCP routes and can't have a hostname or path.
+	// +optional
+	Port int32 `json:"port,omitempty"`
+}
+
+// PathMatchType is the way a route matches the paths of requests.
+type PathMatchType string
+
+const (
+	// PathMatchPrefix matches the route's path and every path below it.
+	PathMatchPrefix PathMatchType = "Prefix"
+	// PathMatchExact only matches the route's path.
+	PathMatchExact PathMatchType = "Exact"
+)
+
+// IsWildcard returns true if the route matches every subdomain of its domain.
+func (route RouteSpecFields) IsWildcard() bool {
+	return route.Hostname == WildcardHostname
+}
+
+// IsExactPath returns true if the route only matches its path and not the
+// paths below it.
+func (route RouteSpecFields) IsExactPath() bool {
+	return route.PathMatch == PathMatchExact
+}
+
+// IsTCP returns true if the route forwards TCP traffic rather than HTTP.
+func (route RouteSpecFields) IsTCP() bool {
+	return route.Port != 0
 }
 
 // String returns a RouteSpecFields converted into an address.
 func (route RouteSpecFields) String() string {
+	if route.IsTCP() {
+		return fmt.Sprintf("%s:%d", route.Domain, route.Port)
+	}
+
 	var hostnamePrefix string
 	if route.Hostname != "" {
 		hostnamePrefix = route.Hostname + "."
@@ -72,6 +228,37 @@ func (route RouteSpecFields) String() string {
 	return hostnamePrefix + route.Domain + path.Join("/", route.Path)
 }
 
+// URL returns the address of the route.
+func (route RouteSpecFields) URL() *apis.URL {
+	if route.IsTCP() {
+		return &apis.URL{
+			Scheme: "tcp",
+			Host:   fmt.Sprintf("%s:%d", route.Domain, route.Port),
+		}
+	}
+
+	host := route.Domain
+	if route.Hostname != "" {
+		host = route.Hostname + "." + route.Domain
+	}
+
+	return &apis.URL{
+		Scheme: "http",
+		Host:   host,
+		Path:   path.Join("/", route.Path),
+	}
+}
+
+// RouteStatus represents information about the status of a Route.
+type RouteStatus struct {
+	// Pull in the fields from Knative's duckv1beta1 status field.
+	duckv1beta1.Status `json:",inline"`
+
+	// URL is the address the Route serves traffic on.
+	// +optional
+	URL *apis.URL `json:"url,omitempty"`
+}
+
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
 
 // RouteList is a list of Route resources
@@ -49,3 +49,29 @@ func ExampleRouteSpecFields_String_without_path() {
 
 	// Output: foo.example.com/
 }
+
+func ExampleRouteSpecFields_String_tcp() {
+	r := RouteSpecFields{
+		Domain: "tcp.example.com",
+		Port:   1234,
+	}
+
+	fmt.Println(r.String())
+	fmt.Println(r.URL())
+
+	// Output: tcp.example.com:1234
+	// tcp://tcp.example.com:1234
+}
+
+func ExampleRouteSpecFields_String_wildcard() {
+	r := RouteSpecFields{
+		Hostname: WildcardHostname,
+		Domain:   "example.com",
+	}
+
+	fmt.Println(r.String())
+	fmt.Println(r.IsWildcard())
+
+	// Output: *.example.com/
+	// true
+}
@@ -17,9 +17,12 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net"
+	"strings"
 
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -70,6 +73,16 @@ func (r *Route) Validate(ctx context.Context) (errs *apis.FieldError) {
 		})
 	}
 
+	// Internal and public routes can't share a VirtualService because they're
+	// attached to different gateways.
+	if vs != nil && (vs.Annotations["internal"] == "true") != r.Spec.Internal {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Immutable field changed",
+			Paths:   []string{"spec.internal"},
+			Details: fmt.Sprintf("The route is invalid: Routes for this host and domain must have internal set to %t.", !r.Spec.Internal),
+		})
+	}
+
 	return errs
 }
 
@@ -83,11 +96,175 @@ func (r *RouteSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 		errs = errs.Also(apis.ErrInvalidValue("hostname", r.Hostname))
 	}
 
-	// validate we only have one AppNames.
-	// TODO: We don't want to keep this:
-	// https://github.com/google/kf/issues/279
-	for i := 1; i < len(r.AppNames); i++ {
-		errs = errs.Also(apis.ErrInvalidArrayValue(r.AppNames[i], "appNames", i))
+	// Wildcards can only replace the whole hostname, otherwise it's unclear
+	// which route a host belongs to.
+	if strings.Contains(r.Hostname, "*") && !r.IsWildcard() {
+		errs = errs.Also(apis.ErrInvalidValue(r.Hostname, "hostname"))
+	}
+
+	if strings.Contains(r.Domain, "*") {
+		errs = errs.Also(apis.ErrInvalidValue(r.Domain, "domain"))
+	}
+
+	// Paths are matched literally, a wildcard would read like a pattern.
+	if strings.Contains(r.Path, "*") {
+		errs = errs.Also(apis.ErrInvalidValue(r.Path, "path"))
+	}
+
+	switch r.PathMatch {
+	case "", PathMatchPrefix, PathMatchExact:
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(r.PathMatch, "pathMatch"))
+	}
+
+	if r.IsTCP() {
+		if r.Port < 1 || r.Port > 65535 {
+			errs = errs.Also(apis.ErrOutOfBoundsValue(r.Port, 1, 65535, "port"))
+		}
+
+		// TCP routes match every connection on the port.
+		if r.Hostname != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("hostname"))
+		}
+
+		if r.Path != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("path"))
+		}
+
+		if r.PathMatch != "" {
+			errs = errs.Also(apis.ErrDisallowedFields("pathMatch"))
+		}
+
+		if r.Internal {
+			errs = errs.Also(apis.ErrDisallowedFields("internal"))
+		}
+
+		// Connections are forwarded as-is, so there are no requests to
+		// time out, retry or change the headers of.
+		if r.Timeout != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("timeout"))
+		}
+
+		if r.Retries != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("retries"))
+		}
+
+		if r.Headers != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("headers"))
+		}
+
+		if r.AccessPolicy != nil {
+			errs = errs.Also(apis.ErrDisallowedFields("accessPolicy"))
+		}
+	}
+
+	// Client addresses are only known for requests that come through the
+	// ingress gateway.
+	if r.Internal && r.AccessPolicy.HasSourceCIDRs() {
+		errs = errs.Also(apis.ErrDisallowedFields("allowedSourceCIDRs", "deniedSourceCIDRs").ViaField("accessPolicy"))
+	}
+
+	if r.Timeout != nil && r.Timeout.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.Timeout.Duration.String(), "timeout"))
+	}
+
+	if r.Retries != nil {
+		errs = errs.Also(r.Retries.Validate(ctx).ViaField("retries"))
+	}
+
+	if r.Headers != nil {
+		errs = errs.Also(r.Headers.Validate(ctx).ViaField("headers"))
+	}
+
+	if r.AccessPolicy != nil {
+		errs = errs.Also(r.AccessPolicy.Validate(ctx).ViaField("accessPolicy"))
+	}
+
+	for appName, weight := range r.AppWeights {
+		if weight < 0 {
+			errs = errs.Also(apis.ErrInvalidValue(weight, apis.CurrentField).ViaFieldKey("appWeights", appName))
+		}
+	}
+
+	return errs
+}
+
+// Validate makes sure that RouteSpecRetries is properly configured.
+func (r *RouteSpecRetries) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if r.Attempts < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.Attempts, "attempts"))
+	}
+
+	if r.PerTryTimeout != nil && r.PerTryTimeout.Duration <= 0 {
+		errs = errs.Also(apis.ErrInvalidValue(r.PerTryTimeout.Duration.String(), "perTryTimeout"))
+	}
+
+	return errs
+}
+
+// Validate makes sure that RouteSpecHeaders is properly configured.
+func (h *RouteSpecHeaders) Validate(ctx context.Context) (errs *apis.FieldError) {
+	// The Host header picks the App that receives the request, so requests
+	// can't change it.
+	errs = errs.Also(validateHeaderOperations(h.Request, "Host").ViaField("request"))
+	errs = errs.Also(validateHeaderOperations(h.Response).ViaField("response"))
+
+	return errs
+}
+
+// Validate makes sure that RouteSpecAccessPolicy is properly configured.
+func (p *RouteSpecAccessPolicy) Validate(ctx context.Context) (errs *apis.FieldError) {
+	errs = errs.Also(validateIPv4CIDRs(p.AllowedSourceCIDRs).ViaField("allowedSourceCIDRs"))
+	errs = errs.Also(validateIPv4CIDRs(p.DeniedSourceCIDRs).ViaField("deniedSourceCIDRs"))
+
+	if p.RequestsPerSecond < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(p.RequestsPerSecond, "requestsPerSecond"))
+	}
+
+	return errs
+}
+
+func validateIPv4CIDRs(cidrs []string) (errs *apis.FieldError) {
+	for i, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil || ipNet.IP.To4() == nil {
+			errs = errs.Also(apis.ErrInvalidValue(cidr, apis.CurrentField).ViaIndex(i))
+		}
+	}
+
+	return errs
+}
+
+func validateHeaderOperations(ops *RouteSpecHeaderOperations, reserved ...string) (errs *apis.FieldError) {
+	if ops == nil {
+		return nil
+	}
+
+	validName := func(name string) bool {
+		for _, r := range reserved {
+			if strings.EqualFold(name, r) {
+				return false
+			}
+		}
+		return len(validation.IsHTTPHeaderName(name)) == 0
+	}
+
+	for name := range ops.Set {
+		if !validName(name) {
+			errs = errs.Also(apis.ErrInvalidValue(name, apis.CurrentField).ViaFieldKey("set", name))
+		}
+	}
+
+	for name := range ops.Add {
+		if !validName(name) {
+			errs = errs.Also(apis.ErrInvalidValue(name, apis.CurrentField).ViaFieldKey("add", name))
+		}
+	}
+
+	for i, name := range ops.Remove {
+		if !validName(name) {
+			errs = errs.Also(apis.ErrInvalidValue(name, apis.CurrentField).ViaFieldIndex("remove", i))
+		}
 	}
 
 	return errs
@@ -19,6 +19,7 @@ import (
 	"errors"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/kf/pkg/kf/testutil"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
@@ -108,7 +109,310 @@ func TestRouteValidation(t *testing.T) {
 					},
 				},
 			},
-			want: apis.ErrInvalidArrayValue("app-2", "spec.appNames", 1).Also(apis.ErrInvalidArrayValue("app-3", "spec.appNames", 2)),
+		},
+		"existing VirtualService is internal": {
+			setup: func(t *testing.T, fake *fake.FakeNetworkingV1alpha3) {
+				fake.AddReactor("get", "virtualservices", func(action ktesting.Action) (handled bool, ret runtime.Object, err error) {
+					return true, &v1alpha3.VirtualService{
+						ObjectMeta: metav1.ObjectMeta{
+							Annotations: map[string]string{
+								"space":    "valid",
+								"internal": "true",
+							},
+						},
+					}, nil
+				})
+			},
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec:       goodRouteSpec,
+			},
+			want: &apis.FieldError{
+				Message: "Immutable field changed",
+				Paths:   []string{"spec.internal"},
+				Details: "The route is invalid: Routes for this host and domain must have internal set to true.",
+			},
+		},
+		"weighted appNames": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AppNames:   []string{"app-1", "app-2"},
+					AppWeights: map[string]int{"app-1": 90, "app-2": 10},
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+					},
+				},
+			},
+		},
+		"negative weight": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AppNames:   []string{"app-1"},
+					AppWeights: map[string]int{"app-1": -1},
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue(-1, "spec.appWeights[app-1]"),
+		},
+		"tcp route": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+				},
+			},
+		},
+		"tcp route port out of range": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   70000,
+					},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(70000, 1, 65535, "spec.port"),
+		},
+		"tcp route with hostname and path": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "tcp.example.com",
+						Path:     "/some-path",
+						Port:     1024,
+					},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.hostname", "spec.path"),
+		},
+		"wildcard hostname": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "*",
+						Domain:   "example.com",
+					},
+				},
+			},
+		},
+		"partial wildcard hostname": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Hostname: "api-*",
+						Domain:   "example.com",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("api-*", "spec.hostname"),
+		},
+		"wildcard domain": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "*.example.com",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("*.example.com", "spec.domain"),
+		},
+		"wildcard path": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+						Path:   "/api/*",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("/api/*", "spec.path"),
+		},
+		"exact path": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain:    "example.com",
+						Path:      "/some-path",
+						PathMatch: PathMatchExact,
+					},
+				},
+			},
+		},
+		"unknown path match": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain:    "example.com",
+						PathMatch: "Regex",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("Regex", "spec.pathMatch"),
+		},
+		"tcp route with path match": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					RouteSpecFields: RouteSpecFields{
+						Domain:    "tcp.example.com",
+						PathMatch: PathMatchExact,
+						Port:      1024,
+					},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.pathMatch"),
+		},
+		"timeout, retries and headers": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					Timeout: &metav1.Duration{Duration: 30 * time.Second},
+					Retries: &RouteSpecRetries{
+						Attempts:      3,
+						PerTryTimeout: &metav1.Duration{Duration: 10 * time.Second},
+					},
+					Headers: &RouteSpecHeaders{
+						Request: &RouteSpecHeaderOperations{
+							Remove: []string{"X-Forwarded-Host"},
+						},
+						Response: &RouteSpecHeaderOperations{
+							Set: map[string]string{"Strict-Transport-Security": "max-age=31536000"},
+							Add: map[string]string{"X-Served-By": "kf"},
+						},
+					},
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+					},
+				},
+			},
+		},
+		"invalid timeouts and retries": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					Timeout: &metav1.Duration{Duration: -time.Second},
+					Retries: &RouteSpecRetries{
+						Attempts:      -1,
+						PerTryTimeout: &metav1.Duration{},
+					},
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("-1s", "spec.timeout").Also(
+				apis.ErrInvalidValue(-1, "spec.retries.attempts"),
+				apis.ErrInvalidValue("0s", "spec.retries.perTryTimeout"),
+			),
+		},
+		"invalid headers": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					Headers: &RouteSpecHeaders{
+						Request: &RouteSpecHeaderOperations{
+							Set:    map[string]string{"host": "other.example.com"},
+							Remove: []string{"X-Forwarded-*"},
+						},
+						Response: &RouteSpecHeaderOperations{
+							Add: map[string]string{"Bad Header": "value"},
+						},
+					},
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("host", "spec.headers.request.set[host]").Also(
+				apis.ErrInvalidValue("X-Forwarded-*", "spec.headers.request.remove[0]"),
+				apis.ErrInvalidValue("Bad Header", "spec.headers.response.add[Bad Header]"),
+			),
+		},
+		"access policy": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AccessPolicy: &RouteSpecAccessPolicy{
+						AllowedSourceCIDRs: []string{"10.0.0.0/8"},
+						DeniedSourceCIDRs:  []string{"10.1.2.3/32"},
+						RequestsPerSecond:  100,
+					},
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+					},
+				},
+			},
+		},
+		"invalid access policy": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AccessPolicy: &RouteSpecAccessPolicy{
+						AllowedSourceCIDRs: []string{"10.0.0.0/8", "10.0.0.1"},
+						DeniedSourceCIDRs:  []string{"2001:db8::/32"},
+						RequestsPerSecond:  -1,
+					},
+					RouteSpecFields: RouteSpecFields{
+						Domain: "example.com",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("10.0.0.1", "spec.accessPolicy.allowedSourceCIDRs[1]").Also(
+				apis.ErrInvalidValue("2001:db8::/32", "spec.accessPolicy.deniedSourceCIDRs[0]"),
+				apis.ErrInvalidValue(-1, "spec.accessPolicy.requestsPerSecond"),
+			),
+		},
+		"internal route with source CIDRs": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					AccessPolicy: &RouteSpecAccessPolicy{
+						AllowedSourceCIDRs: []string{"10.0.0.0/8"},
+					},
+					RouteSpecFields: RouteSpecFields{
+						Domain:   "apps.internal",
+						Internal: true,
+					},
+				},
+			},
+			want: apis.ErrDisallowedFields(
+				"spec.accessPolicy.allowedSourceCIDRs",
+				"spec.accessPolicy.deniedSourceCIDRs",
+			),
+		},
+		"tcp route with timeout, retries, headers and access policy": {
+			route: &Route{
+				ObjectMeta: goodObjMeta,
+				Spec: RouteSpec{
+					Timeout: &metav1.Duration{Duration: time.Second},
+					Retries: &RouteSpecRetries{Attempts: 1},
+					Headers: &RouteSpecHeaders{},
+					AccessPolicy: &RouteSpecAccessPolicy{
+						AllowedSourceCIDRs: []string{"10.0.0.0/8"},
+					},
+					RouteSpecFields: RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.timeout", "spec.retries", "spec.headers", "spec.accessPolicy"),
 		},
 		"fetching VirtualServices returns an error": {
 			setup: func(t *testing.T, fake *fake.FakeNetworkingV1alpha3) {
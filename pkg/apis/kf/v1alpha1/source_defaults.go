@@ -16,6 +16,12 @@ package v1alpha1
 
 import "context"
 
+const (
+	// DefaultDockerfilePath is the Dockerfile built if a Dockerfile build
+	// doesn't have a path.
+	DefaultDockerfilePath = "Dockerfile"
+)
+
 // SetDefaults implements apis.Defaultable
 func (k *Source) SetDefaults(ctx context.Context) {
 	k.Spec.SetDefaults(ctx)
@@ -23,7 +29,9 @@ func (k *Source) SetDefaults(ctx context.Context) {
 
 // SetDefaults implements apis.Defaultable
 func (k *SourceSpec) SetDefaults(ctx context.Context) {
-	// XXX: currently no defaults to set
+	if k.IsDockerfileBuild() && k.Dockerfile.Path == "" {
+		k.Dockerfile.Path = DefaultDockerfilePath
+	}
 }
 
 // SetSpaceDefaults sets the default values for the source based on the space's
@@ -44,7 +52,22 @@ func (k *SourceSpec) SetSpaceDefaults(space *Space) {
 			k.BuildpackBuild.Registry = space.Spec.BuildpackBuild.ContainerRegistry
 		}
 
+		if k.BuildpackBuild.BuildTemplate == "" {
+			k.BuildpackBuild.BuildTemplate = space.Spec.BuildpackBuild.BuildTemplate
+		}
+
 		// user defined values in buildpackbuild.env take priority from buildpackbuild.env
 		k.BuildpackBuild.Env = append(space.Spec.BuildpackBuild.Env, k.BuildpackBuild.Env...)
 	}
+
+	if k.IsDockerfileBuild() {
+		if k.Dockerfile.Registry == "" {
+			k.Dockerfile.Registry = space.Spec.BuildpackBuild.ContainerRegistry
+		}
+	}
+
+	if k.Timeout == nil && space.Spec.BuildpackBuild.Timeout != nil {
+		timeout := *space.Spec.BuildpackBuild.Timeout
+		k.Timeout = &timeout
+	}
 }
@@ -0,0 +1,40 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ExampleSourceSpec_SetSpaceDefaults_timeout() {
+	space := &Space{}
+	space.SetDefaults(context.Background())
+
+	inherited := SourceSpec{}
+	inherited.SetSpaceDefaults(space)
+
+	overridden := SourceSpec{Timeout: &metav1.Duration{Duration: time.Hour}}
+	overridden.SetSpaceDefaults(space)
+
+	fmt.Println("Inherited:", inherited.Timeout.Duration)
+	fmt.Println("Overridden:", overridden.Timeout.Duration)
+
+	// Output: Inherited: 30m0s
+	// Overridden: 1h0m0s
+}
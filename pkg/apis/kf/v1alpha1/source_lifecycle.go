@@ -16,6 +16,7 @@ package v1alpha1
 
 import (
 	"fmt"
+	"strings"
 
 	build "github.com/knative/build/pkg/apis/build/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
@@ -34,14 +35,30 @@ const (
 	// and is usable by developers.
 	SourceConditionSucceeded                         = apis.ConditionSucceeded
 	SourceConditionBuildSucceeded apis.ConditionType = "BuildSucceeded"
+	// SourceConditionImageResolved is set when the digest of the built image
+	// is recorded. Apps are deployed by digest so the Source can't be used
+	// before.
+	SourceConditionImageResolved apis.ConditionType = "ImageResolved"
 
 	BuildArgImage            = "IMAGE"
 	BuildArgBuildpack        = "BUILDPACK"
 	BuildArgBuildpackBuilder = "BUILDER_IMAGE"
+	BuildArgRunImage         = "RUN_IMAGE"
+	BuildArgDockerfile       = "DOCKERFILE"
+	BuildArgTarget           = "TARGET"
+	BuildArgBuildArgs        = "BUILD_ARGS"
+	BuildArgCache            = "CACHE"
+
+	// buildStepPrefix is added by Knative Build to the name of each step's
+	// container.
+	buildStepPrefix = "build-step-"
 )
 
 func (status *SourceStatus) manage() apis.ConditionManager {
-	return apis.NewBatchConditionSet(SourceConditionBuildSucceeded).Manage(status)
+	return apis.NewBatchConditionSet(
+		SourceConditionBuildSucceeded,
+		SourceConditionImageResolved,
+	).Manage(status)
 }
 
 // Succeeded returns if the space is ready to be used.
@@ -73,17 +90,42 @@ func (status *SourceStatus) PropagateBuildStatus(build *build.Build) {
 		return
 	}
 
+	// The digests belong to the image of the previous build, they're
+	// resolved again once this one succeeds.
+	if status.BuildName != build.Name {
+		status.ImageDigest = ""
+		status.Provenance = nil
+	}
+
 	status.BuildName = build.Name
+	status.StartTime = build.Status.StartTime.DeepCopy()
+	status.CompletionTime = build.Status.CompletionTime.DeepCopy()
+	status.Steps = buildSteps(build)
 
 	for _, condition := range build.Status.GetConditions() {
 		if condition.Type == "Succeeded" {
 			switch condition.Status {
 			case corev1.ConditionTrue:
 				status.Image = GetBuildArg(build, BuildArgImage)
+				status.Stack = GetBuildArg(build, BuildArgRunImage)
 
 				status.manage().MarkTrue(SourceConditionBuildSucceeded)
+				if status.ImageDigest == "" {
+					status.manage().MarkUnknown(SourceConditionImageResolved, "Resolving", "Resolving the digest of the built image")
+				} else {
+					status.MarkImageResolved()
+				}
 			case corev1.ConditionFalse:
-				status.manage().MarkFalse(SourceConditionBuildSucceeded, condition.Reason, "Build failed: %s", condition.Message)
+				message := condition.Message
+				if step := status.FailedStep(); step != nil && step.FailureMessage() != "" {
+					message = fmt.Sprintf("step %q exited with code %d: %s",
+						step.Name,
+						step.Terminated.ExitCode,
+						step.FailureMessage(),
+					)
+				}
+
+				status.manage().MarkFalse(SourceConditionBuildSucceeded, condition.Reason, "Build failed: %s", message)
 			case corev1.ConditionUnknown:
 				status.manage().MarkUnknown(SourceConditionBuildSucceeded, condition.Reason, "Build in progress")
 			}
@@ -91,6 +133,62 @@ func (status *SourceStatus) PropagateBuildStatus(build *build.Build) {
 	}
 }
 
+// MarkImageResolved notes that the digest of the built image was recorded.
+func (status *SourceStatus) MarkImageResolved() {
+	status.manage().MarkTrue(SourceConditionImageResolved)
+}
+
+// MarkImageUnresolved notes that the built image couldn't be fetched to
+// resolve its digest. The Source stays unusable until it can be.
+func (status *SourceStatus) MarkImageUnresolved(err error) {
+	status.manage().MarkUnknown(SourceConditionImageResolved, "FetchingImage", "Couldn't fetch the built image: %s", err)
+}
+
+// FailedStep returns the first step of the latest build that exited with a
+// non-zero code or nil if none did.
+func (status *SourceStatus) FailedStep() *SourceStatusStep {
+	for i, step := range status.Steps {
+		if step.Terminated != nil && step.Terminated.ExitCode != 0 {
+			return &status.Steps[i]
+		}
+	}
+
+	return nil
+}
+
+// FailureMessage returns the last line of the step's termination message.
+// Steps that fall back to their logs for the message usually print the cause
+// of the failure last.
+func (step *SourceStatusStep) FailureMessage() string {
+	if step.Terminated == nil {
+		return ""
+	}
+
+	lines := strings.Split(strings.TrimSpace(step.Terminated.Message), "\n")
+	return strings.TrimSpace(lines[len(lines)-1])
+}
+
+// buildSteps pairs the state of each step of the Build with its name. Steps
+// run in order so the completed ones come first; names are only reported for
+// those.
+func buildSteps(b *build.Build) []SourceStatusStep {
+	if len(b.Status.StepStates) == 0 {
+		return nil
+	}
+
+	var steps []SourceStatusStep
+	for i, state := range b.Status.StepStates {
+		step := SourceStatusStep{ContainerState: *state.DeepCopy()}
+		if i < len(b.Status.StepsCompleted) {
+			step.Name = strings.TrimPrefix(b.Status.StepsCompleted[i], buildStepPrefix)
+		}
+
+		steps = append(steps, step)
+	}
+
+	return steps
+}
+
 func GetBuildArg(b *build.Build, key string) string {
 	for _, arg := range b.Spec.Template.Arguments {
 		if arg.Name == key {
@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/kf/pkg/kf/testutil"
@@ -164,6 +165,7 @@ func initTestSourceStatus(t *testing.T) *SourceStatus {
 	// sanity check
 	apitesting.CheckConditionOngoing(status.duck(), SourceConditionSucceeded, t)
 	apitesting.CheckConditionOngoing(status.duck(), SourceConditionBuildSucceeded, t)
+	apitesting.CheckConditionOngoing(status.duck(), SourceConditionImageResolved, t)
 
 	return status
 }
@@ -224,6 +226,106 @@ func pendingBuild() *build.Build {
 	}
 }
 
+func failedBuild() *build.Build {
+	startTime := metav1.Unix(1000, 0)
+	completionTime := metav1.Unix(1060, 0)
+
+	return &build.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "some-build-name",
+		},
+		Status: build.BuildStatus{
+			Status: duckv1alpha1.Status{
+				Conditions: duckv1alpha1.Conditions{
+					{
+						Type:    duckv1alpha1.ConditionType("Succeeded"),
+						Status:  corev1.ConditionFalse,
+						Reason:  "BuildFailed",
+						Message: "build step \"build-step-build\" exited with code 1",
+					},
+				},
+			},
+			StartTime:      &startTime,
+			CompletionTime: &completionTime,
+			StepsCompleted: []string{"build-step-detect", "build-step-build"},
+			StepStates: []corev1.ContainerState{
+				{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+				{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Message:  "npm ERR! code ELIFECYCLE\nnpm ERR! missing script: start\n",
+				}},
+				{Waiting: &corev1.ContainerStateWaiting{Reason: "PodInitializing"}},
+			},
+		},
+	}
+}
+
+func TestSourceStatus_PropagateBuildStatus_failed(t *testing.T) {
+	status := initTestSourceStatus(t)
+
+	status.PropagateBuildStatus(failedBuild())
+
+	apitesting.CheckConditionFailed(status.duck(), SourceConditionBuildSucceeded, t)
+	cond := status.GetCondition(SourceConditionBuildSucceeded)
+	testutil.AssertEqual(t, "reason", "BuildFailed", cond.Reason)
+	testutil.AssertEqual(t, "message", `Build failed: step "build" exited with code 1: npm ERR! missing script: start`, cond.Message)
+
+	testutil.AssertEqual(t, "StartTime", int64(1000), status.StartTime.Unix())
+	testutil.AssertEqual(t, "CompletionTime", int64(1060), status.CompletionTime.Unix())
+	testutil.AssertEqual(t, "Steps count", 3, len(status.Steps))
+	testutil.AssertEqual(t, "first step", "detect", status.Steps[0].Name)
+	testutil.AssertEqual(t, "pending step", "", status.Steps[2].Name)
+	testutil.AssertEqual(t, "pending step reason", "PodInitializing", status.Steps[2].Waiting.Reason)
+
+	failed := status.FailedStep()
+	testutil.AssertNotNil(t, "FailedStep", failed)
+	testutil.AssertEqual(t, "FailedStep name", "build", failed.Name)
+	testutil.AssertEqual(t, "FailureMessage", "npm ERR! missing script: start", failed.FailureMessage())
+}
+
+func TestSourceStatus_PropagateBuildStatus_noTerminationMessage(t *testing.T) {
+	status := initTestSourceStatus(t)
+
+	b := failedBuild()
+	b.Status.StepStates[1].Terminated.Message = ""
+	status.PropagateBuildStatus(b)
+
+	cond := status.GetCondition(SourceConditionBuildSucceeded)
+	testutil.AssertEqual(t, "message", `Build failed: build step "build-step-build" exited with code 1`, cond.Message)
+}
+
+func TestSourceStatus_PropagateBuildStatus_stack(t *testing.T) {
+	status := initTestSourceStatus(t)
+
+	b := happyBuild()
+	b.Spec.Template.Arguments = append(b.Spec.Template.Arguments, build.ArgumentSpec{
+		Name:  BuildArgRunImage,
+		Value: "gcr.io/mirror/bionic",
+	})
+	status.PropagateBuildStatus(b)
+
+	testutil.AssertEqual(t, "Image", "some-container-image", status.Image)
+	testutil.AssertEqual(t, "Stack", "gcr.io/mirror/bionic", status.Stack)
+}
+
+func TestSourceStatus_PropagateBuildStatus_provenance(t *testing.T) {
+	status := initTestSourceStatus(t)
+
+	status.PropagateBuildStatus(happyBuild())
+	status.ImageDigest = "sha256:abc"
+	status.Provenance = &SourceProvenance{BuilderImage: "some-builder"}
+
+	// Digests are kept while the status is for the same build.
+	status.PropagateBuildStatus(happyBuild())
+	testutil.AssertEqual(t, "ImageDigest", "sha256:abc", status.ImageDigest)
+
+	next := pendingBuild()
+	next.Name = "next-build-name"
+	status.PropagateBuildStatus(next)
+	testutil.AssertEqual(t, "ImageDigest", "", status.ImageDigest)
+	testutil.AssertEqual(t, "Provenance", (*SourceProvenance)(nil), status.Provenance)
+}
+
 func TestSourceHappyPath(t *testing.T) {
 	status := initTestSourceStatus(t)
 
@@ -238,10 +340,18 @@ func TestSourceHappyPath(t *testing.T) {
 	// Build succeeds
 	status.PropagateBuildStatus(happyBuild())
 
-	apitesting.CheckConditionSucceeded(status.duck(), SourceConditionSucceeded, t)
+	apitesting.CheckConditionOngoing(status.duck(), SourceConditionSucceeded, t)
 	apitesting.CheckConditionSucceeded(status.duck(), SourceConditionBuildSucceeded, t)
+	apitesting.CheckConditionOngoing(status.duck(), SourceConditionImageResolved, t)
 	testutil.AssertEqual(t, "BuildName", "some-build-name", status.BuildName)
 	testutil.AssertEqual(t, "Image", "some-container-image", status.Image)
+
+	// Image digest is recorded
+	status.ImageDigest = "sha256:abc"
+	status.MarkImageResolved()
+
+	apitesting.CheckConditionSucceeded(status.duck(), SourceConditionSucceeded, t)
+	apitesting.CheckConditionSucceeded(status.duck(), SourceConditionImageResolved, t)
 }
 
 func TestSourceStatus_lifecycle(t *testing.T) {
@@ -255,10 +365,34 @@ func TestSourceStatus_lifecycle(t *testing.T) {
 		"happy path": {
 			Init: func(status *SourceStatus) {
 				status.PropagateBuildStatus(happyBuild())
+				status.MarkImageResolved()
 			},
 			ExpectSucceeded: []apis.ConditionType{
 				SourceConditionSucceeded,
 				SourceConditionBuildSucceeded,
+				SourceConditionImageResolved,
+			},
+		},
+		"image unresolved": {
+			Init: func(status *SourceStatus) {
+				status.PropagateBuildStatus(happyBuild())
+				status.MarkImageUnresolved(errors.New("registry unavailable"))
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				SourceConditionBuildSucceeded,
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SourceConditionSucceeded,
+				SourceConditionImageResolved,
+			},
+		},
+		"build failed": {
+			Init: func(status *SourceStatus) {
+				status.PropagateBuildStatus(failedBuild())
+			},
+			ExpectFailed: []apis.ConditionType{
+				SourceConditionSucceeded,
+				SourceConditionBuildSucceeded,
 			},
 		},
 		"build not owned": {
@@ -15,6 +15,8 @@
 package v1alpha1
 
 import (
+	"strings"
+
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -38,7 +40,8 @@ type Source struct {
 }
 
 // SourceSpec defines the source code for an App.
-// The fields ContainerImage and BuildpackBuild are mutually exclusive.
+// The fields ContainerImage, BuildpackBuild and Dockerfile are mutually
+// exclusive.
 type SourceSpec struct {
 
 	// UpdateRequests is a unique identifier for an SourceSpec.
@@ -58,6 +61,25 @@ type SourceSpec struct {
 	// BuildpackBuild defines buildpack information for source.
 	// +optional
 	BuildpackBuild SourceSpecBuildpackBuild `json:"buildpackBuild,omitempty"`
+
+	// Dockerfile defines building the source with a Dockerfile.
+	// +optional
+	Dockerfile SourceSpecDockerfile `json:"dockerfile,omitempty"`
+
+	// Timeout is the longest the build can run before it fails. It defaults
+	// to the space's build timeout.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
+// SourceCancelledAnnotation is set on a Source to cancel its build. It's an
+// annotation rather than part of the spec so cancelling doesn't make the
+// Source differ from its App's.
+const SourceCancelledAnnotation = "kf.dev/cancelled"
+
+// IsCancelled returns true if the build of the Source was cancelled.
+func (r *Source) IsCancelled() bool {
+	return r.Annotations[SourceCancelledAnnotation] == "true"
 }
 
 // SourceSpecContainerImage defines a container image for an App.
@@ -68,19 +90,33 @@ type SourceSpecContainerImage struct {
 }
 
 // SourceSpecBuildpackBuild defines building an App using Buildpacks.
+// The fields Source and Git are mutually exclusive.
 type SourceSpecBuildpackBuild struct {
 
 	// Source is the Container Image which contains the App's source code.
 	Source string `json:"source"`
 
-	// Stack is the base layer to use for the App.
+	// Git is the git repository which contains the App's source code.
+	// +optional
+	Git *SourceSpecGit `json:"git,omitempty"`
+
+	// Stack is the run image the App is built on, it must be one of the run
+	// images advertised by the builder. If empty, the build template's
+	// default run image is used.
 	// +optional
 	Stack string `json:"stack,omitempty"`
 
-	// Buildpack is the Buildpack to use for the App.
+	// Buildpack is the Buildpack to use for the App. Multiple buildpacks
+	// may be given as a CSV.
+	// Deprecated: Use Buildpacks instead.
 	// +optional
 	Buildpack string `json:"buildpack,omitempty"`
 
+	// Buildpacks is the ordered group of buildpacks to build the App with.
+	// If empty, the builder detects the buildpacks to use.
+	// +optional
+	Buildpacks []string `json:"buildpacks,omitempty"`
+
 	// BuildpackBuilder is the container image which builds the App.
 	BuildpackBuilder string `json:"buildpackBuilder"`
 
@@ -89,6 +125,69 @@ type SourceSpecBuildpackBuild struct {
 
 	// Env represents the environment variables to apply when building the App.
 	Env []corev1.EnvVar `json:"env,omitempty"`
+
+	// CacheClaim is the name of a PersistentVolumeClaim that holds the
+	// buildpacks' layers between builds. If empty, nothing is cached.
+	// +optional
+	CacheClaim string `json:"cacheClaim,omitempty"`
+
+	// BuildTemplate is the name of a BuildTemplate in the Source's namespace
+	// to build with. If empty, the buildpack ClusterBuildTemplate is used.
+	// +optional
+	BuildTemplate string `json:"buildTemplate,omitempty"`
+}
+
+// SourceSpecDockerfile defines building an App using a Dockerfile.
+// The fields Source and Git are mutually exclusive.
+type SourceSpecDockerfile struct {
+
+	// Source is the Container Image which contains the App's source code.
+	Source string `json:"source"`
+
+	// Git is the git repository which contains the App's source code.
+	// +optional
+	Git *SourceSpecGit `json:"git,omitempty"`
+
+	// Path is the path of the Dockerfile relative to the root of the source
+	// code. It defaults to DefaultDockerfilePath.
+	// +optional
+	Path string `json:"path,omitempty"`
+
+	// BuildArgs are the values of the ARG instructions in the Dockerfile.
+	// +optional
+	BuildArgs map[string]string `json:"buildArgs,omitempty"`
+
+	// Target is the stage of a multi-stage Dockerfile to build. If empty,
+	// the last stage is built.
+	// +optional
+	Target string `json:"target,omitempty"`
+
+	// Registry is the container registry which will store the built image.
+	Registry string `json:"registry"`
+}
+
+// SourceSpecGit defines source code that is cloned from a git repository
+// when the App is built.
+type SourceSpecGit struct {
+
+	// URL is the location of the repository.
+	URL string `json:"url"`
+
+	// Revision is the branch, tag or commit to build. The branch or tag is
+	// resolved each time the App is built. If empty, the repository's
+	// default branch is built.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// SubPath is the directory within the repository that contains the App.
+	// +optional
+	SubPath string `json:"subPath,omitempty"`
+
+	// CredentialsSecret is the name of a Secret in the App's namespace used
+	// to clone the repository. It must be of type kubernetes.io/basic-auth
+	// or kubernetes.io/ssh-auth.
+	// +optional
+	CredentialsSecret string `json:"credentialsSecret,omitempty"`
 }
 
 // SourceStatus is the current configuration and running state for an App's Source.
@@ -97,6 +196,29 @@ type SourceStatus struct {
 	duckv1beta1.Status `json:",inline"`
 
 	SourceStatusFields `json:",inline"`
+
+	// StartTime is when the latest build started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the latest build finished, successfully or not.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// Steps holds the state of each step of the latest build in the order
+	// they run.
+	// +optional
+	Steps []SourceStatusStep `json:"steps,omitempty"`
+}
+
+// SourceStatusStep is the state of a single step of a build.
+type SourceStatusStep struct {
+	// Name is the name of the step, it's only known once the step has
+	// finished.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	corev1.ContainerState `json:",inline"`
 }
 
 // SourceStatusFields holds the fields of Source's status that
@@ -110,6 +232,72 @@ type SourceStatusFields struct {
 	// BuildName is the name of the build that produced the image.
 	// +optional
 	BuildName string `json:"buildName,omitempty"`
+
+	// Stack is the run image the latest successfully built image is based
+	// on. It's empty if the build template's default run image was used.
+	// +optional
+	Stack string `json:"stack,omitempty"`
+
+	// ImageDigest is the digest of Image. Apps are deployed by digest so
+	// re-tagging the image doesn't change what runs. It's empty until the
+	// digest is resolved from the registry.
+	// +optional
+	ImageDigest string `json:"imageDigest,omitempty"`
+
+	// Provenance records what the image was built from.
+	// +optional
+	Provenance *SourceProvenance `json:"provenance,omitempty"`
+}
+
+// SourceProvenance records the inputs of a build. Digests are resolved once
+// the build finishes.
+type SourceProvenance struct {
+	// BuilderImage is the buildpack builder image the App was built with.
+	// +optional
+	BuilderImage string `json:"builderImage,omitempty"`
+
+	// BuilderImageDigest is the digest of BuilderImage.
+	// +optional
+	BuilderImageDigest string `json:"builderImageDigest,omitempty"`
+
+	// SourceImage is the container image holding the App's source code.
+	// It's empty for git sources.
+	// +optional
+	SourceImage string `json:"sourceImage,omitempty"`
+
+	// SourceImageDigest is the digest of SourceImage.
+	// +optional
+	SourceImageDigest string `json:"sourceImageDigest,omitempty"`
+
+	// Buildpacks are the buildpacks that contributed to the image, in the
+	// order they ran.
+	// +optional
+	Buildpacks []SourceProvenanceBuildpack `json:"buildpacks,omitempty"`
+}
+
+// SourceProvenanceBuildpack is a buildpack that contributed to an image.
+type SourceProvenanceBuildpack struct {
+	// ID is the ID of the buildpack.
+	ID string `json:"id"`
+
+	// Version is the version of the buildpack.
+	// +optional
+	Version string `json:"version,omitempty"`
+}
+
+// PinnedImage returns Image referenced by its digest if it's known, or Image
+// otherwise.
+func (fields *SourceStatusFields) PinnedImage() string {
+	if fields.ImageDigest == "" || strings.Contains(fields.Image, "@") {
+		return fields.Image
+	}
+
+	repository := fields.Image
+	if i := strings.LastIndex(repository, ":"); i > strings.LastIndex(repository, "/") {
+		repository = repository[:i]
+	}
+
+	return repository + "@" + fields.ImageDigest
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -129,5 +317,28 @@ func (spec *SourceSpec) IsContainerBuild() bool {
 
 // IsBuildpackBuild returns true if the build is for a buildpack
 func (spec *SourceSpec) IsBuildpackBuild() bool {
-	return spec.BuildpackBuild.Source != ""
+	return spec.BuildpackBuild.Source != "" || spec.BuildpackBuild.Git != nil
+}
+
+// IsDockerfileBuild returns true if the build is for a Dockerfile
+func (spec *SourceSpec) IsDockerfileBuild() bool {
+	return spec.Dockerfile.Source != "" || spec.Dockerfile.Git != nil
+}
+
+// BuildpackGroup returns the ordered buildpacks to build with, falling back
+// to the deprecated CSV in Buildpack. It's empty if the builder should detect
+// the buildpacks.
+func (buildpackBuild *SourceSpecBuildpackBuild) BuildpackGroup() []string {
+	if len(buildpackBuild.Buildpacks) > 0 {
+		return buildpackBuild.Buildpacks
+	}
+
+	var group []string
+	for _, buildpack := range strings.Split(buildpackBuild.Buildpack, ",") {
+		if buildpack = strings.TrimSpace(buildpack); buildpack != "" {
+			group = append(group, buildpack)
+		}
+	}
+
+	return group
 }
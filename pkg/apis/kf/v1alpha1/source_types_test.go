@@ -0,0 +1,47 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import "fmt"
+
+func ExampleSourceSpecBuildpackBuild_BuildpackGroup() {
+	build := SourceSpecBuildpackBuild{Buildpack: "nodejs, python"}
+	fmt.Println("CSV:", build.BuildpackGroup())
+
+	build = SourceSpecBuildpackBuild{Buildpacks: []string{"python", "nodejs"}}
+	fmt.Println("List:", build.BuildpackGroup())
+
+	build = SourceSpecBuildpackBuild{}
+	fmt.Println("Detect:", len(build.BuildpackGroup()))
+
+	// Output: CSV: [nodejs python]
+	// List: [python nodejs]
+	// Detect: 0
+}
+
+func ExampleSourceStatusFields_PinnedImage() {
+	fields := SourceStatusFields{Image: "gcr.io/my-project/app-my-space-my-app:3"}
+	fmt.Println("Unresolved:", fields.PinnedImage())
+
+	fields.ImageDigest = "sha256:f3a6c8"
+	fmt.Println("Resolved:", fields.PinnedImage())
+
+	fields.Image = "localhost:5000/app"
+	fmt.Println("Untagged:", fields.PinnedImage())
+
+	// Output: Unresolved: gcr.io/my-project/app-my-space-my-app:3
+	// Resolved: gcr.io/my-project/app-my-space-my-app@sha256:f3a6c8
+	// Untagged: localhost:5000/app@sha256:f3a6c8
+}
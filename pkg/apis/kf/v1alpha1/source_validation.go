@@ -16,7 +16,17 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -26,6 +36,57 @@ func (source *Source) Validate(ctx context.Context) (errs *apis.FieldError) {
 	// of a spec issue.
 	if !apis.IsInStatusUpdate(ctx) {
 		errs = errs.Also(source.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+		errs = errs.Also(source.validateSpacePolicy(ctx))
+	}
+
+	return errs
+}
+
+// validateSpacePolicy checks that a buildpack build uses the builder images
+// and build template allowed by its space. Sources whose spec didn't change
+// aren't checked so they can still be cancelled after the policy changes.
+func (source *Source) validateSpacePolicy(ctx context.Context) *apis.FieldError {
+	getter := SpaceGetterFromContext(ctx)
+	if getter == nil || !source.Spec.IsBuildpackBuild() {
+		return nil
+	}
+
+	if base, ok := apis.GetBaseline(ctx).(*Source); ok && equality.Semantic.DeepEqual(base.Spec, source.Spec) {
+		return nil
+	}
+
+	space, err := getter.Get(source.Namespace, metav1.GetOptions{})
+	switch {
+	case apierrs.IsNotFound(err):
+		// Sources outside of a space don't have a policy.
+		return nil
+	case err != nil:
+		return &apis.FieldError{
+			Message: "failed to validate the space's build policy",
+			Details: fmt.Sprintf("failed to fetch Space: %s", err),
+		}
+	}
+
+	return source.Spec.BuildpackBuild.ValidateSpacePolicy(&space.Spec.BuildpackBuild).ViaField("spec", "buildpackBuild")
+}
+
+// ValidateSpacePolicy makes sure that a SourceSpecBuildpackBuild uses a
+// builder image and build template allowed by the space.
+func (buildpackBuild *SourceSpecBuildpackBuild) ValidateSpacePolicy(space *SpaceSpecBuildpackBuild) (errs *apis.FieldError) {
+	if !space.AllowsBuilderImage(buildpackBuild.BuildpackBuilder) {
+		errs = errs.Also(&apis.FieldError{
+			Message: "builder image isn't allowed in the space",
+			Paths:   []string{"buildpackBuilder"},
+			Details: fmt.Sprintf("%s must be one of: %s", buildpackBuild.BuildpackBuilder, strings.Join(space.AllowedBuilderImages, ", ")),
+		})
+	}
+
+	if buildpackBuild.BuildTemplate != space.BuildTemplate {
+		errs = errs.Also(&apis.FieldError{
+			Message: "build template doesn't match the space",
+			Paths:   []string{"buildTemplate"},
+			Details: fmt.Sprintf("Sources in the space must be built with the build template %q", space.BuildTemplate),
+		})
 	}
 
 	return errs
@@ -34,19 +95,49 @@ func (source *Source) Validate(ctx context.Context) (errs *apis.FieldError) {
 // Validate makes sure that a SourceSpec is properly configured.
 func (spec *SourceSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
 
-	if spec.IsContainerBuild() && spec.IsBuildpackBuild() {
-		errs = errs.Also(apis.ErrMultipleOneOf("buildpackBuild", "containerImage"))
-	} else if spec.IsContainerBuild() {
+	var buildTypes []string
+	if spec.IsBuildpackBuild() {
+		buildTypes = append(buildTypes, "buildpackBuild")
+	}
+	if spec.IsContainerBuild() {
+		buildTypes = append(buildTypes, "containerImage")
+	}
+	if spec.IsDockerfileBuild() {
+		buildTypes = append(buildTypes, "dockerfile")
+	}
+
+	switch {
+	case len(buildTypes) > 1:
+		errs = errs.Also(apis.ErrMultipleOneOf(buildTypes...))
+	case spec.IsContainerBuild():
 		errs = errs.Also(spec.ContainerImage.Validate(ctx))
-	} else if spec.IsBuildpackBuild() {
+	case spec.IsBuildpackBuild():
 		errs = errs.Also(spec.BuildpackBuild.Validate(ctx))
-	} else {
-		errs = errs.Also(apis.ErrMissingOneOf("buildpackBuild", "containerImage"))
+	case spec.IsDockerfileBuild():
+		errs = errs.Also(spec.Dockerfile.Validate(ctx).ViaField("dockerfile"))
+	default:
+		errs = errs.Also(apis.ErrMissingOneOf("buildpackBuild", "containerImage", "dockerfile"))
 	}
 
+	errs = errs.Also(validateBuildTimeout(spec.Timeout, "timeout"))
+
 	return errs
 }
 
+// validateBuildTimeout checks that the timeout is within the range Knative
+// Build allows.
+func validateBuildTimeout(timeout *metav1.Duration, field string) *apis.FieldError {
+	if timeout == nil {
+		return nil
+	}
+
+	if timeout.Duration <= 0 || timeout.Duration > MaxBuildTimeout {
+		return apis.ErrOutOfBoundsValue(timeout.Duration, time.Duration(0), MaxBuildTimeout, field)
+	}
+
+	return nil
+}
+
 // Validate makes sure that an SourceSpecContainerImage is properly configured.
 func (containerImage *SourceSpecContainerImage) Validate(ctx context.Context) (errs *apis.FieldError) {
 
@@ -60,13 +151,7 @@ func (containerImage *SourceSpecContainerImage) Validate(ctx context.Context) (e
 // Validate makes sure that a SourceSpecBuildpackBuild is properly configured.
 func (buildpackBuild *SourceSpecBuildpackBuild) Validate(ctx context.Context) (errs *apis.FieldError) {
 
-	if buildpackBuild.Source == "" {
-		errs = errs.Also(apis.ErrMissingField("source"))
-	}
-
-	if buildpackBuild.Stack == "" {
-		errs = errs.Also(apis.ErrMissingField("stack"))
-	}
+	errs = errs.Also(validateSourceCode(ctx, buildpackBuild.Source, buildpackBuild.Git))
 
 	if buildpackBuild.BuildpackBuilder == "" {
 		errs = errs.Also(apis.ErrMissingField("buildpackBuilder"))
@@ -76,5 +161,173 @@ func (buildpackBuild *SourceSpecBuildpackBuild) Validate(ctx context.Context) (e
 		errs = errs.Also(apis.ErrMissingField("registry"))
 	}
 
+	if t := buildpackBuild.BuildTemplate; t != "" {
+		if msgs := validation.IsDNS1123Subdomain(t); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(t, "buildTemplate"))
+		}
+	}
+
+	errs = errs.Also(validateBuildpacks(buildpackBuild.Buildpack, buildpackBuild.Buildpacks))
+
 	return errs
 }
+
+// validateBuildpacks checks that the buildpacks can be written to the build's
+// group and that the deprecated CSV isn't used alongside the list. The build
+// template substitutes the CSV into its script so every ID in it is checked
+// too, ignoring whitespace and empty entries the same way BuildpackGroup does.
+func validateBuildpacks(csv string, buildpacks []string) (errs *apis.FieldError) {
+	if csv != "" && len(buildpacks) > 0 {
+		errs = errs.Also(apis.ErrMultipleOneOf("buildpack", "buildpacks"))
+	}
+
+	if csv != "" {
+		for _, buildpack := range strings.Split(csv, ",") {
+			buildpack = strings.TrimSpace(buildpack)
+			if buildpack == "" {
+				continue
+			}
+
+			if !buildpackIDPattern.MatchString(buildpack) {
+				errs = errs.Also(apis.ErrInvalidValue(csv, "buildpack"))
+				break
+			}
+		}
+	}
+
+	seen := make(map[string]bool)
+	for i, buildpack := range buildpacks {
+		switch {
+		case !buildpackIDPattern.MatchString(buildpack):
+			errs = errs.Also(apis.ErrInvalidValue(buildpack, apis.CurrentField).ViaFieldIndex("buildpacks", i))
+		case seen[buildpack]:
+			dup := &apis.FieldError{
+				Message: fmt.Sprintf("duplicate buildpack %q", buildpack),
+				Paths:   []string{apis.CurrentField},
+			}
+			errs = errs.Also(dup.ViaFieldIndex("buildpacks", i))
+		}
+
+		seen[buildpack] = true
+	}
+
+	return errs
+}
+
+var (
+	// buildpackIDPattern matches buildpack IDs, e.g. org.cloudfoundry.nodejs.
+	buildpackIDPattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+
+	// containedPathPattern matches relative paths that are safe to pass to
+	// the build's shell.
+	containedPathPattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+
+	// dockerfileTargetPattern matches the names of build stages.
+	dockerfileTargetPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+$`)
+)
+
+// Validate makes sure that a SourceSpecDockerfile is properly configured.
+func (dockerfile *SourceSpecDockerfile) Validate(ctx context.Context) (errs *apis.FieldError) {
+
+	errs = errs.Also(validateSourceCode(ctx, dockerfile.Source, dockerfile.Git))
+
+	if dockerfile.Registry == "" {
+		errs = errs.Also(apis.ErrMissingField("registry"))
+	}
+
+	// The Dockerfile has to be inside of the source code.
+	if p := dockerfile.Path; p != "" && !isContainedPath(p) {
+		errs = errs.Also(apis.ErrInvalidValue(p, "path"))
+	}
+
+	if t := dockerfile.Target; t != "" && !dockerfileTargetPattern.MatchString(t) {
+		errs = errs.Also(apis.ErrInvalidValue(t, "target"))
+	}
+
+	for name := range dockerfile.BuildArgs {
+		if name == "" || strings.ContainsAny(name, "= ") {
+			errs = errs.Also(apis.ErrInvalidValue(name, apis.CurrentField).ViaFieldKey("buildArgs", name))
+		}
+	}
+
+	return errs
+}
+
+// isContainedPath returns true if the path is relative and can't escape the
+// directory it's relative to.
+func isContainedPath(p string) bool {
+	clean := path.Clean(p)
+
+	return !path.IsAbs(p) &&
+		clean != ".." &&
+		!strings.HasPrefix(clean, "../") &&
+		containedPathPattern.MatchString(p)
+}
+
+// validateSourceCode checks that the source code comes from either a
+// container image or a git repository.
+func validateSourceCode(ctx context.Context, source string, git *SourceSpecGit) (errs *apis.FieldError) {
+	switch {
+	case source != "" && git != nil:
+		errs = errs.Also(apis.ErrMultipleOneOf("source", "git"))
+	case git != nil:
+		errs = errs.Also(git.Validate(ctx).ViaField("git"))
+	case source == "":
+		errs = errs.Also(apis.ErrMissingField("source"))
+	}
+
+	return errs
+}
+
+var (
+	// gitRevisionPattern matches branches, tags and commits.
+	gitRevisionPattern = regexp.MustCompile(`^[A-Za-z0-9._/-]+$`)
+
+	// scpLikeGitURLPattern matches the user@host:path form of SSH URLs.
+	scpLikeGitURLPattern = regexp.MustCompile(`^[A-Za-z0-9._-]+@[A-Za-z0-9.-]+:[^:]+$`)
+)
+
+// Validate makes sure that a SourceSpecGit is properly configured.
+func (git *SourceSpecGit) Validate(ctx context.Context) (errs *apis.FieldError) {
+
+	if git.URL == "" {
+		errs = errs.Also(apis.ErrMissingField("url"))
+	} else if !isGitURL(git.URL) {
+		errs = errs.Also(apis.ErrInvalidValue(git.URL, "url"))
+	}
+
+	if r := git.Revision; r != "" && (strings.HasPrefix(r, "-") || !gitRevisionPattern.MatchString(r)) {
+		errs = errs.Also(apis.ErrInvalidValue(r, "revision"))
+	}
+
+	if p := git.SubPath; p != "" && !isContainedPath(p) {
+		errs = errs.Also(apis.ErrInvalidValue(p, "subPath"))
+	}
+
+	if s := git.CredentialsSecret; s != "" {
+		if msgs := validation.IsDNS1123Subdomain(s); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(s, "credentialsSecret"))
+		}
+	}
+
+	return errs
+}
+
+// isGitURL returns true if the URL is a remote repository git can clone.
+func isGitURL(rawURL string) bool {
+	if scpLikeGitURLPattern.MatchString(rawURL) {
+		return true
+	}
+
+	u, err := url.Parse(rawURL)
+	if err != nil || u.Host == "" {
+		return false
+	}
+
+	switch u.Scheme {
+	case "https", "http", "ssh", "git":
+		return true
+	default:
+		return false
+	}
+}
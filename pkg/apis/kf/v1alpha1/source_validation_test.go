@@ -17,8 +17,10 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/kf/pkg/kf/testutil"
+	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
@@ -32,13 +34,18 @@ func TestSource_Validate(t *testing.T) {
 		Registry:         "some-container-registry",
 	}
 	badBuildpackBuild := SourceSpecBuildpackBuild{
-		Source:           "missing-stack",
-		BuildpackBuilder: "no-stack",
-		Registry:         "still-no-stack",
+		Source:           "missing-registry",
+		Stack:            "some-stack",
+		BuildpackBuilder: "no-registry",
 	}
 	goodContainerImage := SourceSpecContainerImage{
 		Image: "some-container-image",
 	}
+	goodDockerfile := SourceSpecDockerfile{
+		Source:   "some-source-image",
+		Path:     "Dockerfile",
+		Registry: "some-container-registry",
+	}
 
 	cases := map[string]struct {
 		spec Source
@@ -76,6 +83,64 @@ func TestSource_Validate(t *testing.T) {
 			},
 			want: apis.ErrMultipleOneOf("spec.buildpackBuild", "spec.containerImage"),
 		},
+		"valid dockerfile": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					Dockerfile: goodDockerfile,
+				},
+			},
+		},
+		"invalid dockerfile and buildpackBuild": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					BuildpackBuild: goodBuildpackBuild,
+					Dockerfile:     goodDockerfile,
+				},
+			},
+			want: apis.ErrMultipleOneOf("spec.buildpackBuild", "spec.dockerfile"),
+		},
+		"invalid dockerfile": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					Dockerfile: SourceSpecDockerfile{
+						Source: "some-source-image",
+					},
+				},
+			},
+			want: apis.ErrMissingField("spec.dockerfile.registry"),
+		},
+		"valid timeout": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					Dockerfile: goodDockerfile,
+					Timeout:    &metav1.Duration{Duration: time.Hour},
+				},
+			},
+		},
+		"invalid timeout": {
+			spec: Source{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "valid",
+				},
+				Spec: SourceSpec{
+					Dockerfile: goodDockerfile,
+					Timeout:    &metav1.Duration{Duration: 25 * time.Hour},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(25*time.Hour, time.Duration(0), MaxBuildTimeout, "spec.timeout"),
+		},
 		"invalid neither": {
 			spec: Source{
 				ObjectMeta: metav1.ObjectMeta{
@@ -83,7 +148,7 @@ func TestSource_Validate(t *testing.T) {
 				},
 				Spec: SourceSpec{},
 			},
-			want: apis.ErrMissingOneOf("spec.buildpackBuild", "spec.containerImage"),
+			want: apis.ErrMissingOneOf("spec.buildpackBuild", "spec.containerImage", "spec.dockerfile"),
 		},
 		"invalid buildpackBuild": {
 			spec: Source{
@@ -94,7 +159,7 @@ func TestSource_Validate(t *testing.T) {
 					BuildpackBuild: badBuildpackBuild,
 				},
 			},
-			want: apis.ErrMissingField("spec.stack"),
+			want: apis.ErrMissingField("spec.registry"),
 		},
 	}
 
@@ -131,14 +196,44 @@ func TestSourceSpecBuildpackBuild_Validate(t *testing.T) {
 			},
 			want: apis.ErrMissingField("source"),
 		},
-		"missing stack": {
+		"valid git": {
+			spec: SourceSpecBuildpackBuild{
+				Git: &SourceSpecGit{
+					URL: "https://github.com/google/kf.git",
+				},
+				Stack:            "some-stack",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+		},
+		"image and git": {
+			spec: SourceSpecBuildpackBuild{
+				Source: "some-image",
+				Git: &SourceSpecGit{
+					URL: "https://github.com/google/kf.git",
+				},
+				Stack:            "some-stack",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+			want: apis.ErrMultipleOneOf("source", "git"),
+		},
+		"invalid git": {
+			spec: SourceSpecBuildpackBuild{
+				Git:              &SourceSpecGit{},
+				Stack:            "some-stack",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+			want: apis.ErrMissingField("git.url"),
+		},
+		"default stack": {
 			spec: SourceSpecBuildpackBuild{
 				Source:           "some-image",
 				Buildpack:        "some-buildpack",
 				BuildpackBuilder: "buildpackBuilder",
 				Registry:         "some-registry",
 			},
-			want: apis.ErrMissingField("stack"),
 		},
 		"missing buildpackBuilder": {
 			spec: SourceSpecBuildpackBuild{
@@ -158,6 +253,313 @@ func TestSourceSpecBuildpackBuild_Validate(t *testing.T) {
 			},
 			want: apis.ErrMissingField("registry"),
 		},
+		"invalid buildTemplate": {
+			spec: SourceSpecBuildpackBuild{
+				Source:           "some-image",
+				Stack:            "some-stack",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+				BuildTemplate:    "-bad",
+			},
+			want: apis.ErrInvalidValue("-bad", "buildTemplate"),
+		},
+		"ordered buildpacks": {
+			spec: SourceSpecBuildpackBuild{
+				Source:           "some-image",
+				Buildpacks:       []string{"org.cloudfoundry.nodejs", "org.cloudfoundry.python"},
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+		},
+		"buildpack and buildpacks": {
+			spec: SourceSpecBuildpackBuild{
+				Source:           "some-image",
+				Buildpack:        "some-buildpack",
+				Buildpacks:       []string{"some-buildpack"},
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+			want: apis.ErrMultipleOneOf("buildpack", "buildpacks"),
+		},
+		"invalid buildpack": {
+			spec: SourceSpecBuildpackBuild{
+				Source:           "some-image",
+				Buildpacks:       []string{"nodejs", "python\"\nid = \"evil"},
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+			want: apis.ErrInvalidValue("python\"\nid = \"evil", apis.CurrentField).ViaFieldIndex("buildpacks", 1),
+		},
+		"buildpack list": {
+			spec: SourceSpecBuildpackBuild{
+				Source:           "some-image",
+				Buildpack:        "org.cloudfoundry.nodejs,org.cloudfoundry.python",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+		},
+		"invalid buildpack list": {
+			spec: SourceSpecBuildpackBuild{
+				Source:           "some-image",
+				Buildpack:        "nodejs,$(touch /workspace/evil)",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+			want: apis.ErrInvalidValue("nodejs,$(touch /workspace/evil)", "buildpack"),
+		},
+		"buildpack list with spaces": {
+			spec: SourceSpecBuildpackBuild{
+				Source:           "some-image",
+				Buildpack:        "nodejs, python",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+		},
+		"empty buildpack in list": {
+			spec: SourceSpecBuildpackBuild{
+				Source:           "some-image",
+				Buildpack:        "nodejs,,python,",
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+		},
+		"duplicate buildpack": {
+			spec: SourceSpecBuildpackBuild{
+				Source:           "some-image",
+				Buildpacks:       []string{"nodejs", "python", "nodejs"},
+				BuildpackBuilder: "buildpackBuilder",
+				Registry:         "some-registry",
+			},
+			want: (&apis.FieldError{
+				Message: `duplicate buildpack "nodejs"`,
+				Paths:   []string{apis.CurrentField},
+			}).ViaFieldIndex("buildpacks", 2),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.spec.Validate(context.Background())
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+// fakeSpaceGetter returns the space with the given name.
+type fakeSpaceGetter map[string]*Space
+
+func (f fakeSpaceGetter) Get(name string, options metav1.GetOptions) (*Space, error) {
+	if space, ok := f[name]; ok {
+		return space, nil
+	}
+
+	return nil, apierrs.NewNotFound(Resource("spaces"), name)
+}
+
+func TestSource_Validate_spacePolicy(t *testing.T) {
+	space := &Space{}
+	space.Name = "my-space"
+	space.Spec.BuildpackBuild.AllowedBuilderImages = []string{"gcr.io/corp/builder"}
+	space.Spec.BuildpackBuild.BuildTemplate = "corp-buildpack"
+
+	makeSource := func(namespace, builder, template string) *Source {
+		source := &Source{}
+		source.Namespace = namespace
+		source.Spec.BuildpackBuild = SourceSpecBuildpackBuild{
+			Source:           "some-source-image",
+			Stack:            "some-stack",
+			BuildpackBuilder: builder,
+			Registry:         "some-container-registry",
+			BuildTemplate:    template,
+		}
+		return source
+	}
+
+	cases := map[string]struct {
+		source   *Source
+		baseline *Source
+		want     *apis.FieldError
+	}{
+		"allowed": {
+			source: makeSource("my-space", "gcr.io/corp/builder", "corp-buildpack"),
+		},
+		"builder image not allowed": {
+			source: makeSource("my-space", "gcr.io/other/builder", "corp-buildpack"),
+			want: &apis.FieldError{
+				Message: "builder image isn't allowed in the space",
+				Paths:   []string{"spec.buildpackBuild.buildpackBuilder"},
+				Details: "gcr.io/other/builder must be one of: gcr.io/corp/builder",
+			},
+		},
+		"different build template": {
+			source: makeSource("my-space", "gcr.io/corp/builder", ""),
+			want: &apis.FieldError{
+				Message: "build template doesn't match the space",
+				Paths:   []string{"spec.buildpackBuild.buildTemplate"},
+				Details: `Sources in the space must be built with the build template "corp-buildpack"`,
+			},
+		},
+		"unchanged spec": {
+			source:   makeSource("my-space", "gcr.io/other/builder", ""),
+			baseline: makeSource("my-space", "gcr.io/other/builder", ""),
+		},
+		"outside of a space": {
+			source: makeSource("other-namespace", "gcr.io/other/builder", ""),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := SetupSpaceGetter(context.Background(), fakeSpaceGetter{space.Name: space})
+			if tc.baseline != nil {
+				ctx = apis.WithinUpdate(ctx, tc.baseline)
+			}
+
+			got := tc.source.Validate(ctx)
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestSourceSpecDockerfile_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec SourceSpecDockerfile
+		want *apis.FieldError
+	}{
+		"valid": {
+			spec: SourceSpecDockerfile{
+				Source:    "some-image",
+				Path:      "build/Dockerfile.prod",
+				BuildArgs: map[string]string{"VERSION": "1.0 beta"},
+				Target:    "release",
+				Registry:  "some-registry",
+			},
+		},
+		"missing source": {
+			spec: SourceSpecDockerfile{
+				Registry: "some-registry",
+			},
+			want: apis.ErrMissingField("source"),
+		},
+		"missing registry": {
+			spec: SourceSpecDockerfile{
+				Source: "some-image",
+			},
+			want: apis.ErrMissingField("registry"),
+		},
+		"absolute path": {
+			spec: SourceSpecDockerfile{
+				Source:   "some-image",
+				Path:     "/etc/Dockerfile",
+				Registry: "some-registry",
+			},
+			want: apis.ErrInvalidValue("/etc/Dockerfile", "path"),
+		},
+		"path outside of source": {
+			spec: SourceSpecDockerfile{
+				Source:   "some-image",
+				Path:     "app/../../Dockerfile",
+				Registry: "some-registry",
+			},
+			want: apis.ErrInvalidValue("app/../../Dockerfile", "path"),
+		},
+		"path with shell characters": {
+			spec: SourceSpecDockerfile{
+				Source:   "some-image",
+				Path:     "$(reboot)",
+				Registry: "some-registry",
+			},
+			want: apis.ErrInvalidValue("$(reboot)", "path"),
+		},
+		"invalid target": {
+			spec: SourceSpecDockerfile{
+				Source:   "some-image",
+				Target:   "build stage",
+				Registry: "some-registry",
+			},
+			want: apis.ErrInvalidValue("build stage", "target"),
+		},
+		"invalid build arg": {
+			spec: SourceSpecDockerfile{
+				Source:    "some-image",
+				BuildArgs: map[string]string{"A=B": "C"},
+				Registry:  "some-registry",
+			},
+			want: apis.ErrInvalidValue("A=B", "buildArgs[A=B]"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			got := tc.spec.Validate(context.Background())
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
+
+func TestSourceSpecGit_Validate(t *testing.T) {
+	cases := map[string]struct {
+		spec SourceSpecGit
+		want *apis.FieldError
+	}{
+		"valid https": {
+			spec: SourceSpecGit{
+				URL:               "https://github.com/google/kf.git",
+				Revision:          "release/v1",
+				SubPath:           "samples/apps/helloworld",
+				CredentialsSecret: "git-credentials",
+			},
+		},
+		"valid ssh": {
+			spec: SourceSpecGit{
+				URL: "ssh://git@github.com/google/kf.git",
+			},
+		},
+		"valid scp-like": {
+			spec: SourceSpecGit{
+				URL: "git@github.com:google/kf.git",
+			},
+		},
+		"missing url": {
+			spec: SourceSpecGit{},
+			want: apis.ErrMissingField("url"),
+		},
+		"local url": {
+			spec: SourceSpecGit{
+				URL: "file:///etc",
+			},
+			want: apis.ErrInvalidValue("file:///etc", "url"),
+		},
+		"flag url": {
+			spec: SourceSpecGit{
+				URL: "--upload-pack=touch",
+			},
+			want: apis.ErrInvalidValue("--upload-pack=touch", "url"),
+		},
+		"flag revision": {
+			spec: SourceSpecGit{
+				URL:      "https://github.com/google/kf.git",
+				Revision: "-b",
+			},
+			want: apis.ErrInvalidValue("-b", "revision"),
+		},
+		"subPath outside of repository": {
+			spec: SourceSpecGit{
+				URL:     "https://github.com/google/kf.git",
+				SubPath: "../..",
+			},
+			want: apis.ErrInvalidValue("../..", "subPath"),
+		},
+		"invalid credentials secret": {
+			spec: SourceSpecGit{
+				URL:               "https://github.com/google/kf.git",
+				CredentialsSecret: "Git_Credentials",
+			},
+			want: apis.ErrInvalidValue("Git_Credentials", "credentialsSecret"),
+		},
 	}
 
 	for tn, tc := range cases {
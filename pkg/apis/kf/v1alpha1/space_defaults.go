@@ -17,8 +17,10 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/google/kf/pkg/kf/algorithms"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // TODO(#396): We should pull these from a ConfigMap
@@ -29,6 +31,21 @@ const (
 	// DefaultDomainTemplate contains the default domain template. It should
 	// be used with `fmt.Sprintf(DefaultDomainTemplate, namespace)`
 	DefaultDomainTemplate = "%s.kf.cluster.local"
+
+	// DefaultBuildTimeout is the longest builds can run if the space doesn't
+	// set a timeout.
+	DefaultBuildTimeout = 30 * time.Minute
+
+	// MaxBuildTimeout is the longest timeout Knative Build allows.
+	MaxBuildTimeout = 24 * time.Hour
+
+	// DefaultSuccessfulBuildsLimit is the number of successful builds kept
+	// for each App.
+	DefaultSuccessfulBuildsLimit = 10
+
+	// DefaultFailedBuildsLimit is the number of failed builds kept for each
+	// App.
+	DefaultFailedBuildsLimit = 3
 )
 
 // SetDefaults implements apis.Defaultable
@@ -54,6 +71,25 @@ func (k *SpaceSpecBuildpackBuild) SetDefaults(ctx context.Context) {
 	if k.BuilderImage == "" {
 		k.BuilderImage = DefaultBuilderImage
 	}
+
+	if k.Timeout == nil {
+		k.Timeout = &metav1.Duration{Duration: DefaultBuildTimeout}
+	}
+
+	k.Retention.SetDefaults(ctx)
+}
+
+// SetDefaults implements apis.Defaultable
+func (k *SpaceSpecBuildRetention) SetDefaults(ctx context.Context) {
+	if k.SuccessfulBuildsLimit == nil {
+		limit := DefaultSuccessfulBuildsLimit
+		k.SuccessfulBuildsLimit = &limit
+	}
+
+	if k.FailedBuildsLimit == nil {
+		limit := DefaultFailedBuildsLimit
+		k.FailedBuildsLimit = &limit
+	}
 }
 
 // SetDefaults implements apis.Defaultable
@@ -36,9 +36,15 @@ func ExampleSpace_SetDefaults() {
 
 	fmt.Println("Builder:", space.Spec.BuildpackBuild.BuilderImage)
 	fmt.Println("Domains:", strings.Join(domainNames, ", "))
+	fmt.Println("Build timeout:", space.Spec.BuildpackBuild.Timeout.Duration)
+	fmt.Println("Successful builds kept:", *space.Spec.BuildpackBuild.Retention.SuccessfulBuildsLimit)
+	fmt.Println("Failed builds kept:", *space.Spec.BuildpackBuild.Retention.FailedBuildsLimit)
 
 	// Output: Builder: gcr.io/kf-releases/buildpack-builder:latest
 	// Domains: *mynamespace.kf.cluster.local
+	// Build timeout: 30m0s
+	// Successful builds kept: 10
+	// Failed builds kept: 3
 }
 
 func ExampleSpaceSpecExecution_SetDefaults_dedupe() {
@@ -48,6 +48,12 @@ const (
 	// SpaceConditionLimitRangeReady is set when the limit range is
 	// ready.
 	SpaceConditionLimitRangeReady apis.ConditionType = "LimitRangeReady"
+	// SpaceConditionGatewayReady is set when the Gateway serving the domains
+	// with certificates is ready.
+	SpaceConditionGatewayReady apis.ConditionType = "GatewayReady"
+	// SpaceConditionIngressPortsReady is set when the ingress gateway's
+	// Service exposes the TCP ports reserved by the spaces.
+	SpaceConditionIngressPortsReady apis.ConditionType = "IngressPortsReady"
 )
 
 func (status *SpaceStatus) manage() apis.ConditionManager {
@@ -57,6 +63,8 @@ func (status *SpaceStatus) manage() apis.ConditionManager {
 		SpaceConditionAuditorRoleReady,
 		SpaceConditionResourceQuotaReady,
 		SpaceConditionLimitRangeReady,
+		SpaceConditionGatewayReady,
+		SpaceConditionIngressPortsReady,
 	).Manage(status)
 }
 
@@ -105,6 +113,47 @@ func (status *SpaceStatus) MarkLimitRangeNotOwned(name string) {
 		fmt.Sprintf("There is an existing limitrange %q that we do not own.", name))
 }
 
+// MarkGatewayNotOwned marks the Gateway as not being owned by the Space.
+func (status *SpaceStatus) MarkGatewayNotOwned(name string) {
+	status.manage().MarkFalse(SpaceConditionGatewayReady, "NotOwned",
+		fmt.Sprintf("There is an existing gateway %q that we do not own.", name))
+}
+
+// MarkCertificateNotOwned marks the copy of a domain's certificate as not
+// being owned by the Space.
+func (status *SpaceStatus) MarkCertificateNotOwned(name string) {
+	status.manage().MarkFalse(SpaceConditionGatewayReady, "NotOwned",
+		fmt.Sprintf("There is an existing certificate secret %q that we do not own.", name))
+}
+
+// MarkCertificateInvalid marks the Gateway as not ready because the
+// certificate of a domain can't be used.
+func (status *SpaceStatus) MarkCertificateInvalid(domain string, err error) {
+	status.manage().MarkFalse(SpaceConditionGatewayReady, "InvalidCertificate",
+		fmt.Sprintf("The certificate for domain %q is invalid: %s", domain, err))
+}
+
+// MarkDomainTaken marks the Gateway as not ready because another space
+// serves one of the space's domains over HTTPS first.
+func (status *SpaceStatus) MarkDomainTaken(domain string, owner string) {
+	status.manage().MarkFalse(SpaceConditionGatewayReady, "DomainConflict",
+		fmt.Sprintf("Domain %q is already served over HTTPS by space %q.", domain, owner))
+}
+
+// MarkTCPPortTaken marks the Gateway as not ready because another space
+// reserved one of the space's TCP ports first.
+func (status *SpaceStatus) MarkTCPPortTaken(port int32, owner string) {
+	status.manage().MarkFalse(SpaceConditionGatewayReady, "PortConflict",
+		fmt.Sprintf("TCP port %d is already reserved by space %q.", port, owner))
+}
+
+// MarkIngressPortsUnavailable marks the ingress ports as not ready because
+// the ingress gateway's Service couldn't be updated.
+func (status *SpaceStatus) MarkIngressPortsUnavailable(err error) {
+	status.manage().MarkFalse(SpaceConditionIngressPortsReady, "SyncFailed",
+		fmt.Sprintf("Couldn't expose the TCP ports on the ingress gateway: %s", err))
+}
+
 // PropagateNamespaceStatus copies fields from the Namespace status to Space
 // and updates the readiness based on the current phase.
 func (status *SpaceStatus) PropagateNamespaceStatus(ns *v1.Namespace) {
@@ -147,6 +196,19 @@ func (status *SpaceStatus) PropagateLimitRangeStatus(limitRange *v1.LimitRange)
 	status.manage().MarkTrue(SpaceConditionLimitRangeReady)
 }
 
+// PropagateGatewayStatus records the certificates served by the space's
+// Gateway and marks it ready.
+func (status *SpaceStatus) PropagateGatewayStatus(certificates []SpaceStatusCertificate) {
+	status.Certificates = certificates
+	status.manage().MarkTrue(SpaceConditionGatewayReady)
+}
+
+// PropagateIngressPortsStatus marks the ingress ports ready once the ingress
+// gateway's Service exposes the reserved TCP ports.
+func (status *SpaceStatus) PropagateIngressPortsStatus() {
+	status.manage().MarkTrue(SpaceConditionIngressPortsReady)
+}
+
 func (status *SpaceStatus) duck() *duckv1beta1.Status {
 	return &status.Status
 }
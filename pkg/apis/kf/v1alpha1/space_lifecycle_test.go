@@ -15,6 +15,7 @@
 package v1alpha1
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/google/kf/pkg/kf/testutil"
@@ -170,6 +171,8 @@ func initTestStatus(t *testing.T) *SpaceStatus {
 	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionDeveloperRoleReady, t)
 	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionResourceQuotaReady, t)
 	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionLimitRangeReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionGatewayReady, t)
+	apitesting.CheckConditionOngoing(status.duck(), SpaceConditionIngressPortsReady, t)
 
 	return status
 }
@@ -184,6 +187,8 @@ func TestSpaceHappyPath(t *testing.T) {
 		Status: corev1.ResourceQuotaStatus{},
 	})
 	status.PropagateLimitRangeStatus(nil)
+	status.PropagateGatewayStatus(nil)
+	status.PropagateIngressPortsStatus()
 
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionReady, t)
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionNamespaceReady, t)
@@ -191,6 +196,8 @@ func TestSpaceHappyPath(t *testing.T) {
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionDeveloperRoleReady, t)
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionResourceQuotaReady, t)
 	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionLimitRangeReady, t)
+	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionGatewayReady, t)
+	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionIngressPortsReady, t)
 }
 
 func TestPropagateNamespaceStatus_terminating(t *testing.T) {
@@ -248,6 +255,8 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 					Status: corev1.ResourceQuotaStatus{},
 				})
 				status.PropagateLimitRangeStatus(nil)
+				status.PropagateGatewayStatus(nil)
+				status.PropagateIngressPortsStatus()
 			},
 			ExpectSucceeded: []apis.ConditionType{
 				SpaceConditionReady,
@@ -256,6 +265,20 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				SpaceConditionDeveloperRoleReady,
 				SpaceConditionResourceQuotaReady,
 				SpaceConditionLimitRangeReady,
+				SpaceConditionGatewayReady,
+				SpaceConditionIngressPortsReady,
+			},
+		},
+		"ingress ports unavailable": {
+			Init: func(status *SpaceStatus) {
+				status.MarkIngressPortsUnavailable(errors.New("conflict"))
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SpaceConditionNamespaceReady,
+			},
+			ExpectFailed: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionIngressPortsReady,
 			},
 		},
 		"terminating namespace": {
@@ -345,6 +368,63 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 				SpaceConditionLimitRangeReady,
 			},
 		},
+		"gateway not owned": {
+			Init: func(status *SpaceStatus) {
+				status.MarkGatewayNotOwned("space-gateway")
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SpaceConditionNamespaceReady,
+			},
+			ExpectFailed: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionGatewayReady,
+			},
+		},
+		"certificate not owned": {
+			Init: func(status *SpaceStatus) {
+				status.MarkCertificateNotOwned("space-cert")
+			},
+			ExpectFailed: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionGatewayReady,
+			},
+		},
+		"invalid certificate": {
+			Init: func(status *SpaceStatus) {
+				status.MarkCertificateInvalid("example.com", errors.New("expired"))
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SpaceConditionNamespaceReady,
+			},
+			ExpectFailed: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionGatewayReady,
+			},
+		},
+		"domain taken": {
+			Init: func(status *SpaceStatus) {
+				status.MarkDomainTaken("example.com", "other-space")
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SpaceConditionNamespaceReady,
+			},
+			ExpectFailed: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionGatewayReady,
+			},
+		},
+		"tcp port taken": {
+			Init: func(status *SpaceStatus) {
+				status.MarkTCPPortTaken(1024, "other-space")
+			},
+			ExpectOngoing: []apis.ConditionType{
+				SpaceConditionNamespaceReady,
+			},
+			ExpectFailed: []apis.ConditionType{
+				SpaceConditionReady,
+				SpaceConditionGatewayReady,
+			},
+		},
 	}
 
 	// XXX: if we start copying state from subresources back to the parent,
@@ -370,3 +450,14 @@ func TestSpaceStatus_lifecycle(t *testing.T) {
 		})
 	}
 }
+
+func TestPropagateGatewayStatus(t *testing.T) {
+	t.Parallel()
+	status := initTestStatus(t)
+
+	certificates := []SpaceStatusCertificate{{Domain: "example.com", SecretName: "example-cert"}}
+	status.PropagateGatewayStatus(certificates)
+
+	apitesting.CheckConditionSucceeded(status.duck(), SpaceConditionGatewayReady, t)
+	testutil.AssertEqual(t, "certificates", certificates, status.Certificates)
+}
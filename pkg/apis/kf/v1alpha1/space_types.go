@@ -17,6 +17,7 @@ package v1alpha1
 import metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 import corev1 "k8s.io/api/core/v1"
 import duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+import "sort"
 
 // +genclient
 // +genclient:nonNamespaced
@@ -75,6 +76,17 @@ type SpaceSpecBuildpackBuild struct {
 	// BuilderImage is a buildpacks.io builder image.
 	BuilderImage string `json:"builderImage,omitempty"`
 
+	// AllowedBuilderImages restricts the builder images Apps in the space
+	// can be built with. If empty, any builder image is allowed.
+	// +optional
+	AllowedBuilderImages []string `json:"allowedBuilderImages,omitempty"`
+
+	// BuildTemplate is the name of a BuildTemplate in the space used to build
+	// Apps instead of the buildpack ClusterBuildTemplate. It must take the
+	// same arguments.
+	// +optional
+	BuildTemplate string `json:"buildTemplate,omitempty"`
+
 	// ContainerRegistry holds the container registry that buildpack builds are
 	// stored in.
 	ContainerRegistry string `json:"containerRegistry,omitempty"`
@@ -85,6 +97,50 @@ type SpaceSpecBuildpackBuild struct {
 	// +patchMergeKey=name
 	// +patchStrategy=merge
 	Env []corev1.EnvVar `json:"env,omitempty" patchStrategy:"merge" patchMergeKey:"name"`
+
+	// Timeout is the longest builds in the space can run before they fail.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// Retention controls how many old builds of each App are kept.
+	// +optional
+	Retention SpaceSpecBuildRetention `json:"retention,omitempty"`
+}
+
+// AllowsBuilderImage returns true if Apps in the space can be built with the
+// builder image.
+func (s *SpaceSpecBuildpackBuild) AllowsBuilderImage(image string) bool {
+	if len(s.AllowedBuilderImages) == 0 {
+		return true
+	}
+
+	for _, allowed := range s.AllowedBuilderImages {
+		if allowed == image {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SpaceSpecBuildRetention controls the garbage collection of an App's old
+// Sources along with their Builds and images. Sources that are still
+// building, are the latest for their App or are in the App's revision
+// history are always kept.
+type SpaceSpecBuildRetention struct {
+	// SuccessfulBuildsLimit is the number of successful builds kept for each
+	// App.
+	// +optional
+	SuccessfulBuildsLimit *int `json:"successfulBuildsLimit,omitempty"`
+
+	// FailedBuildsLimit is the number of failed builds kept for each App.
+	// +optional
+	FailedBuildsLimit *int `json:"failedBuildsLimit,omitempty"`
+
+	// DeleteImages removes the images of garbage collected builds from the
+	// container registry.
+	// +optional
+	DeleteImages bool `json:"deleteImages,omitempty"`
 }
 
 // SpaceSpecExecution contains settings for the execution environment.
@@ -127,6 +183,92 @@ type SpaceDomain struct {
 	// specified. There can only be a single default set to true per space.
 	// NOTE: This may change in the future.
 	Default bool
+
+	// Internal implies that routes on this domain are only reachable by Apps
+	// inside the cluster's service mesh.
+	Internal bool
+
+	// TCPPorts holds the ports on the ingress gateway that TCP routes on this
+	// domain can reserve.
+	TCPPorts []int32
+
+	// CertificateSecret is the name of a kubernetes.io/tls Secret in the
+	// space holding the certificate for the domain. Routes on domains with a
+	// certificate are served over HTTPS.
+	CertificateSecret string
+
+	// RedirectHTTP redirects HTTP requests on the domain to HTTPS. It requires
+	// a CertificateSecret.
+	RedirectHTTP bool
+}
+
+// HasCertificate returns true if routes on the domain are served over HTTPS.
+func (d *SpaceDomain) HasCertificate() bool {
+	return d.CertificateSecret != ""
+}
+
+// FindDomain returns the SpaceDomain with the given domain or nil if the
+// space doesn't have it.
+func (s *SpaceSpecExecution) FindDomain(domain string) *SpaceDomain {
+	for i := range s.Domains {
+		if s.Domains[i].Domain == domain {
+			return &s.Domains[i]
+		}
+	}
+
+	return nil
+}
+
+// SpaceGatewayName gets the name of the Istio Gateway in the KfNamespace that
+// serves the domains of a space with certificates and its TCP ports.
+func SpaceGatewayName(spaceName string) string {
+	return GenerateName("kf", spaceName, "tls")
+}
+
+// ReservesTCPPort returns true if TCP routes on the domain can use the port.
+func (s *SpaceSpecExecution) ReservesTCPPort(domain string, port int32) bool {
+	for _, d := range s.Domains {
+		if d.Domain != domain {
+			continue
+		}
+
+		for _, p := range d.TCPPorts {
+			if p == port {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// TCPPorts returns the ports reserved for TCP routes on any of the space's
+// domains in ascending order.
+func (s *SpaceSpecExecution) TCPPorts() []int32 {
+	seen := make(map[int32]bool)
+	var ports []int32
+	for _, d := range s.Domains {
+		for _, p := range d.TCPPorts {
+			if !seen[p] {
+				seen[p] = true
+				ports = append(ports, p)
+			}
+		}
+	}
+
+	sort.Slice(ports, func(i, j int) bool { return ports[i] < ports[j] })
+	return ports
+}
+
+// Precedes returns true if the space's claims on the ingress gateway win
+// over the other space's when both claim the same thing. Older spaces win,
+// ties are broken by name.
+func (space *Space) Precedes(other *Space) bool {
+	if !space.CreationTimestamp.Equal(&other.CreationTimestamp) {
+		return space.CreationTimestamp.Before(&other.CreationTimestamp)
+	}
+
+	return space.Name < other.Name
 }
 
 // SpaceStatus represents information about the status of a Space.
@@ -135,6 +277,35 @@ type SpaceStatus struct {
 	duckv1beta1.Status `json:",inline"`
 
 	Quota corev1.ResourceQuotaStatus `json:"quota,omitempty"`
+
+	// Certificates holds the certificates of the space's domains that are
+	// served by the space's Gateway.
+	// +optional
+	Certificates []SpaceStatusCertificate `json:"certificates,omitempty"`
+}
+
+// HasCertificate returns true if the space's Gateway serves the domain with a
+// certificate.
+func (status *SpaceStatus) HasCertificate(domain string) bool {
+	for _, cert := range status.Certificates {
+		if cert.Domain == domain {
+			return true
+		}
+	}
+
+	return false
+}
+
+// SpaceStatusCertificate holds information about the certificate of a domain.
+type SpaceStatusCertificate struct {
+	// Domain is the domain served with the certificate.
+	Domain string `json:"domain"`
+
+	// SecretName is the name of the Secret holding the certificate.
+	SecretName string `json:"secretName"`
+
+	// NotAfter is the time the certificate expires.
+	NotAfter metav1.Time `json:"notAfter"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
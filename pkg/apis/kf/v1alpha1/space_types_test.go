@@ -0,0 +1,75 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ExampleSpaceSpecExecution_ReservesTCPPort() {
+	execution := SpaceSpecExecution{
+		Domains: []SpaceDomain{
+			{Domain: "example.com", Default: true},
+			{Domain: "tcp.example.com", TCPPorts: []int32{1024, 1025}},
+		},
+	}
+
+	fmt.Println("tcp.example.com:1024", execution.ReservesTCPPort("tcp.example.com", 1024))
+	fmt.Println("tcp.example.com:2048", execution.ReservesTCPPort("tcp.example.com", 2048))
+	fmt.Println("example.com:1024", execution.ReservesTCPPort("example.com", 1024))
+
+	// Output: tcp.example.com:1024 true
+	// tcp.example.com:2048 false
+	// example.com:1024 false
+}
+
+func ExampleSpaceSpecExecution_TCPPorts() {
+	execution := SpaceSpecExecution{
+		Domains: []SpaceDomain{
+			{Domain: "example.com", Default: true},
+			{Domain: "tcp.example.com", TCPPorts: []int32{2048, 1024}},
+			{Domain: "other.example.com", TCPPorts: []int32{1024, 1025}},
+		},
+	}
+
+	fmt.Println(execution.TCPPorts())
+
+	// Output: [1024 1025 2048]
+}
+
+func ExampleSpace_Precedes() {
+	now := time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC)
+	space := func(name string, created time.Time) *Space {
+		return &Space{ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+		}}
+	}
+
+	older := space("z-space", now.Add(-time.Hour))
+	newer := space("a-space", now)
+	twin := space("b-space", now)
+
+	fmt.Println("older precedes newer:", older.Precedes(newer))
+	fmt.Println("newer precedes older:", newer.Precedes(older))
+	fmt.Println("ties go by name:", newer.Precedes(twin), twin.Precedes(newer))
+
+	// Output: older precedes newer: true
+	// newer precedes older: false
+	// ties go by name: true false
+}
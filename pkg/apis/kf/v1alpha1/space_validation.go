@@ -16,7 +16,9 @@ package v1alpha1
 
 import (
 	"context"
+	"fmt"
 
+	"k8s.io/apimachinery/pkg/util/validation"
 	"knative.dev/pkg/apis"
 )
 
@@ -68,6 +70,42 @@ func (s *SpaceSpecBuildpackBuild) Validate(ctx context.Context) (errs *apis.Fiel
 		errs = errs.Also(apis.ErrMissingField("containerRegistry"))
 	}
 
+	for i, image := range s.AllowedBuilderImages {
+		if image == "" {
+			errs = errs.Also(apis.ErrInvalidValue(image, "allowedBuilderImages").ViaIndex(i))
+		}
+	}
+
+	if s.BuilderImage != "" && !s.AllowsBuilderImage(s.BuilderImage) {
+		errs = errs.Also(&apis.FieldError{
+			Message: "builder image isn't allowed",
+			Paths:   []string{"builderImage"},
+			Details: fmt.Sprintf("%s must be one of allowedBuilderImages", s.BuilderImage),
+		})
+	}
+
+	if t := s.BuildTemplate; t != "" {
+		if msgs := validation.IsDNS1123Subdomain(t); len(msgs) > 0 {
+			errs = errs.Also(apis.ErrInvalidValue(t, "buildTemplate"))
+		}
+	}
+
+	errs = errs.Also(validateBuildTimeout(s.Timeout, "timeout"))
+	errs = errs.Also(s.Retention.Validate(ctx).ViaField("retention"))
+
+	return errs
+}
+
+// Validate makes sure that SpaceSpecBuildRetention is properly configured.
+func (s *SpaceSpecBuildRetention) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if s.SuccessfulBuildsLimit != nil && *s.SuccessfulBuildsLimit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*s.SuccessfulBuildsLimit, "successfulBuildsLimit"))
+	}
+
+	if s.FailedBuildsLimit != nil && *s.FailedBuildsLimit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*s.FailedBuildsLimit, "failedBuildsLimit"))
+	}
+
 	return errs
 }
 
@@ -95,6 +133,26 @@ func (s *SpaceSpecExecution) Validate(ctx context.Context) (errs *apis.FieldErro
 		lastDefault = i
 	}
 
+	for i, d := range s.Domains {
+		for j, port := range d.TCPPorts {
+			if port < 1 || port > 65535 {
+				errs = errs.Also(apis.ErrOutOfBoundsValue(port, 1, 65535, "TCPPorts").
+					ViaIndex(j).
+					ViaFieldIndex("domains", i))
+			}
+		}
+
+		// Internal domains are only served inside the mesh, which already
+		// encrypts traffic between Apps.
+		if d.Internal && d.HasCertificate() {
+			errs = errs.Also(apis.ErrDisallowedFields("CertificateSecret").ViaFieldIndex("domains", i))
+		}
+
+		if d.RedirectHTTP && !d.HasCertificate() {
+			errs = errs.Also(apis.ErrMissingField("CertificateSecret").ViaFieldIndex("domains", i))
+		}
+	}
+
 	if lastDefault < 0 {
 		errs = errs.Also(
 			&apis.FieldError{
@@ -92,6 +92,57 @@ func TestSpaceValidation(t *testing.T) {
 			},
 			want: apis.ErrMissingField("spec.buildpackBuild.builderImage"),
 		},
+		"negative build retention": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: goodExecuton,
+					BuildpackBuild: SpaceSpecBuildpackBuild{
+						BuilderImage:      DefaultBuilderImage,
+						ContainerRegistry: "gcr.io/test",
+						Retention: SpaceSpecBuildRetention{
+							SuccessfulBuildsLimit: intPtr(-1),
+							FailedBuildsLimit:     intPtr(-2),
+						},
+					},
+				},
+			},
+			want: apis.ErrInvalidValue(-1, "spec.buildpackBuild.retention.successfulBuildsLimit").Also(
+				apis.ErrInvalidValue(-2, "spec.buildpackBuild.retention.failedBuildsLimit"),
+			),
+		},
+		"builder image not allowed": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: goodExecuton,
+					BuildpackBuild: SpaceSpecBuildpackBuild{
+						BuilderImage:         DefaultBuilderImage,
+						AllowedBuilderImages: []string{"gcr.io/corp/builder"},
+						ContainerRegistry:    "gcr.io/test",
+					},
+				},
+			},
+			want: &apis.FieldError{
+				Message: "builder image isn't allowed",
+				Paths:   []string{"spec.buildpackBuild.builderImage"},
+				Details: DefaultBuilderImage + " must be one of allowedBuilderImages",
+			},
+		},
+		"invalid build template": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: goodExecuton,
+					BuildpackBuild: SpaceSpecBuildpackBuild{
+						BuilderImage:      DefaultBuilderImage,
+						ContainerRegistry: "gcr.io/test",
+						BuildTemplate:     "Not A Name",
+					},
+				},
+			},
+			want: apis.ErrInvalidValue("Not A Name", "spec.buildpackBuild.buildTemplate"),
+		},
 		"no domains": {
 			space: &Space{
 				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
@@ -147,6 +198,66 @@ func TestSpaceValidation(t *testing.T) {
 				Details: "one domain must be set to default",
 			},
 		},
+		"invalid tcp port": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: []SpaceDomain{
+							{Domain: "example.com", Default: true},
+							{Domain: "tcp.example.com", TCPPorts: []int32{1024, 70000}},
+						},
+					},
+					BuildpackBuild: SpaceSpecBuildpackBuild{
+						ContainerRegistry: "gcr.io/test",
+						BuilderImage:      DefaultBuilderImage,
+					},
+				},
+			},
+			want: apis.ErrOutOfBoundsValue(70000, 1, 65535, "spec.execution.domains[1].TCPPorts[1]"),
+		},
+		"domain certificate": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: []SpaceDomain{
+							{Domain: "example.com", Default: true, CertificateSecret: "example-cert", RedirectHTTP: true},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+		},
+		"redirect without certificate": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: []SpaceDomain{
+							{Domain: "example.com", Default: true, RedirectHTTP: true},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: apis.ErrMissingField("spec.execution.domains[0].CertificateSecret"),
+		},
+		"internal domain with certificate": {
+			space: &Space{
+				ObjectMeta: metav1.ObjectMeta{Name: "valid"},
+				Spec: SpaceSpec{
+					Execution: SpaceSpecExecution{
+						Domains: []SpaceDomain{
+							{Domain: "example.com", Default: true},
+							{Domain: "apps.internal", Internal: true, CertificateSecret: "internal-cert"},
+						},
+					},
+					BuildpackBuild: goodBuildpackBuild,
+				},
+			},
+			want: apis.ErrDisallowedFields("spec.execution.domains[1].CertificateSecret"),
+		},
 	}
 
 	for tn, tc := range cases {
@@ -0,0 +1,65 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+)
+
+const (
+	// DefaultSuccessfulRunsHistoryLimit is the number of successful runs of
+	// a scheduled Task kept if the user doesn't specify a limit.
+	DefaultSuccessfulRunsHistoryLimit = 3
+
+	// DefaultFailedRunsHistoryLimit is the number of failed runs of a
+	// scheduled Task kept if the user doesn't specify a limit.
+	DefaultFailedRunsHistoryLimit = 1
+)
+
+// SetDefaults implements apis.Defaultable
+func (k *Task) SetDefaults(ctx context.Context) {
+	k.Spec.SetDefaults(ctx)
+}
+
+// SetDefaults implements apis.Defaultable
+func (k *TaskSpec) SetDefaults(ctx context.Context) {
+	k.Command = strings.TrimSpace(k.Command)
+
+	if k.Schedule != nil {
+		k.Schedule.SetDefaults(ctx)
+	}
+}
+
+// SetDefaults implements apis.Defaultable
+func (k *TaskSpecSchedule) SetDefaults(ctx context.Context) {
+	k.Cron = strings.TrimSpace(k.Cron)
+
+	if k.ConcurrencyPolicy == "" {
+		k.ConcurrencyPolicy = batchv1beta1.AllowConcurrent
+	}
+
+	if k.SuccessfulRunsHistoryLimit == nil {
+		limit := int32(DefaultSuccessfulRunsHistoryLimit)
+		k.SuccessfulRunsHistoryLimit = &limit
+	}
+
+	if k.FailedRunsHistoryLimit == nil {
+		limit := int32(DefaultFailedRunsHistoryLimit)
+		k.FailedRunsHistoryLimit = &limit
+	}
+}
@@ -0,0 +1,151 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"fmt"
+
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/apis"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// GetGroupVersionKind returns the GroupVersionKind.
+func (r *Task) GetGroupVersionKind() schema.GroupVersionKind {
+	return SchemeGroupVersion.WithKind("Task")
+}
+
+const (
+	// TaskConditionSucceeded is set when the Task's command has finished
+	// running successfully.
+	TaskConditionSucceeded                       = apis.ConditionSucceeded
+	TaskConditionJobSucceeded apis.ConditionType = "JobSucceeded"
+)
+
+func (status *TaskStatus) manage() apis.ConditionManager {
+	return apis.NewBatchConditionSet(TaskConditionJobSucceeded).Manage(status)
+}
+
+// Succeeded returns if the Task's command finished successfully.
+func (status *TaskStatus) Succeeded() bool {
+	return status.manage().IsHappy()
+}
+
+// IsFinished returns if the Task has stopped running, either because its
+// command finished or because it was terminated.
+func (status *TaskStatus) IsFinished() bool {
+	cond := status.GetCondition(TaskConditionSucceeded)
+	return cond != nil && !cond.IsUnknown()
+}
+
+// GetCondition returns the condition by name.
+func (status *TaskStatus) GetCondition(t apis.ConditionType) *apis.Condition {
+	return status.manage().GetCondition(t)
+}
+
+// InitializeConditions sets the initial values to the conditions.
+func (status *TaskStatus) InitializeConditions() {
+	status.manage().InitializeConditions()
+}
+
+// MarkAppNotFound marks the Task as failed because its App doesn't exist.
+func (status *TaskStatus) MarkAppNotFound(appName string) {
+	status.manage().MarkFalse(TaskConditionJobSucceeded, "AppNotFound",
+		fmt.Sprintf("App %q doesn't exist.", appName))
+}
+
+// MarkAppNotReady marks the Task as waiting for its App to have an image to
+// run the command in.
+func (status *TaskStatus) MarkAppNotReady(appName string) {
+	status.manage().MarkUnknown(TaskConditionJobSucceeded, "AppNotReady",
+		fmt.Sprintf("Waiting for App %q to be built.", appName))
+}
+
+// MarkCronJobNotOwned marks the CronJob as not being owned by the Task.
+func (status *TaskStatus) MarkCronJobNotOwned(name string) {
+	status.manage().MarkFalse(TaskConditionJobSucceeded, "NotOwned",
+		fmt.Sprintf("There is an existing CronJob %q that we do not own.", name))
+}
+
+// MarkJobNotOwned marks the Job as not being owned by the Task.
+func (status *TaskStatus) MarkJobNotOwned(name string) {
+	status.manage().MarkFalse(TaskConditionJobSucceeded, "NotOwned",
+		fmt.Sprintf("There is an existing Job %q that we do not own.", name))
+}
+
+// MarkTerminated marks the Task as stopped before its command finished.
+func (status *TaskStatus) MarkTerminated() {
+	status.manage().MarkFalse(TaskConditionJobSucceeded, "Terminated", "Task was terminated")
+}
+
+// PropagateJobStatus copies fields from the Job status to the Task and
+// updates the readiness based on the Job's conditions.
+func (status *TaskStatus) PropagateJobStatus(job *batchv1.Job) {
+	if job == nil {
+		return
+	}
+
+	status.JobName = job.Name
+	status.StartTime = job.Status.StartTime.DeepCopy()
+	status.CompletionTime = job.Status.CompletionTime.DeepCopy()
+
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			status.manage().MarkTrue(TaskConditionJobSucceeded)
+			return
+		case batchv1.JobFailed:
+			status.CompletionTime = condition.LastTransitionTime.DeepCopy()
+			status.manage().MarkFalse(TaskConditionJobSucceeded, condition.Reason, "Task failed: %s", condition.Message)
+			return
+		}
+	}
+
+	if job.Status.Active > 0 {
+		status.manage().MarkUnknown(TaskConditionJobSucceeded, "Running", "Task is running")
+	} else {
+		status.manage().MarkUnknown(TaskConditionJobSucceeded, "Pending", "Task is waiting to start")
+	}
+}
+
+// PropagateCronJobStatus copies fields from the CronJob status to a scheduled
+// Task. Scheduled Tasks never finish on their own, so the Task stays unknown
+// until it's terminated.
+func (status *TaskStatus) PropagateCronJobStatus(cronJob *batchv1beta1.CronJob) {
+	if cronJob == nil {
+		return
+	}
+
+	status.JobName = cronJob.Name
+	status.LastScheduleTime = cronJob.Status.LastScheduleTime.DeepCopy()
+	status.ActiveRuns = len(cronJob.Status.Active)
+
+	if cronJob.Spec.Suspend != nil && *cronJob.Spec.Suspend {
+		status.manage().MarkUnknown(TaskConditionJobSucceeded, "Suspended", "Task schedule is suspended")
+	} else {
+		status.manage().MarkUnknown(TaskConditionJobSucceeded, "Scheduled", "Task runs on schedule %q", cronJob.Spec.Schedule)
+	}
+}
+
+func (status *TaskStatus) duck() *duckv1beta1.Status {
+	return &status.Status
+}
@@ -0,0 +1,222 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/kf/testutil"
+	batchv1 "k8s.io/api/batch/v1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	apitesting "knative.dev/pkg/apis/testing"
+)
+
+func initTestTaskStatus(t *testing.T) *TaskStatus {
+	t.Helper()
+	status := &TaskStatus{}
+	status.InitializeConditions()
+
+	// sanity check
+	apitesting.CheckConditionOngoing(status.duck(), TaskConditionSucceeded, t)
+	apitesting.CheckConditionOngoing(status.duck(), TaskConditionJobSucceeded, t)
+
+	return status
+}
+
+func testJob(conditions ...batchv1.JobCondition) *batchv1.Job {
+	job := &batchv1.Job{}
+	job.Name = "some-job"
+	job.Status.StartTime = &metav1.Time{Time: time.Unix(1000, 0)}
+	job.Status.Conditions = conditions
+	return job
+}
+
+func TestTaskStatus_PropagateJobStatus(t *testing.T) {
+	status := initTestTaskStatus(t)
+
+	job := testJob()
+	status.PropagateJobStatus(job)
+
+	testutil.AssertEqual(t, "JobName", "some-job", status.JobName)
+	testutil.AssertEqual(t, "StartTime", int64(1000), status.StartTime.Unix())
+	testutil.AssertEqual(t, "reason", "Pending", status.GetCondition(TaskConditionJobSucceeded).Reason)
+	testutil.AssertEqual(t, "IsFinished", false, status.IsFinished())
+
+	job.Status.Active = 1
+	status.PropagateJobStatus(job)
+	testutil.AssertEqual(t, "reason", "Running", status.GetCondition(TaskConditionJobSucceeded).Reason)
+
+	job = testJob(batchv1.JobCondition{
+		Type:               batchv1.JobFailed,
+		Status:             corev1.ConditionTrue,
+		Reason:             "BackoffLimitExceeded",
+		Message:            "Job has reached the specified backoff limit",
+		LastTransitionTime: metav1.Time{Time: time.Unix(1060, 0)},
+	})
+	status.PropagateJobStatus(job)
+
+	cond := status.GetCondition(TaskConditionJobSucceeded)
+	testutil.AssertEqual(t, "reason", "BackoffLimitExceeded", cond.Reason)
+	testutil.AssertEqual(t, "message", "Task failed: Job has reached the specified backoff limit", cond.Message)
+	testutil.AssertEqual(t, "CompletionTime", int64(1060), status.CompletionTime.Unix())
+	testutil.AssertEqual(t, "IsFinished", true, status.IsFinished())
+}
+
+func TestTaskStatus_PropagateCronJobStatus(t *testing.T) {
+	status := initTestTaskStatus(t)
+
+	cronJob := &batchv1beta1.CronJob{}
+	cronJob.Name = "some-cron-job"
+	cronJob.Spec.Schedule = "0 * * * *"
+	cronJob.Status.LastScheduleTime = &metav1.Time{Time: time.Unix(3600, 0)}
+	cronJob.Status.Active = []corev1.ObjectReference{{Name: "some-cron-job-1"}}
+	status.PropagateCronJobStatus(cronJob)
+
+	testutil.AssertEqual(t, "JobName", "some-cron-job", status.JobName)
+	testutil.AssertEqual(t, "LastScheduleTime", int64(3600), status.LastScheduleTime.Unix())
+	testutil.AssertEqual(t, "ActiveRuns", 1, status.ActiveRuns)
+	testutil.AssertEqual(t, "reason", "Scheduled", status.GetCondition(TaskConditionJobSucceeded).Reason)
+	testutil.AssertEqual(t, "IsFinished", false, status.IsFinished())
+
+	suspend := true
+	cronJob.Spec.Suspend = &suspend
+	status.PropagateCronJobStatus(cronJob)
+	testutil.AssertEqual(t, "reason", "Suspended", status.GetCondition(TaskConditionJobSucceeded).Reason)
+	testutil.AssertEqual(t, "IsFinished", false, status.IsFinished())
+}
+
+func TestTaskStatus_lifecycle(t *testing.T) {
+	cases := map[string]struct {
+		Init func(*TaskStatus)
+
+		ExpectSucceeded []apis.ConditionType
+		ExpectFailed    []apis.ConditionType
+		ExpectOngoing   []apis.ConditionType
+	}{
+		"job complete": {
+			Init: func(status *TaskStatus) {
+				status.PropagateJobStatus(testJob(batchv1.JobCondition{
+					Type:   batchv1.JobComplete,
+					Status: corev1.ConditionTrue,
+				}))
+			},
+			ExpectSucceeded: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"job failed": {
+			Init: func(status *TaskStatus) {
+				status.PropagateJobStatus(testJob(batchv1.JobCondition{
+					Type:   batchv1.JobFailed,
+					Status: corev1.ConditionTrue,
+				}))
+			},
+			ExpectFailed: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"job running": {
+			Init: func(status *TaskStatus) {
+				job := testJob()
+				job.Status.Active = 1
+				status.PropagateJobStatus(job)
+			},
+			ExpectOngoing: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"app not ready": {
+			Init: func(status *TaskStatus) {
+				status.MarkAppNotReady("my-app")
+			},
+			ExpectOngoing: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"app not found": {
+			Init: func(status *TaskStatus) {
+				status.MarkAppNotFound("my-app")
+			},
+			ExpectFailed: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"cron job scheduled": {
+			Init: func(status *TaskStatus) {
+				status.PropagateCronJobStatus(&batchv1beta1.CronJob{})
+			},
+			ExpectOngoing: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"cron job not owned": {
+			Init: func(status *TaskStatus) {
+				status.MarkCronJobNotOwned("my-cron-job")
+			},
+			ExpectFailed: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"job not owned": {
+			Init: func(status *TaskStatus) {
+				status.MarkJobNotOwned("my-job")
+			},
+			ExpectFailed: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+		"terminated": {
+			Init: func(status *TaskStatus) {
+				status.MarkTerminated()
+			},
+			ExpectFailed: []apis.ConditionType{
+				TaskConditionSucceeded,
+				TaskConditionJobSucceeded,
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			status := initTestTaskStatus(t)
+
+			tc.Init(status)
+
+			for _, exp := range tc.ExpectFailed {
+				apitesting.CheckConditionFailed(status.duck(), exp, t)
+			}
+
+			for _, exp := range tc.ExpectOngoing {
+				apitesting.CheckConditionOngoing(status.duck(), exp, t)
+			}
+
+			for _, exp := range tc.ExpectSucceeded {
+				apitesting.CheckConditionSucceeded(status.duck(), exp, t)
+			}
+		})
+	}
+}
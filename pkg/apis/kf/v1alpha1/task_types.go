@@ -0,0 +1,141 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+// +genclient
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// Task is a command run against the latest image of an App, either once or
+// on a schedule.
+type Task struct {
+	metav1.TypeMeta `json:",inline"`
+	// +optional
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	// +optional
+	Spec TaskSpec `json:"spec,omitempty"`
+
+	// +optional
+	Status TaskStatus `json:"status,omitempty"`
+}
+
+// TaskSpec is the desired configuration for a Task.
+type TaskSpec struct {
+	// AppName is the name of the App in the Task's namespace whose image
+	// and environment the command runs with.
+	AppName string `json:"appName"`
+
+	// Command is the shell command to run.
+	Command string `json:"command"`
+
+	// Schedule runs the command periodically rather than once.
+	// +optional
+	Schedule *TaskSpecSchedule `json:"schedule,omitempty"`
+
+	// Terminated stops the Task if it's still running. Scheduled Tasks stop
+	// being run.
+	// +optional
+	Terminated bool `json:"terminated,omitempty"`
+}
+
+// TaskSpecSchedule is the configuration for running a Task periodically.
+type TaskSpecSchedule struct {
+	// Cron is the schedule in Cron format, see
+	// https://en.wikipedia.org/wiki/Cron.
+	Cron string `json:"cron"`
+
+	// ConcurrencyPolicy is how to treat a run that's due while the previous
+	// one is still running. It's one of Allow, Forbid or Replace.
+	// +optional
+	ConcurrencyPolicy batchv1beta1.ConcurrencyPolicy `json:"concurrencyPolicy,omitempty"`
+
+	// SuccessfulRunsHistoryLimit is the number of successful runs to keep.
+	// +optional
+	SuccessfulRunsHistoryLimit *int32 `json:"successfulRunsHistoryLimit,omitempty"`
+
+	// FailedRunsHistoryLimit is the number of failed runs to keep.
+	// +optional
+	FailedRunsHistoryLimit *int32 `json:"failedRunsHistoryLimit,omitempty"`
+
+	// Suspend stops new runs from being started without affecting ones that
+	// have already started.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+}
+
+// IsScheduled returns true if the Task runs periodically.
+func (spec *TaskSpec) IsScheduled() bool {
+	return spec.Schedule != nil
+}
+
+// TaskStatus is the current state of a Task.
+type TaskStatus struct {
+	// Pull in the fields from Knative's duckv1beta1 status field.
+	duckv1beta1.Status `json:",inline"`
+
+	// JobName is the name of the Job running the command, or the CronJob
+	// running it for scheduled Tasks.
+	// +optional
+	JobName string `json:"jobName,omitempty"`
+
+	// Image is the App image the command runs in.
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// StartTime is when the command started.
+	// +optional
+	StartTime *metav1.Time `json:"startTime,omitempty"`
+
+	// CompletionTime is when the command finished, successfully or not.
+	// +optional
+	CompletionTime *metav1.Time `json:"completionTime,omitempty"`
+
+	// LastScheduleTime is when a scheduled Task was last run.
+	// +optional
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+
+	// ActiveRuns is the number of runs of a scheduled Task that are running.
+	// +optional
+	ActiveRuns int `json:"activeRuns,omitempty"`
+}
+
+// TaskNameLabel is set on the Jobs, CronJobs and Pods of a Task to the Task's name.
+const TaskNameLabel = "kf.dev/task"
+
+// ComponentLabels returns the labels of the resources that run the Task.
+func (task *Task) ComponentLabels() map[string]string {
+	return map[string]string{
+		NameLabel:      task.Spec.AppName,
+		ManagedByLabel: "kf",
+		ComponentLabel: "task",
+		TaskNameLabel:  task.Name,
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+// TaskList is a list of Task resources.
+type TaskList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata"`
+
+	Items []Task `json:"items"`
+}
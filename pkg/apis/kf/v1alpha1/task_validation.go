@@ -0,0 +1,133 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"strings"
+
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	"knative.dev/pkg/apis"
+)
+
+// Validate checks for errors in the Task's spec or status fields.
+func (task *Task) Validate(ctx context.Context) (errs *apis.FieldError) {
+	// If we're specifically updating status, don't reject the change because
+	// of a spec issue.
+	if apis.IsInStatusUpdate(ctx) {
+		return nil
+	}
+
+	errs = errs.Also(task.Spec.Validate(apis.WithinSpec(ctx)).ViaField("spec"))
+
+	if base, ok := apis.GetBaseline(ctx).(*Task); ok {
+		errs = errs.Also(task.Spec.validateUpdate(&base.Spec).ViaField("spec"))
+	}
+
+	return errs
+}
+
+// Validate makes sure that TaskSpec is properly configured.
+func (spec *TaskSpec) Validate(ctx context.Context) (errs *apis.FieldError) {
+	if spec.AppName == "" {
+		errs = errs.Also(apis.ErrMissingField("appName"))
+	}
+
+	if spec.Command == "" {
+		errs = errs.Also(apis.ErrMissingField("command"))
+	}
+
+	if spec.Schedule != nil {
+		errs = errs.Also(spec.Schedule.Validate(ctx).ViaField("schedule"))
+	}
+
+	return errs
+}
+
+// cronDescriptors are the predefined schedules that can be used in place of
+// the five Cron fields.
+var cronDescriptors = map[string]bool{
+	"@yearly":   true,
+	"@annually": true,
+	"@monthly":  true,
+	"@weekly":   true,
+	"@daily":    true,
+	"@midnight": true,
+	"@hourly":   true,
+}
+
+// Validate makes sure that TaskSpecSchedule is properly configured.
+func (schedule *TaskSpecSchedule) Validate(ctx context.Context) (errs *apis.FieldError) {
+	switch cron := schedule.Cron; {
+	case cron == "":
+		errs = errs.Also(apis.ErrMissingField("cron"))
+	case strings.HasPrefix(cron, "@every "), cronDescriptors[cron]:
+		// valid
+	case len(strings.Fields(cron)) != 5:
+		errs = errs.Also(apis.ErrInvalidValue(cron, "cron"))
+	}
+
+	switch schedule.ConcurrencyPolicy {
+	case "", batchv1beta1.AllowConcurrent, batchv1beta1.ForbidConcurrent, batchv1beta1.ReplaceConcurrent:
+		// valid
+	default:
+		errs = errs.Also(apis.ErrInvalidValue(schedule.ConcurrencyPolicy, "concurrencyPolicy"))
+	}
+
+	if limit := schedule.SuccessfulRunsHistoryLimit; limit != nil && *limit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*limit, "successfulRunsHistoryLimit"))
+	}
+
+	if limit := schedule.FailedRunsHistoryLimit; limit != nil && *limit < 0 {
+		errs = errs.Also(apis.ErrInvalidValue(*limit, "failedRunsHistoryLimit"))
+	}
+
+	return errs
+}
+
+// validateUpdate makes sure that only the Terminated field of a TaskSpec is
+// changed and that Tasks aren't restarted once terminated.
+func (spec *TaskSpec) validateUpdate(base *TaskSpec) (errs *apis.FieldError) {
+	if spec.AppName != base.AppName {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Immutable field changed",
+			Paths:   []string{"appName"},
+		})
+	}
+
+	// Scheduled Tasks pick up the new command on their next run.
+	if spec.Command != base.Command && !base.IsScheduled() {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Immutable field changed",
+			Paths:   []string{"command"},
+		})
+	}
+
+	if spec.IsScheduled() != base.IsScheduled() {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Tasks can't be changed between scheduled and one-off",
+			Paths:   []string{"schedule"},
+		})
+	}
+
+	if base.Terminated && !spec.Terminated {
+		errs = errs.Also(&apis.FieldError{
+			Message: "Terminated Tasks can't be restarted",
+			Paths:   []string{"terminated"},
+		})
+	}
+
+	return errs
+}
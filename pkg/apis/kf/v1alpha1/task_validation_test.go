@@ -0,0 +1,149 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/testutil"
+	"knative.dev/pkg/apis"
+)
+
+func TestTask_Validate(t *testing.T) {
+	negativeLimit := int32(-1)
+	goodSpec := TaskSpec{
+		AppName: "my-app",
+		Command: "rake db:migrate",
+	}
+
+	cases := map[string]struct {
+		task Task
+		base *Task
+		want *apis.FieldError
+	}{
+		"valid": {
+			task: Task{Spec: goodSpec},
+		},
+		"missing fields": {
+			task: Task{},
+			want: apis.ErrMissingField("spec.appName", "spec.command"),
+		},
+		"terminated": {
+			task: Task{Spec: TaskSpec{
+				AppName:    "my-app",
+				Command:    "rake db:migrate",
+				Terminated: true,
+			}},
+			base: &Task{Spec: goodSpec},
+		},
+		"command changed": {
+			task: Task{Spec: TaskSpec{
+				AppName: "other-app",
+				Command: "rake db:seed",
+			}},
+			base: &Task{Spec: goodSpec},
+			want: (&apis.FieldError{
+				Message: "Immutable field changed",
+				Paths:   []string{"appName", "command"},
+			}).ViaField("spec"),
+		},
+		"scheduled": {
+			task: Task{Spec: TaskSpec{
+				AppName:  "my-app",
+				Command:  "rake db:cleanup",
+				Schedule: &TaskSpecSchedule{Cron: "0 3 * * *"},
+			}},
+		},
+		"scheduled with descriptor": {
+			task: Task{Spec: TaskSpec{
+				AppName:  "my-app",
+				Command:  "rake db:cleanup",
+				Schedule: &TaskSpecSchedule{Cron: "@every 1h30m"},
+			}},
+		},
+		"bad schedule": {
+			task: Task{Spec: TaskSpec{
+				AppName: "my-app",
+				Command: "rake db:cleanup",
+				Schedule: &TaskSpecSchedule{
+					Cron:                   "every day",
+					ConcurrencyPolicy:      "Sometimes",
+					FailedRunsHistoryLimit: &negativeLimit,
+				},
+			}},
+			want: apis.ErrInvalidValue("every day", "spec.schedule.cron").
+				Also(apis.ErrInvalidValue("Sometimes", "spec.schedule.concurrencyPolicy")).
+				Also(apis.ErrInvalidValue(-1, "spec.schedule.failedRunsHistoryLimit")),
+		},
+		"missing cron": {
+			task: Task{Spec: TaskSpec{
+				AppName:  "my-app",
+				Command:  "rake db:cleanup",
+				Schedule: &TaskSpecSchedule{},
+			}},
+			want: apis.ErrMissingField("spec.schedule.cron"),
+		},
+		"scheduled command changed": {
+			task: Task{Spec: TaskSpec{
+				AppName:  "my-app",
+				Command:  "rake db:vacuum",
+				Schedule: &TaskSpecSchedule{Cron: "@daily"},
+			}},
+			base: &Task{Spec: TaskSpec{
+				AppName:  "my-app",
+				Command:  "rake db:cleanup",
+				Schedule: &TaskSpecSchedule{Cron: "@hourly"},
+			}},
+		},
+		"schedule added": {
+			task: Task{Spec: TaskSpec{
+				AppName:  "my-app",
+				Command:  "rake db:migrate",
+				Schedule: &TaskSpecSchedule{Cron: "@daily"},
+			}},
+			base: &Task{Spec: goodSpec},
+			want: (&apis.FieldError{
+				Message: "Tasks can't be changed between scheduled and one-off",
+				Paths:   []string{"schedule"},
+			}).ViaField("spec"),
+		},
+		"restarted": {
+			task: Task{Spec: goodSpec},
+			base: &Task{Spec: TaskSpec{
+				AppName:    "my-app",
+				Command:    "rake db:migrate",
+				Terminated: true,
+			}},
+			want: (&apis.FieldError{
+				Message: "Terminated Tasks can't be restarted",
+				Paths:   []string{"terminated"},
+			}).ViaField("spec"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctx := context.Background()
+			if tc.base != nil {
+				ctx = apis.WithinUpdate(ctx, tc.base)
+			}
+
+			got := tc.task.Validate(ctx)
+
+			testutil.AssertEqual(t, "validation errors", tc.want.Error(), got.Error())
+		})
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"strings"
 
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	cv1alpha3 "knative.dev/pkg/client/clientset/versioned/typed/istio/v1alpha3"
 )
@@ -167,3 +168,23 @@ func SetupIstioClient(ctx context.Context, istioClient cv1alpha3.VirtualServices
 func IstioClientFromContext(ctx context.Context) cv1alpha3.VirtualServicesGetter {
 	return ctx.Value(istioClientKey{}).(cv1alpha3.VirtualServicesGetter)
 }
+
+// SpaceGetter gets a Space by name. It's implemented by the Spaces client.
+type SpaceGetter interface {
+	Get(name string, options metav1.GetOptions) (*Space, error)
+}
+
+type spaceGetterKey struct{}
+
+// SetupSpaceGetter adds a SpaceGetter to the context so resources can be
+// validated against the policies of their space.
+func SetupSpaceGetter(ctx context.Context, getter SpaceGetter) context.Context {
+	return context.WithValue(ctx, spaceGetterKey{}, getter)
+}
+
+// SpaceGetterFromContext returns the SpaceGetter on the context or nil if
+// there isn't one.
+func SpaceGetterFromContext(ctx context.Context) SpaceGetter {
+	getter, _ := ctx.Value(spaceGetterKey{}).(SpaceGetter)
+	return getter
+}
@@ -20,7 +20,9 @@ package v1alpha1
 
 import (
 	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
+	apis "knative.dev/pkg/apis"
 )
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
@@ -84,6 +86,23 @@ func (in *AppList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppRevision) DeepCopyInto(out *AppRevision) {
+	*out = *in
+	in.ReadyTime.DeepCopyInto(&out.ReadyTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppRevision.
+func (in *AppRevision) DeepCopy() *AppRevision {
+	if in == nil {
+		return nil
+	}
+	out := new(AppRevision)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 	*out = *in
@@ -95,6 +114,20 @@ func (in *AppSpec) DeepCopyInto(out *AppSpec) {
 		*out = make([]RouteSpecFields, len(*in))
 		copy(*out, *in)
 	}
+	in.Rollout.DeepCopyInto(&out.Rollout)
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int)
+		**out = **in
+	}
+	in.NetworkPolicy.DeepCopyInto(&out.NetworkPolicy)
+	if in.Processes != nil {
+		in, out := &in.Processes, &out.Processes
+		*out = make([]AppSpecProcess, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -139,6 +172,87 @@ func (in *AppSpecInstances) DeepCopy() *AppSpecInstances {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppSpecNetworkPolicy) DeepCopyInto(out *AppSpecNetworkPolicy) {
+	*out = *in
+	if in.AllowedSourceApps != nil {
+		in, out := &in.AllowedSourceApps, &out.AllowedSourceApps
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSpecNetworkPolicy.
+func (in *AppSpecNetworkPolicy) DeepCopy() *AppSpecNetworkPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(AppSpecNetworkPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppSpecProcess) DeepCopyInto(out *AppSpecProcess) {
+	*out = *in
+	if in.Instances != nil {
+		in, out := &in.Instances, &out.Instances
+		*out = new(int)
+		**out = **in
+	}
+	in.Resources.DeepCopyInto(&out.Resources)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSpecProcess.
+func (in *AppSpecProcess) DeepCopy() *AppSpecProcess {
+	if in == nil {
+		return nil
+	}
+	out := new(AppSpecProcess)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppSpecRollout) DeepCopyInto(out *AppSpecRollout) {
+	*out = *in
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]AppSpecRolloutStep, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSpecRollout.
+func (in *AppSpecRollout) DeepCopy() *AppSpecRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(AppSpecRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppSpecRolloutStep) DeepCopyInto(out *AppSpecRolloutStep) {
+	*out = *in
+	out.Pause = in.Pause
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppSpecRolloutStep.
+func (in *AppSpecRolloutStep) DeepCopy() *AppSpecRolloutStep {
+	if in == nil {
+		return nil
+	}
+	out := new(AppSpecRolloutStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AppSpecTemplate) DeepCopyInto(out *AppSpecTemplate) {
 	*out = *in
@@ -160,9 +274,17 @@ func (in *AppSpecTemplate) DeepCopy() *AppSpecTemplate {
 func (in *AppStatus) DeepCopyInto(out *AppStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
-	out.SourceStatusFields = in.SourceStatusFields
+	in.SourceStatusFields.DeepCopyInto(&out.SourceStatusFields)
 	out.ConfigurationStatusFields = in.ConfigurationStatusFields
 	in.RouteStatusFields.DeepCopyInto(&out.RouteStatusFields)
+	in.Rollout.DeepCopyInto(&out.Rollout)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]AppRevision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -176,6 +298,26 @@ func (in *AppStatus) DeepCopy() *AppStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppStatusRollout) DeepCopyInto(out *AppStatusRollout) {
+	*out = *in
+	if in.StepStartTime != nil {
+		in, out := &in.StepStartTime, &out.StepStartTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppStatusRollout.
+func (in *AppStatusRollout) DeepCopy() *AppStatusRollout {
+	if in == nil {
+		return nil
+	}
+	out := new(AppStatusRollout)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in HTTPRoutes) DeepCopyInto(out *HTTPRoutes) {
 	{
@@ -226,6 +368,7 @@ func (in *Route) DeepCopyInto(out *Route) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
 	return
 }
 
@@ -288,6 +431,33 @@ func (in *RouteSpec) DeepCopyInto(out *RouteSpec) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.AppWeights != nil {
+		in, out := &in.AppWeights, &out.AppWeights
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.Retries != nil {
+		in, out := &in.Retries, &out.Retries
+		*out = new(RouteSpecRetries)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = new(RouteSpecHeaders)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AccessPolicy != nil {
+		in, out := &in.AccessPolicy, &out.AccessPolicy
+		*out = new(RouteSpecAccessPolicy)
+		(*in).DeepCopyInto(*out)
+	}
 	out.RouteSpecFields = in.RouteSpecFields
 	return
 }
@@ -302,6 +472,32 @@ func (in *RouteSpec) DeepCopy() *RouteSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteSpecAccessPolicy) DeepCopyInto(out *RouteSpecAccessPolicy) {
+	*out = *in
+	if in.AllowedSourceCIDRs != nil {
+		in, out := &in.AllowedSourceCIDRs, &out.AllowedSourceCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DeniedSourceCIDRs != nil {
+		in, out := &in.DeniedSourceCIDRs, &out.DeniedSourceCIDRs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteSpecAccessPolicy.
+func (in *RouteSpecAccessPolicy) DeepCopy() *RouteSpecAccessPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteSpecAccessPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RouteSpecFields) DeepCopyInto(out *RouteSpecFields) {
 	*out = *in
@@ -318,6 +514,110 @@ func (in *RouteSpecFields) DeepCopy() *RouteSpecFields {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteSpecHeaderOperations) DeepCopyInto(out *RouteSpecHeaderOperations) {
+	*out = *in
+	if in.Set != nil {
+		in, out := &in.Set, &out.Set
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Add != nil {
+		in, out := &in.Add, &out.Add
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Remove != nil {
+		in, out := &in.Remove, &out.Remove
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteSpecHeaderOperations.
+func (in *RouteSpecHeaderOperations) DeepCopy() *RouteSpecHeaderOperations {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteSpecHeaderOperations)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteSpecHeaders) DeepCopyInto(out *RouteSpecHeaders) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = new(RouteSpecHeaderOperations)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Response != nil {
+		in, out := &in.Response, &out.Response
+		*out = new(RouteSpecHeaderOperations)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteSpecHeaders.
+func (in *RouteSpecHeaders) DeepCopy() *RouteSpecHeaders {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteSpecHeaders)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteSpecRetries) DeepCopyInto(out *RouteSpecRetries) {
+	*out = *in
+	if in.PerTryTimeout != nil {
+		in, out := &in.PerTryTimeout, &out.PerTryTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteSpecRetries.
+func (in *RouteSpecRetries) DeepCopy() *RouteSpecRetries {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteSpecRetries)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RouteStatus) DeepCopyInto(out *RouteStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(apis.URL)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RouteStatus.
+func (in *RouteStatus) DeepCopy() *RouteStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RouteStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Source) DeepCopyInto(out *Source) {
 	*out = *in
@@ -379,11 +679,54 @@ func (in *SourceList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceProvenance) DeepCopyInto(out *SourceProvenance) {
+	*out = *in
+	if in.Buildpacks != nil {
+		in, out := &in.Buildpacks, &out.Buildpacks
+		*out = make([]SourceProvenanceBuildpack, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceProvenance.
+func (in *SourceProvenance) DeepCopy() *SourceProvenance {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceProvenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceProvenanceBuildpack) DeepCopyInto(out *SourceProvenanceBuildpack) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceProvenanceBuildpack.
+func (in *SourceProvenanceBuildpack) DeepCopy() *SourceProvenanceBuildpack {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceProvenanceBuildpack)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceSpec) DeepCopyInto(out *SourceSpec) {
 	*out = *in
 	out.ContainerImage = in.ContainerImage
 	in.BuildpackBuild.DeepCopyInto(&out.BuildpackBuild)
+	in.Dockerfile.DeepCopyInto(&out.Dockerfile)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	return
 }
 
@@ -400,6 +743,16 @@ func (in *SourceSpec) DeepCopy() *SourceSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceSpecBuildpackBuild) DeepCopyInto(out *SourceSpecBuildpackBuild) {
 	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(SourceSpecGit)
+		**out = **in
+	}
+	if in.Buildpacks != nil {
+		in, out := &in.Buildpacks, &out.Buildpacks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]v1.EnvVar, len(*in))
@@ -436,11 +789,70 @@ func (in *SourceSpecContainerImage) DeepCopy() *SourceSpecContainerImage {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceSpecDockerfile) DeepCopyInto(out *SourceSpecDockerfile) {
+	*out = *in
+	if in.Git != nil {
+		in, out := &in.Git, &out.Git
+		*out = new(SourceSpecGit)
+		**out = **in
+	}
+	if in.BuildArgs != nil {
+		in, out := &in.BuildArgs, &out.BuildArgs
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceSpecDockerfile.
+func (in *SourceSpecDockerfile) DeepCopy() *SourceSpecDockerfile {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceSpecDockerfile)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceSpecGit) DeepCopyInto(out *SourceSpecGit) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceSpecGit.
+func (in *SourceSpecGit) DeepCopy() *SourceSpecGit {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceSpecGit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceStatus) DeepCopyInto(out *SourceStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
-	out.SourceStatusFields = in.SourceStatusFields
+	in.SourceStatusFields.DeepCopyInto(&out.SourceStatusFields)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.Steps != nil {
+		in, out := &in.Steps, &out.Steps
+		*out = make([]SourceStatusStep, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -457,6 +869,11 @@ func (in *SourceStatus) DeepCopy() *SourceStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SourceStatusFields) DeepCopyInto(out *SourceStatusFields) {
 	*out = *in
+	if in.Provenance != nil {
+		in, out := &in.Provenance, &out.Provenance
+		*out = new(SourceProvenance)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
@@ -470,6 +887,23 @@ func (in *SourceStatusFields) DeepCopy() *SourceStatusFields {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SourceStatusStep) DeepCopyInto(out *SourceStatusStep) {
+	*out = *in
+	in.ContainerState.DeepCopyInto(&out.ContainerState)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SourceStatusStep.
+func (in *SourceStatusStep) DeepCopy() *SourceStatusStep {
+	if in == nil {
+		return nil
+	}
+	out := new(SourceStatusStep)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Space) DeepCopyInto(out *Space) {
 	*out = *in
@@ -501,6 +935,11 @@ func (in *Space) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceDomain) DeepCopyInto(out *SpaceDomain) {
 	*out = *in
+	if in.TCPPorts != nil {
+		in, out := &in.TCPPorts, &out.TCPPorts
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
 	return
 }
 
@@ -519,7 +958,9 @@ func (in SpaceDomains) DeepCopyInto(out *SpaceDomains) {
 	{
 		in := &in
 		*out = make(SpaceDomains, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 		return
 	}
 }
@@ -587,9 +1028,40 @@ func (in *SpaceSpec) DeepCopy() *SpaceSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceSpecBuildRetention) DeepCopyInto(out *SpaceSpecBuildRetention) {
+	*out = *in
+	if in.SuccessfulBuildsLimit != nil {
+		in, out := &in.SuccessfulBuildsLimit, &out.SuccessfulBuildsLimit
+		*out = new(int)
+		**out = **in
+	}
+	if in.FailedBuildsLimit != nil {
+		in, out := &in.FailedBuildsLimit, &out.FailedBuildsLimit
+		*out = new(int)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceSpecBuildRetention.
+func (in *SpaceSpecBuildRetention) DeepCopy() *SpaceSpecBuildRetention {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceSpecBuildRetention)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *SpaceSpecBuildpackBuild) DeepCopyInto(out *SpaceSpecBuildpackBuild) {
 	*out = *in
+	if in.AllowedBuilderImages != nil {
+		in, out := &in.AllowedBuilderImages, &out.AllowedBuilderImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.Env != nil {
 		in, out := &in.Env, &out.Env
 		*out = make([]v1.EnvVar, len(*in))
@@ -597,6 +1069,12 @@ func (in *SpaceSpecBuildpackBuild) DeepCopyInto(out *SpaceSpecBuildpackBuild) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	in.Retention.DeepCopyInto(&out.Retention)
 	return
 }
 
@@ -623,7 +1101,9 @@ func (in *SpaceSpecExecution) DeepCopyInto(out *SpaceSpecExecution) {
 	if in.Domains != nil {
 		in, out := &in.Domains, &out.Domains
 		*out = make([]SpaceDomain, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	return
 }
@@ -689,6 +1169,13 @@ func (in *SpaceStatus) DeepCopyInto(out *SpaceStatus) {
 	*out = *in
 	in.Status.DeepCopyInto(&out.Status)
 	in.Quota.DeepCopyInto(&out.Quota)
+	if in.Certificates != nil {
+		in, out := &in.Certificates, &out.Certificates
+		*out = make([]SpaceStatusCertificate, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
@@ -701,3 +1188,157 @@ func (in *SpaceStatus) DeepCopy() *SpaceStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpaceStatusCertificate) DeepCopyInto(out *SpaceStatusCertificate) {
+	*out = *in
+	in.NotAfter.DeepCopyInto(&out.NotAfter)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpaceStatusCertificate.
+func (in *SpaceStatusCertificate) DeepCopy() *SpaceStatusCertificate {
+	if in == nil {
+		return nil
+	}
+	out := new(SpaceStatusCertificate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Task) DeepCopyInto(out *Task) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Task.
+func (in *Task) DeepCopy() *Task {
+	if in == nil {
+		return nil
+	}
+	out := new(Task)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Task) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskList) DeepCopyInto(out *TaskList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Task, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskList.
+func (in *TaskList) DeepCopy() *TaskList {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
+	*out = *in
+	if in.Schedule != nil {
+		in, out := &in.Schedule, &out.Schedule
+		*out = new(TaskSpecSchedule)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskSpec.
+func (in *TaskSpec) DeepCopy() *TaskSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskSpecSchedule) DeepCopyInto(out *TaskSpecSchedule) {
+	*out = *in
+	if in.SuccessfulRunsHistoryLimit != nil {
+		in, out := &in.SuccessfulRunsHistoryLimit, &out.SuccessfulRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedRunsHistoryLimit != nil {
+		in, out := &in.FailedRunsHistoryLimit, &out.FailedRunsHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskSpecSchedule.
+func (in *TaskSpecSchedule) DeepCopy() *TaskSpecSchedule {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskSpecSchedule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskStatus) DeepCopyInto(out *TaskStatus) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+	if in.StartTime != nil {
+		in, out := &in.StartTime, &out.StartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskStatus.
+func (in *TaskStatus) DeepCopy() *TaskStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(TaskStatus)
+	in.DeepCopyInto(out)
+	return out
+}
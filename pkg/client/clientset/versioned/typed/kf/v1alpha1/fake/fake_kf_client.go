@@ -42,6 +42,10 @@ func (c *FakeKfV1alpha1) Spaces() v1alpha1.SpaceInterface {
 	return &FakeSpaces{c}
 }
 
+func (c *FakeKfV1alpha1) Tasks(namespace string) v1alpha1.TaskInterface {
+	return &FakeTasks{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeKfV1alpha1) RESTClient() rest.Interface {
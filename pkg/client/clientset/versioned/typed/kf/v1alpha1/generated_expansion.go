@@ -23,3 +23,5 @@ type RouteExpansion interface{}
 type SourceExpansion interface{}
 
 type SpaceExpansion interface{}
+
+type TaskExpansion interface{}
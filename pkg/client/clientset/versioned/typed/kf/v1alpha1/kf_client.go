@@ -29,6 +29,7 @@ type KfV1alpha1Interface interface {
 	RoutesGetter
 	SourcesGetter
 	SpacesGetter
+	TasksGetter
 }
 
 // KfV1alpha1Client is used to interact with features provided by the kf.dev group.
@@ -52,6 +53,10 @@ func (c *KfV1alpha1Client) Spaces() SpaceInterface {
 	return newSpaces(c)
 }
 
+func (c *KfV1alpha1Client) Tasks(namespace string) TaskInterface {
+	return newTasks(c, namespace)
+}
+
 // NewForConfig creates a new KfV1alpha1Client for the given config.
 func NewForConfig(c *rest.Config) (*KfV1alpha1Client, error) {
 	config := *c
@@ -59,6 +59,8 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Sources().Informer()}, nil
 	case v1alpha1.SchemeGroupVersion.WithResource("spaces"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Spaces().Informer()}, nil
+	case v1alpha1.SchemeGroupVersion.WithResource("tasks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Kf().V1alpha1().Tasks().Informer()}, nil
 
 	}
 
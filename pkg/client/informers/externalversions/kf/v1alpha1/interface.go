@@ -30,6 +30,8 @@ type Interface interface {
 	Sources() SourceInformer
 	// Spaces returns a SpaceInformer.
 	Spaces() SpaceInformer
+	// Tasks returns a TaskInformer.
+	Tasks() TaskInformer
 }
 
 type version struct {
@@ -62,3 +64,8 @@ func (v *version) Sources() SourceInformer {
 func (v *version) Spaces() SpaceInformer {
 	return &spaceInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
 }
+
+// Tasks returns a TaskInformer.
+func (v *version) Tasks() TaskInformer {
+	return &taskInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
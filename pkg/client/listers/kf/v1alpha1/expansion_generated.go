@@ -43,3 +43,11 @@ type SourceNamespaceListerExpansion interface{}
 // SpaceListerExpansion allows custom methods to be added to
 // SpaceLister.
 type SpaceListerExpansion interface{}
+
+// TaskListerExpansion allows custom methods to be added to
+// TaskLister.
+type TaskListerExpansion interface{}
+
+// TaskNamespaceListerExpansion allows custom methods to be added to
+// TaskNamespaceLister.
+type TaskNamespaceListerExpansion interface{}
@@ -28,8 +28,10 @@ type ClientExtension interface {
 	DeleteInForeground(namespace string, name string) error
 
 	// DeployLogs writes the logs for the build and deploy stage to the given
-	// out.  The method exits once the logs are done streaming.
-	DeployLogs(out io.Writer, appName, resourceVersion, namespace string, noStart bool) error
+	// out.  The method exits once the logs are done streaming. If
+	// cancelOnInterrupt is set, interrupting the process while the App is
+	// building cancels the build.
+	DeployLogs(out io.Writer, appName, resourceVersion, namespace string, noStart, cancelOnInterrupt bool) error
 	Restart(namespace, name string) error
 	Restage(namespace, name string) error
 }
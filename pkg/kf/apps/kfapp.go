@@ -108,6 +108,72 @@ func (k *KfApp) GetContainerPorts() []corev1.ContainerPort {
 	return nil
 }
 
+// SetCommand sets the shell command that starts the application instead of
+// the image's default process.
+func (k *KfApp) SetCommand(command string) {
+	k.getOrCreateRevisionTemplateSpec().Command = command
+}
+
+// GetCommand gets the shell command that starts the application or an empty
+// string if it runs the image's default process.
+func (k *KfApp) GetCommand() string {
+	if rl := k.getRevisionTemplateSpecOrNil(); rl != nil {
+		return rl.Command
+	}
+
+	return ""
+}
+
+// SetArgs sets the arguments passed to the container's entrypoint.
+func (k *KfApp) SetArgs(args []string) {
+	k.getOrCreateContainer().Args = args
+}
+
+// GetArgs gets the arguments passed to the container's entrypoint.
+func (k *KfApp) GetArgs() []string {
+	if container := k.getContainerOrNil(); container != nil {
+		return container.Args
+	}
+
+	return nil
+}
+
+// GetResources gets the compute resources requested and limited for each
+// instance of the application.
+func (k *KfApp) GetResources() corev1.ResourceRequirements {
+	if container := k.getContainerOrNil(); container != nil {
+		return container.Resources
+	}
+
+	return corev1.ResourceRequirements{}
+}
+
+// SetResources sets the compute resources requested and limited for each
+// instance of the application.
+func (k *KfApp) SetResources(resources corev1.ResourceRequirements) {
+	k.getOrCreateContainer().Resources = resources
+}
+
+// MergeResources adds the requests and limits listed to the existing ones,
+// overwriting duplicates by resource name.
+func (k *KfApp) MergeResources(resources corev1.ResourceRequirements) {
+	container := k.getOrCreateContainer()
+
+	for name, quantity := range resources.Requests {
+		if container.Resources.Requests == nil {
+			container.Resources.Requests = corev1.ResourceList{}
+		}
+		container.Resources.Requests[name] = quantity
+	}
+
+	for name, quantity := range resources.Limits {
+		if container.Resources.Limits == nil {
+			container.Resources.Limits = corev1.ResourceList{}
+		}
+		container.Resources.Limits[name] = quantity
+	}
+}
+
 // SetServiceAccount sets the account the application will run as.
 func (k *KfApp) SetServiceAccount(sa string) {
 	k.getOrCreateRevisionTemplateSpec().Spec.ServiceAccountName = sa
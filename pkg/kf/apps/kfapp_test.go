@@ -23,6 +23,7 @@ import (
 	"github.com/google/kf/pkg/kf/describe"
 	"github.com/google/kf/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -157,6 +158,56 @@ func ExampleKfApp_GetImage() {
 	// After set: "my-company/my-app"
 }
 
+func ExampleKfApp_GetCommand() {
+	myApp := NewKfApp()
+	fmt.Printf("Default: %q\n", myApp.GetCommand())
+
+	myApp.SetCommand("bundle exec rails server")
+	fmt.Printf("After set: %q\n", myApp.GetCommand())
+
+	// Output: Default: ""
+	// After set: "bundle exec rails server"
+}
+
+func ExampleKfApp_GetArgs() {
+	myApp := NewKfApp()
+	fmt.Printf("Default: %q\n", myApp.GetArgs())
+
+	myApp.SetArgs([]string{"--port", "8080"})
+	fmt.Printf("After set: %q\n", myApp.GetArgs())
+
+	// Output: Default: []
+	// After set: ["--port" "8080"]
+}
+
+func ExampleKfApp_MergeResources() {
+	myApp := NewKfApp()
+	myApp.SetResources(corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("1Gi"),
+			corev1.ResourceCPU:    resource.MustParse("1"),
+		},
+	})
+
+	myApp.MergeResources(corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	})
+
+	resources := myApp.GetResources()
+	fmt.Println("Memory request:", resources.Requests.Memory())
+	fmt.Println("Memory limit:", resources.Limits.Memory())
+	fmt.Println("CPU request:", resources.Requests.Cpu())
+
+	// Output: Memory request: 512Mi
+	// Memory limit: 512Mi
+	// CPU request: 1
+}
+
 func ExampleKfApp_GetContainerPorts() {
 	myApp := NewKfApp()
 	fmt.Printf("Default: %v\n", myApp.GetContainerPorts())
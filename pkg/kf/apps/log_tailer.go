@@ -19,12 +19,15 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"os"
+	"os/signal"
 	"sync"
 	"time"
 
 	"k8s.io/apimachinery/pkg/fields"
 
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/sources"
 	corev1 "k8s.io/api/core/v1"
 	k8smeta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -37,12 +40,20 @@ type pushLogTailer struct {
 	resourceVersion      string
 	namespace            string
 	noStart              bool
+	cancelOnInterrupt    bool
 	buildStartTime       time.Time
 	deployStartTime      time.Time
 	ctx                  context.Context
 	ctxCancel            func()
 	tailBuildLogsOnce    sync.Once
 	checkSourceReadyOnce sync.Once
+
+	// mu guards the fields used to cancel the build on interrupt.
+	mu          sync.Mutex
+	sourceName  string
+	interrupted bool
+	cancelled   bool
+	interrupts  chan os.Signal
 }
 
 func newPushLogTailer(
@@ -52,15 +63,17 @@ func newPushLogTailer(
 	resourceVersion string,
 	namespace string,
 	noStart bool,
+	cancelOnInterrupt bool,
 ) *pushLogTailer {
 
 	t := &pushLogTailer{
-		client:          client,
-		out:             out,
-		appName:         appName,
-		resourceVersion: resourceVersion,
-		namespace:       namespace,
-		noStart:         noStart,
+		client:            client,
+		out:               out,
+		appName:           appName,
+		resourceVersion:   resourceVersion,
+		namespace:         namespace,
+		noStart:           noStart,
+		cancelOnInterrupt: cancelOnInterrupt,
 	}
 
 	t.logger = log.New(out, "\033[32m[build]\033[0m ", 0)
@@ -78,11 +91,17 @@ func (a *appsClient) DeployLogs(
 	resourceVersion string,
 	namespace string,
 	noStart bool,
+	cancelOnInterrupt bool,
 ) error {
 
-	t := newPushLogTailer(a, out, appName, resourceVersion, namespace, noStart)
+	t := newPushLogTailer(a, out, appName, resourceVersion, namespace, noStart, cancelOnInterrupt)
 	defer t.ctxCancel()
 
+	if cancelOnInterrupt {
+		t.watchInterrupts()
+		defer t.stopWatchingInterrupts()
+	}
+
 	for {
 		done, err := t.handleWatch()
 		if err != nil {
@@ -139,17 +158,20 @@ func (t *pushLogTailer) handleUpdate(
 		t.checkSourceReadyOnce.Do(func() {
 			duration := time.Now().Sub(t.buildStartTime)
 			t.logger.Printf("Built in %0.2f seconds\n", duration.Seconds())
+			t.stopWatchingInterrupts()
 			t.ctxCancel()
 			t.deployStartTime = time.Now()
 		})
 	case corev1.ConditionFalse:
 		t.logger.Printf("Failed to build: %s\n", sourceReady.Message)
+		t.stopWatchingInterrupts()
 		t.ctxCancel()
 		return true, fmt.Errorf("build failed: %s", sourceReady.Message)
 	default:
 
 		// This case should mean the Source is still in progress.
 		// It should be safe to tail the logs to show the user what's happening.
+		t.setSourceName(app.Status.LatestCreatedSourceName)
 		go t.tailBuildLogsOnce.Do(
 			func() {
 				// ignoring tail errs because they are spurious
@@ -187,3 +209,63 @@ func (t *pushLogTailer) handleUpdate(
 
 	return false, nil
 }
+
+// watchInterrupts cancels the in-flight build the first time the process is
+// interrupted. Later interrupts aren't caught so the user can still exit
+// without waiting for the build to stop.
+func (t *pushLogTailer) watchInterrupts() {
+	t.interrupts = make(chan os.Signal, 1)
+	signal.Notify(t.interrupts, os.Interrupt)
+
+	go func() {
+		select {
+		case <-t.interrupts:
+		case <-t.ctx.Done():
+			return
+		}
+
+		t.stopWatchingInterrupts()
+		t.logger.Println("Interrupted, cancelling build")
+
+		t.mu.Lock()
+		t.interrupted = true
+		t.mu.Unlock()
+		t.cancelBuild()
+	}()
+}
+
+// stopWatchingInterrupts restores the default interrupt handling once the
+// build is no longer running.
+func (t *pushLogTailer) stopWatchingInterrupts() {
+	if t.interrupts != nil {
+		signal.Stop(t.interrupts)
+	}
+}
+
+// setSourceName records the Source being built so it can be cancelled, and
+// cancels it if the process was interrupted before the Source was known.
+func (t *pushLogTailer) setSourceName(name string) {
+	t.mu.Lock()
+	t.sourceName = name
+	t.mu.Unlock()
+	t.cancelBuild()
+}
+
+// cancelBuild cancels the Source being built if the process was interrupted.
+// The build is only cancelled once, the watch picks up the failed build and
+// exits the push.
+func (t *pushLogTailer) cancelBuild() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if !t.interrupted || t.cancelled || t.sourceName == "" {
+		return
+	}
+	t.cancelled = true
+
+	if err := t.client.sourcesClient.Transform(t.namespace, t.sourceName, sources.CancelBuild); err != nil {
+		t.logger.Printf("Failed to cancel build: %s\n", err)
+		return
+	}
+	t.logger.Printf("Cancelled build %s\n", t.sourceName)
+}
@@ -138,6 +138,7 @@ func TestLogTailer_DeployLogs_ServiceLogs(t *testing.T) {
 				tc.resourceVersion,
 				tc.namespace,
 				tc.noStart,
+				false,
 			)
 			if tc.wantErr != nil || gotErr != nil {
 				testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
@@ -62,11 +62,20 @@ func newApp(appName string, opts ...PushOption) (*v1alpha1.App, error) {
 	}
 
 	src := sources.NewKfSource()
-	src.SetBuildpackBuildSource(cfg.SourceImage)
 	src.SetContainerImageSource(cfg.ContainerImage)
-	src.SetBuildpackBuildRegistry(cfg.ContainerRegistry)
-	src.SetBuildpackBuildEnv(envs)
-	src.SetBuildpackBuildBuildpack(cfg.Buildpack)
+	if cfg.Dockerfile != "" {
+		src.SetDockerfileSource(cfg.SourceImage)
+		src.SetDockerfileGit(cfg.GitSource)
+		src.SetDockerfilePath(cfg.Dockerfile)
+		src.SetDockerfileRegistry(cfg.ContainerRegistry)
+	} else {
+		src.SetBuildpackBuildSource(cfg.SourceImage)
+		src.SetBuildpackBuildGit(cfg.GitSource)
+		src.SetBuildpackBuildRegistry(cfg.ContainerRegistry)
+		src.SetBuildpackBuildEnv(envs)
+		src.SetBuildpackBuildBuildpacks(cfg.Buildpacks)
+		src.SetBuildpackBuildStack(cfg.Stack)
+	}
 
 	app := NewKfApp()
 	app.SetName(appName)
@@ -76,6 +85,18 @@ func newApp(appName string, opts ...PushOption) (*v1alpha1.App, error) {
 	app.Spec.Instances.Stopped = cfg.NoStart
 	app.SetHealthCheck(cfg.HealthCheck)
 	app.Spec.Routes = cfg.Routes
+	app.Spec.Rollout.Strategy = cfg.RolloutStrategy
+	app.Spec.Rollout.CanaryPercent = cfg.CanaryPercent
+	app.Spec.Processes = cfg.Processes
+	app.SetCommand(cfg.Command)
+
+	if len(cfg.Args) > 0 {
+		app.SetArgs(cfg.Args)
+	}
+
+	if cfg.Resources != nil {
+		app.SetResources(*cfg.Resources)
+	}
 
 	if cfg.Grpc {
 		app.SetContainerPorts([]corev1.ContainerPort{{Name: "h2c", ContainerPort: 8080}})
@@ -130,6 +151,7 @@ func (p *pusher) Push(appName string, opts ...PushOption) error {
 		resultingApp.ResourceVersion,
 		app.Namespace,
 		cfg.NoStart,
+		cfg.CancelOnInterrupt,
 	); err != nil {
 		return err
 	}
@@ -208,6 +230,47 @@ func mergeApps(cfg pushConfig, hasDefaultRoutes bool) func(newapp, oldapp *v1alp
 			newapp.Spec.Instances.Exactly = &singleInstance
 		}
 
+		// Rollout overrides
+		if cfg.RolloutStrategy == "" {
+			// Looks like the user did not set a new strategy, use the old one
+			newapp.Spec.Rollout = oldapp.Spec.Rollout
+		} else {
+			if cfg.CanaryPercent == 0 {
+				// Keep the existing canary schedule
+				newapp.Spec.Rollout.CanaryPercent = oldapp.Spec.Rollout.CanaryPercent
+				newapp.Spec.Rollout.Steps = oldapp.Spec.Rollout.Steps
+			}
+
+			// Promotions and rollbacks refer to existing revisions
+			newapp.Spec.Rollout.PromotedRevisionName = oldapp.Spec.Rollout.PromotedRevisionName
+			newapp.Spec.Rollout.AbortedRevisionName = oldapp.Spec.Rollout.AbortedRevisionName
+		}
+
+		// Pushing deploys a new revision so traffic is no longer pinned to a
+		// rolled back one
+		newapp.Spec.Rollout.RollbackRevisionName = ""
+
+		// Resources that weren't set on push keep their old values
+		resources := NewFromApp(oldapp).GetResources()
+		newKfApp := NewFromApp(newapp)
+		newKfApp.SetResources(*resources.DeepCopy())
+		if cfg.Resources != nil {
+			newKfApp.MergeResources(*cfg.Resources)
+		}
+
+		// The start command and args are kept if neither was set on push,
+		// they can't be combined so setting one replaces both
+		if cfg.Command == "" && len(cfg.Args) == 0 {
+			oldKfApp := NewFromApp(oldapp)
+			newKfApp.SetCommand(oldKfApp.GetCommand())
+			if args := oldKfApp.GetArgs(); len(args) > 0 {
+				newKfApp.SetArgs(args)
+			}
+		}
+
+		// The history limit can't be set on push so keep the old one
+		newapp.Spec.RevisionHistoryLimit = oldapp.Spec.RevisionHistoryLimit
+
 		newapp.ResourceVersion = oldapp.ResourceVersion
 		newEnvs := envutil.GetAppEnvVars(newapp)
 		oldEnvs := envutil.GetAppEnvVars(oldapp)
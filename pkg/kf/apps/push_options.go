@@ -24,18 +24,30 @@ import (
 )
 
 type pushConfig struct {
-	// Buildpack is skip the detect buildpack step and use the given name
-	Buildpack string
+	// Args is the arguments passed to the container's entrypoint
+	Args []string
+	// Buildpacks is skip the detect buildpack step and use the given buildpacks in order
+	Buildpacks []string
+	// CanaryPercent is the percent of traffic sent to a canary revision
+	CanaryPercent int
+	// CancelOnInterrupt is cancel the build if the push is interrupted
+	CancelOnInterrupt bool
+	// Command is the shell command that starts the app instead of the image's default process
+	Command string
 	// ContainerImage is the container to deploy
 	ContainerImage string
 	// ContainerRegistry is the container registry's URL
 	ContainerRegistry string
 	// DefaultRouteDomain is Domain for a defaultroute. Only used if a route doesn't already exist
 	DefaultRouteDomain string
+	// Dockerfile is the path of a Dockerfile in the source to build with instead of buildpacks
+	Dockerfile string
 	// EnvironmentVariables is set environment variables
 	EnvironmentVariables map[string]string
 	// ExactScale is scale exactly to this number of instances
 	ExactScale *int
+	// GitSource is the git repository to clone the source code from
+	GitSource *v1alpha1.SourceSpecGit
 	// Grpc is setup the ports for the container to allow gRPC to work
 	Grpc bool
 	// HealthCheck is the health check to use on the app
@@ -50,14 +62,22 @@ type pushConfig struct {
 	NoStart bool
 	// Output is the io.Writer to write output such as build logs
 	Output io.Writer
+	// Processes is the processes to run alongside the web process
+	Processes []v1alpha1.AppSpecProcess
 	// RandomRouteDomain is Domain for a random route. Only used if a route doesn't already exist
 	RandomRouteDomain string
+	// Resources is the compute resources requested and limited for each instance
+	Resources *corev1.ResourceRequirements
+	// RolloutStrategy is the strategy used to send traffic to the new revision
+	RolloutStrategy string
 	// Routes is routes for the app
 	Routes []v1alpha1.RouteSpecFields
 	// ServiceAccount is the service account to authenticate with
 	ServiceAccount string
 	// SourceImage is the source code as a container image
 	SourceImage string
+	// Stack is the run image to build the app on
+	Stack string
 }
 
 // PushOption is a single option for configuring a pushConfig
@@ -86,10 +106,34 @@ func (opts PushOptions) Extend(other PushOptions) PushOptions {
 	return out
 }
 
-// Buildpack returns the last set value for Buildpack or the empty value
+// Args returns the last set value for Args or the empty value
 // if not set.
-func (opts PushOptions) Buildpack() string {
-	return opts.toConfig().Buildpack
+func (opts PushOptions) Args() []string {
+	return opts.toConfig().Args
+}
+
+// Buildpacks returns the last set value for Buildpacks or the empty value
+// if not set.
+func (opts PushOptions) Buildpacks() []string {
+	return opts.toConfig().Buildpacks
+}
+
+// CanaryPercent returns the last set value for CanaryPercent or the empty value
+// if not set.
+func (opts PushOptions) CanaryPercent() int {
+	return opts.toConfig().CanaryPercent
+}
+
+// CancelOnInterrupt returns the last set value for CancelOnInterrupt or the empty value
+// if not set.
+func (opts PushOptions) CancelOnInterrupt() bool {
+	return opts.toConfig().CancelOnInterrupt
+}
+
+// Command returns the last set value for Command or the empty value
+// if not set.
+func (opts PushOptions) Command() string {
+	return opts.toConfig().Command
 }
 
 // ContainerImage returns the last set value for ContainerImage or the empty value
@@ -110,6 +154,12 @@ func (opts PushOptions) DefaultRouteDomain() string {
 	return opts.toConfig().DefaultRouteDomain
 }
 
+// Dockerfile returns the last set value for Dockerfile or the empty value
+// if not set.
+func (opts PushOptions) Dockerfile() string {
+	return opts.toConfig().Dockerfile
+}
+
 // EnvironmentVariables returns the last set value for EnvironmentVariables or the empty value
 // if not set.
 func (opts PushOptions) EnvironmentVariables() map[string]string {
@@ -122,6 +172,12 @@ func (opts PushOptions) ExactScale() *int {
 	return opts.toConfig().ExactScale
 }
 
+// GitSource returns the last set value for GitSource or the empty value
+// if not set.
+func (opts PushOptions) GitSource() *v1alpha1.SourceSpecGit {
+	return opts.toConfig().GitSource
+}
+
 // Grpc returns the last set value for Grpc or the empty value
 // if not set.
 func (opts PushOptions) Grpc() bool {
@@ -164,12 +220,30 @@ func (opts PushOptions) Output() io.Writer {
 	return opts.toConfig().Output
 }
 
+// Processes returns the last set value for Processes or the empty value
+// if not set.
+func (opts PushOptions) Processes() []v1alpha1.AppSpecProcess {
+	return opts.toConfig().Processes
+}
+
 // RandomRouteDomain returns the last set value for RandomRouteDomain or the empty value
 // if not set.
 func (opts PushOptions) RandomRouteDomain() string {
 	return opts.toConfig().RandomRouteDomain
 }
 
+// Resources returns the last set value for Resources or the empty value
+// if not set.
+func (opts PushOptions) Resources() *corev1.ResourceRequirements {
+	return opts.toConfig().Resources
+}
+
+// RolloutStrategy returns the last set value for RolloutStrategy or the empty value
+// if not set.
+func (opts PushOptions) RolloutStrategy() string {
+	return opts.toConfig().RolloutStrategy
+}
+
 // Routes returns the last set value for Routes or the empty value
 // if not set.
 func (opts PushOptions) Routes() []v1alpha1.RouteSpecFields {
@@ -188,10 +262,44 @@ func (opts PushOptions) SourceImage() string {
 	return opts.toConfig().SourceImage
 }
 
-// WithPushBuildpack creates an Option that sets skip the detect buildpack step and use the given name
-func WithPushBuildpack(val string) PushOption {
+// Stack returns the last set value for Stack or the empty value
+// if not set.
+func (opts PushOptions) Stack() string {
+	return opts.toConfig().Stack
+}
+
+// WithPushArgs creates an Option that sets the arguments passed to the container's entrypoint
+func WithPushArgs(val []string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Args = val
+	}
+}
+
+// WithPushBuildpacks creates an Option that sets skip the detect buildpack step and use the given buildpacks in order
+func WithPushBuildpacks(val []string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Buildpacks = val
+	}
+}
+
+// WithPushCanaryPercent creates an Option that sets the percent of traffic sent to a canary revision
+func WithPushCanaryPercent(val int) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.CanaryPercent = val
+	}
+}
+
+// WithPushCancelOnInterrupt creates an Option that sets cancel the build if the push is interrupted
+func WithPushCancelOnInterrupt(val bool) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.CancelOnInterrupt = val
+	}
+}
+
+// WithPushCommand creates an Option that sets the shell command that starts the app instead of the image's default process
+func WithPushCommand(val string) PushOption {
 	return func(cfg *pushConfig) {
-		cfg.Buildpack = val
+		cfg.Command = val
 	}
 }
 
@@ -216,6 +324,13 @@ func WithPushDefaultRouteDomain(val string) PushOption {
 	}
 }
 
+// WithPushDockerfile creates an Option that sets the path of a Dockerfile in the source to build with instead of buildpacks
+func WithPushDockerfile(val string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Dockerfile = val
+	}
+}
+
 // WithPushEnvironmentVariables creates an Option that sets set environment variables
 func WithPushEnvironmentVariables(val map[string]string) PushOption {
 	return func(cfg *pushConfig) {
@@ -230,6 +345,13 @@ func WithPushExactScale(val *int) PushOption {
 	}
 }
 
+// WithPushGitSource creates an Option that sets the git repository to clone the source code from
+func WithPushGitSource(val *v1alpha1.SourceSpecGit) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.GitSource = val
+	}
+}
+
 // WithPushGrpc creates an Option that sets setup the ports for the container to allow gRPC to work
 func WithPushGrpc(val bool) PushOption {
 	return func(cfg *pushConfig) {
@@ -279,6 +401,13 @@ func WithPushOutput(val io.Writer) PushOption {
 	}
 }
 
+// WithPushProcesses creates an Option that sets the processes to run alongside the web process
+func WithPushProcesses(val []v1alpha1.AppSpecProcess) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Processes = val
+	}
+}
+
 // WithPushRandomRouteDomain creates an Option that sets Domain for a random route. Only used if a route doesn't already exist
 func WithPushRandomRouteDomain(val string) PushOption {
 	return func(cfg *pushConfig) {
@@ -286,6 +415,20 @@ func WithPushRandomRouteDomain(val string) PushOption {
 	}
 }
 
+// WithPushResources creates an Option that sets the compute resources requested and limited for each instance
+func WithPushResources(val *corev1.ResourceRequirements) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Resources = val
+	}
+}
+
+// WithPushRolloutStrategy creates an Option that sets the strategy used to send traffic to the new revision
+func WithPushRolloutStrategy(val string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.RolloutStrategy = val
+	}
+}
+
 // WithPushRoutes creates an Option that sets routes for the app
 func WithPushRoutes(val []v1alpha1.RouteSpecFields) PushOption {
 	return func(cfg *pushConfig) {
@@ -307,6 +450,13 @@ func WithPushSourceImage(val string) PushOption {
 	}
 }
 
+// WithPushStack creates an Option that sets the run image to build the app on
+func WithPushStack(val string) PushOption {
+	return func(cfg *pushConfig) {
+		cfg.Stack = val
+	}
+}
+
 // PushOptionDefaults gets the default values for Push.
 func PushOptionDefaults() PushOptions {
 	return PushOptions{
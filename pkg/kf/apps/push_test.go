@@ -25,6 +25,7 @@ import (
 	appsfake "github.com/google/kf/pkg/kf/apps/fake"
 	"github.com/google/kf/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -74,6 +75,7 @@ func TestPush_Logs(t *testing.T) {
 					tc.appName+"-version",    // resourceVersion
 					expectedNamespace,        // namespace
 					tc.noStart,               // NoStart
+					false,                    // CancelOnInterrupt
 				).
 				Return(tc.logErr)
 
@@ -250,7 +252,7 @@ func TestPush(t *testing.T) {
 				apps.WithPushSourceImage("some-image"),
 				apps.WithPushContainerRegistry("some-reg.io"),
 				apps.WithPushServiceAccount("some-service-account"),
-				apps.WithPushBuildpack("some-buildpack"),
+				apps.WithPushBuildpacks([]string{"some-buildpack"}),
 			},
 		},
 		"pushes app with proper Service config": {
@@ -284,7 +286,8 @@ func TestPush(t *testing.T) {
 				apps.WithPushNamespace("default"),
 				apps.WithPushContainerRegistry("some-reg.io"),
 				apps.WithPushServiceAccount("some-service-account"),
-				apps.WithPushBuildpack("some-buildpack"),
+				apps.WithPushBuildpacks([]string{"some-buildpack"}),
+				apps.WithPushStack("some-stack"),
 			},
 			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
 				appsClient.EXPECT().
@@ -293,11 +296,49 @@ func TestPush(t *testing.T) {
 						testutil.AssertEqual(t, "namespace", "default", newApp.Namespace)
 						testutil.AssertEqual(t, "Spec.ServiceAccountName", "some-service-account", newApp.Spec.Template.Spec.ServiceAccountName)
 						testutil.AssertEqual(t, "image", "some-image", newApp.Spec.Source.BuildpackBuild.Source)
-						testutil.AssertEqual(t, "buildpack", "some-buildpack", newApp.Spec.Source.BuildpackBuild.Buildpack)
+						testutil.AssertEqual(t, "buildpacks", []string{"some-buildpack"}, newApp.Spec.Source.BuildpackBuild.Buildpacks)
+						testutil.AssertEqual(t, "stack", "some-stack", newApp.Spec.Source.BuildpackBuild.Stack)
 
 					}).Return(&v1alpha1.App{}, nil)
 			},
 		},
+		"properly configures dockerfile source": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushSourceImage("some-image"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushDockerfile("build/Dockerfile"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "image", "some-image", newApp.Spec.Source.Dockerfile.Source)
+						testutil.AssertEqual(t, "path", "build/Dockerfile", newApp.Spec.Source.Dockerfile.Path)
+						testutil.AssertEqual(t, "registry", "some-reg.io", newApp.Spec.Source.Dockerfile.Registry)
+						testutil.AssertEqual(t, "buildpackBuild", false, newApp.Spec.Source.IsBuildpackBuild())
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"properly configures git source": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushGitSource(&v1alpha1.SourceSpecGit{
+					URL: "https://github.com/google/kf.git",
+				}),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Any(), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "git", &v1alpha1.SourceSpecGit{
+							URL: "https://github.com/google/kf.git",
+						}, newApp.Spec.Source.BuildpackBuild.Git)
+						testutil.AssertEqual(t, "image", "", newApp.Spec.Source.BuildpackBuild.Source)
+					}).Return(&v1alpha1.App{}, nil)
+			},
+		},
 		"pushes app with environment variables": {
 			appName:   "some-app",
 			buildpack: "some-buildpack",
@@ -334,6 +375,188 @@ func TestPush(t *testing.T) {
 					Return(&v1alpha1.App{}, nil)
 			},
 		},
+		"pushes app with canary rollout": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushRolloutStrategy(v1alpha1.RolloutStrategyCanary),
+				apps.WithPushCanaryPercent(25),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := &v1alpha1.App{}
+						oldApp.Spec.Rollout.AbortedRevisionName = "some-app-abcde"
+						newApp = merge(newApp, oldApp)
+						testutil.AssertEqual(t, "rollout", v1alpha1.AppSpecRollout{
+							Strategy:            v1alpha1.RolloutStrategyCanary,
+							CanaryPercent:       25,
+							AbortedRevisionName: "some-app-abcde",
+						}, newApp.Spec.Rollout)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app but leaves rollout": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := &v1alpha1.App{}
+						oldApp.Spec.Rollout = v1alpha1.AppSpecRollout{
+							Strategy:      v1alpha1.RolloutStrategyCanary,
+							CanaryPercent: 10,
+						}
+						newApp = merge(newApp, oldApp)
+						testutil.AssertEqual(t, "rollout", oldApp.Spec.Rollout, newApp.Spec.Rollout)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app that was rolled back": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := &v1alpha1.App{}
+						oldApp.Spec.Rollout.RollbackRevisionName = "some-app-abcde"
+						newApp = merge(newApp, oldApp)
+						testutil.AssertEqual(t, "rollbackRevisionName", "", newApp.Spec.Rollout.RollbackRevisionName)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app with processes": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushProcesses([]v1alpha1.AppSpecProcess{{Type: "worker", Command: "./worker"}}),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := &v1alpha1.App{}
+						oldApp.Spec.Processes = []v1alpha1.AppSpecProcess{{Type: "clock", Command: "./clock"}}
+						newApp = merge(newApp, oldApp)
+						testutil.AssertEqual(t, "processes", []v1alpha1.AppSpecProcess{
+							{Type: "worker", Command: "./worker"},
+						}, newApp.Spec.Processes)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app with command": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushCommand("./server --port $PORT"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "command", "./server --port $PORT", newApp.Spec.Template.Command)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app with args": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushArgs([]string{"--verbose"}),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						testutil.AssertEqual(t, "args", []string{"--verbose"}, newApp.Spec.Template.Spec.Containers[0].Args)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app but leaves command and args": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := apps.NewKfApp()
+						oldApp.SetCommand("./server")
+						oldApp.SetArgs([]string{"--verbose"})
+						newApp = merge(newApp, oldApp.ToApp())
+						testutil.AssertEqual(t, "command", "./server", newApp.Spec.Template.Command)
+						testutil.AssertEqual(t, "args", []string{"--verbose"}, newApp.Spec.Template.Spec.Containers[0].Args)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app with command replacing old args": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushCommand("./server --port $PORT"),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := apps.NewKfApp()
+						oldApp.SetArgs([]string{"--verbose"})
+						newApp = merge(newApp, oldApp.ToApp())
+						testutil.AssertEqual(t, "command", "./server --port $PORT", newApp.Spec.Template.Command)
+						testutil.AssertEqual(t, "args", []string(nil), newApp.Spec.Template.Spec.Containers[0].Args)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
+		"pushes app with resources": {
+			appName: "some-app",
+			opts: apps.PushOptions{
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushResources(&corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+					Limits:   corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+				}),
+			},
+			setup: func(t *testing.T, appsClient *appsfake.FakeClient) {
+				appsClient.EXPECT().
+					Upsert(gomock.Not(gomock.Nil()), gomock.Any(), gomock.Any()).
+					Do(func(namespace string, newApp *v1alpha1.App, merge apps.Merger) {
+						oldApp := &v1alpha1.App{}
+						oldApp.Spec.Template.Spec.Containers = []corev1.Container{{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceMemory:           resource.MustParse("1Gi"),
+									corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+								},
+							},
+						}}
+						newApp = merge(newApp, oldApp)
+						testutil.AssertEqual(t, "resources", corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceMemory:           resource.MustParse("512Mi"),
+								corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+							},
+							Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("512Mi")},
+						}, newApp.Spec.Template.Spec.Containers[0].Resources)
+					}).
+					Return(&v1alpha1.App{}, nil)
+			},
+		},
 		"pushes app with routes": {
 			appName: "some-app",
 			opts: apps.PushOptions{
@@ -519,7 +742,7 @@ func TestPush(t *testing.T) {
 			ctrl := gomock.NewController(t)
 			fakeApps := appsfake.NewFakeClient(ctrl)
 			fakeApps.EXPECT().
-				DeployLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+				DeployLogs(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 				AnyTimes()
 
 			tc.setup(t, fakeApps)
@@ -0,0 +1,254 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// cfSizePattern matches the sizes CloudFoundry accepts for memory and disk,
+// e.g. 512M, 1G or 1024MB.
+var cfSizePattern = regexp.MustCompile(`^([0-9]+)\s*([KMGT])B?$`)
+
+// ParseSize parses a CloudFoundry style memory or disk size into a quantity.
+// CloudFoundry's units are powers of two so 512M is 512Mi. Kubernetes
+// quantities like 512Mi are accepted as well.
+func ParseSize(size string) (resource.Quantity, error) {
+	trimmed := strings.TrimSpace(size)
+
+	if match := cfSizePattern.FindStringSubmatch(strings.ToUpper(trimmed)); match != nil {
+		trimmed = match[1] + match[2] + "i"
+	}
+
+	quantity, err := resource.ParseQuantity(trimmed)
+	if err != nil {
+		return resource.Quantity{}, fmt.Errorf("invalid size %q, use a number followed by M or G e.g. 512M", size)
+	}
+
+	if quantity.Sign() <= 0 {
+		return resource.Quantity{}, fmt.Errorf("invalid size %q, it must be greater than zero", size)
+	}
+
+	return quantity, nil
+}
+
+// NewResourceRequirements creates the corev1.ResourceRequirements of an App's
+// container from CloudFoundry style memory and disk sizes and a Kubernetes
+// CPU quantity. Memory and disk are requested and limited like CloudFoundry
+// does, CPU is only requested so instances can use idle CPU. Blank values are
+// left unset and nil is returned if they're all blank.
+func NewResourceRequirements(memory, disk, cpu string) (*corev1.ResourceRequirements, error) {
+	if memory == "" && disk == "" && cpu == "" {
+		return nil, nil
+	}
+
+	resources := &corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{},
+		Limits:   corev1.ResourceList{},
+	}
+
+	if memory != "" {
+		quantity, err := ParseSize(memory)
+		if err != nil {
+			return nil, fmt.Errorf("memory: %s", err)
+		}
+
+		resources.Requests[corev1.ResourceMemory] = quantity
+		resources.Limits[corev1.ResourceMemory] = quantity
+	}
+
+	if disk != "" {
+		quantity, err := ParseSize(disk)
+		if err != nil {
+			return nil, fmt.Errorf("disk quota: %s", err)
+		}
+
+		resources.Requests[corev1.ResourceEphemeralStorage] = quantity
+		resources.Limits[corev1.ResourceEphemeralStorage] = quantity
+	}
+
+	if cpu != "" {
+		quantity, err := resource.ParseQuantity(strings.TrimSpace(cpu))
+		if err != nil || quantity.Sign() <= 0 {
+			return nil, fmt.Errorf("invalid CPU %q, use a number of cores e.g. 0.5 or 500m", cpu)
+		}
+
+		resources.Requests[corev1.ResourceCPU] = quantity
+	}
+
+	if len(resources.Limits) == 0 {
+		resources.Limits = nil
+	}
+
+	return resources, nil
+}
+
+// sidecarRequests are the resources requested by default by the containers
+// that run next to an App's in each Pod: Knative Serving's queue-proxy and
+// Istio's sidecar. Pod limits apply to them as well.
+var sidecarRequests = []corev1.ResourceList{
+	{corev1.ResourceCPU: resource.MustParse("25m")},
+	{corev1.ResourceCPU: resource.MustParse("10m")},
+}
+
+// ValidateResourceLimits checks that instances of an App with the given
+// resources fit in the space's LimitRange and ResourceQuota.
+func ValidateResourceLimits(space *v1alpha1.Space, resources corev1.ResourceRequirements, instances int) error {
+	if err := ValidateLimitRange(space, resources); err != nil {
+		return err
+	}
+
+	return ValidateResourceQuota(space, resources, instances)
+}
+
+// ValidateLimitRange checks that each instance of an App with the given
+// resources is within the minimums, maximums and limit to request ratios of
+// the space's LimitRange.
+func ValidateLimitRange(space *v1alpha1.Space, resources corev1.ResourceRequirements) error {
+	for _, item := range space.Spec.ResourceLimits.ResourceDefaults {
+		switch item.Type {
+		case corev1.LimitTypeContainer:
+			if err := validateLimitRangeItem(item, resources); err != nil {
+				return err
+			}
+		case corev1.LimitTypePod:
+			if err := validateLimitRangeItem(item, withSidecars(resources)); err != nil {
+				return fmt.Errorf("with the sidecars each instance runs, %s", err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// ValidateResourceQuota checks that the instances of an App with the given
+// resources fit in the space's ResourceQuota. Usage by other Apps in the
+// space isn't taken into account, Kubernetes enforces the quota when the
+// instances are scheduled.
+func ValidateResourceQuota(space *v1alpha1.Space, resources corev1.ResourceRequirements, instances int) error {
+	quota := space.Spec.ResourceLimits.SpaceQuota
+	for _, name := range sortedResourceNames(quota) {
+		hard := quota[name]
+
+		var quantity resource.Quantity
+		var ok bool
+		switch resourceName := string(name); {
+		case strings.HasPrefix(resourceName, "limits."):
+			quantity, ok = resources.Limits[corev1.ResourceName(strings.TrimPrefix(resourceName, "limits."))]
+		case strings.HasPrefix(resourceName, "requests."):
+			quantity, ok = resources.Requests[corev1.ResourceName(strings.TrimPrefix(resourceName, "requests."))]
+		default:
+			quantity, ok = resources.Requests[name]
+		}
+
+		if !ok {
+			continue
+		}
+
+		total := resource.NewMilliQuantity(quantity.MilliValue()*int64(instances), quantity.Format)
+		if total.Cmp(hard) > 0 {
+			return fmt.Errorf("%d instances with %s of %s need %s, more than the space's quota of %s", instances, name, quantity.String(), total.String(), hard.String())
+		}
+	}
+
+	return nil
+}
+
+// validateLimitRangeItem checks the resources against the minimums, maximums
+// and limit to request ratios of a LimitRange.
+func validateLimitRangeItem(item corev1.LimitRangeItem, resources corev1.ResourceRequirements) error {
+	for _, name := range sortedResourceNames(item.Max) {
+		max := item.Max[name]
+		if kind, quantity, ok := largestQuantity(resources, name); ok && quantity.Cmp(max) > 0 {
+			return fmt.Errorf("%s %s of %s is more than the space's maximum of %s", name, kind, quantity.String(), max.String())
+		}
+	}
+
+	for _, name := range sortedResourceNames(item.Min) {
+		min := item.Min[name]
+		if quantity, ok := resources.Requests[name]; ok && quantity.Cmp(min) < 0 {
+			return fmt.Errorf("%s request of %s is less than the space's minimum of %s", name, quantity.String(), min.String())
+		}
+
+		if quantity, ok := resources.Limits[name]; ok && quantity.Cmp(min) < 0 {
+			return fmt.Errorf("%s limit of %s is less than the space's minimum of %s", name, quantity.String(), min.String())
+		}
+	}
+
+	for _, name := range sortedResourceNames(item.MaxLimitRequestRatio) {
+		ratio := item.MaxLimitRequestRatio[name]
+		limit, hasLimit := resources.Limits[name]
+		request, hasRequest := resources.Requests[name]
+
+		// Requests default to the limit, which is always within the ratio.
+		if !hasLimit || !hasRequest || request.Sign() <= 0 {
+			continue
+		}
+
+		if float64(limit.MilliValue())/float64(request.MilliValue()) > float64(ratio.MilliValue())/1000 {
+			return fmt.Errorf("%s limit of %s is more than %s times the request of %s, the space's maximum ratio", name, limit.String(), ratio.String(), request.String())
+		}
+	}
+
+	return nil
+}
+
+// withSidecars adds the requests of the sidecars to the resources the App
+// sets. Resources the App leaves unset aren't checked so they're left alone.
+func withSidecars(resources corev1.ResourceRequirements) corev1.ResourceRequirements {
+	pod := *resources.DeepCopy()
+
+	for _, sidecar := range sidecarRequests {
+		for name, quantity := range sidecar {
+			if total, ok := pod.Requests[name]; ok {
+				total.Add(quantity)
+				pod.Requests[name] = total
+			}
+		}
+	}
+
+	return pod
+}
+
+// largestQuantity returns the limit of the resource if it's set, otherwise
+// its request. Limits can't be lower than requests.
+func largestQuantity(resources corev1.ResourceRequirements, name corev1.ResourceName) (string, resource.Quantity, bool) {
+	if quantity, ok := resources.Limits[name]; ok {
+		return "limit", quantity, true
+	}
+
+	quantity, ok := resources.Requests[name]
+	return "request", quantity, ok
+}
+
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	var names []corev1.ResourceName
+	for name := range list {
+		names = append(names, name)
+	}
+
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+
+	return names
+}
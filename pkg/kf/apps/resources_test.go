@@ -0,0 +1,231 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestParseSize(t *testing.T) {
+	cases := map[string]struct {
+		size string
+
+		expectQuantity resource.Quantity
+		expectErr      error
+	}{
+		"megabytes": {
+			size:           "512M",
+			expectQuantity: resource.MustParse("512Mi"),
+		},
+		"megabytes long form": {
+			size:           "512MB",
+			expectQuantity: resource.MustParse("512Mi"),
+		},
+		"gigabytes lowercase": {
+			size:           "2g",
+			expectQuantity: resource.MustParse("2Gi"),
+		},
+		"kubernetes quantity": {
+			size:           "768Mi",
+			expectQuantity: resource.MustParse("768Mi"),
+		},
+		"invalid": {
+			size:      "lots",
+			expectErr: errors.New(`invalid size "lots", use a number followed by M or G e.g. 512M`),
+		},
+		"zero": {
+			size:      "0M",
+			expectErr: errors.New(`invalid size "0M", it must be greater than zero`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			quantity, err := ParseSize(tc.size)
+			if tc.expectErr != nil || err != nil {
+				testutil.AssertErrorsEqual(t, tc.expectErr, err)
+				return
+			}
+
+			testutil.AssertEqual(t, "quantity", tc.expectQuantity.String(), quantity.String())
+		})
+	}
+}
+
+func TestNewResourceRequirements(t *testing.T) {
+	cases := map[string]struct {
+		memory string
+		disk   string
+		cpu    string
+
+		expectResources *corev1.ResourceRequirements
+		expectErr       error
+	}{
+		"blank": {},
+		"memory and disk are requested and limited": {
+			memory: "512M",
+			disk:   "1G",
+			expectResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceMemory:           resource.MustParse("512Mi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+				},
+				Limits: corev1.ResourceList{
+					corev1.ResourceMemory:           resource.MustParse("512Mi"),
+					corev1.ResourceEphemeralStorage: resource.MustParse("1Gi"),
+				},
+			},
+		},
+		"cpu is only requested": {
+			cpu: "500m",
+			expectResources: &corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU: resource.MustParse("500m"),
+				},
+			},
+		},
+		"invalid memory": {
+			memory:    "lots",
+			expectErr: errors.New(`memory: invalid size "lots", use a number followed by M or G e.g. 512M`),
+		},
+		"invalid disk": {
+			disk:      "-1G",
+			expectErr: errors.New(`disk quota: invalid size "-1G", it must be greater than zero`),
+		},
+		"invalid cpu": {
+			cpu:       "fast",
+			expectErr: errors.New(`invalid CPU "fast", use a number of cores e.g. 0.5 or 500m`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			resources, err := NewResourceRequirements(tc.memory, tc.disk, tc.cpu)
+			if tc.expectErr != nil || err != nil {
+				testutil.AssertErrorsEqual(t, tc.expectErr, err)
+				return
+			}
+
+			testutil.AssertEqual(t, "resources", tc.expectResources, resources)
+		})
+	}
+}
+
+func TestValidateResourceLimits(t *testing.T) {
+	space := &v1alpha1.Space{}
+	space.Spec.ResourceLimits.ResourceDefaults = []corev1.LimitRangeItem{
+		{
+			Type: corev1.LimitTypeContainer,
+			Max: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("2Gi"),
+			},
+			Min: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("128Mi"),
+			},
+			MaxLimitRequestRatio: corev1.ResourceList{
+				corev1.ResourceMemory: resource.MustParse("2"),
+			},
+		},
+		{
+			Type: corev1.LimitTypePod,
+			Max: corev1.ResourceList{
+				corev1.ResourceCPU: resource.MustParse("1"),
+			},
+		},
+	}
+	space.Spec.ResourceLimits.SpaceQuota = corev1.ResourceList{
+		corev1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+		corev1.ResourceLimitsCPU:      resource.MustParse("2"),
+	}
+
+	memory := func(request, limit string) corev1.ResourceRequirements {
+		resources := corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(request)},
+		}
+		if limit != "" {
+			resources.Limits = corev1.ResourceList{corev1.ResourceMemory: resource.MustParse(limit)}
+		}
+		return resources
+	}
+
+	cases := map[string]struct {
+		resources corev1.ResourceRequirements
+		instances int
+
+		expectErr error
+	}{
+		"fits": {
+			resources: memory("1Gi", "1Gi"),
+			instances: 4,
+		},
+		"limit over maximum": {
+			resources: memory("1Gi", "3Gi"),
+			instances: 1,
+			expectErr: errors.New("memory limit of 3Gi is more than the space's maximum of 2Gi"),
+		},
+		"request under minimum": {
+			resources: memory("64Mi", ""),
+			instances: 1,
+			expectErr: errors.New("memory request of 64Mi is less than the space's minimum of 128Mi"),
+		},
+		"limit under minimum": {
+			resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+			},
+			instances: 1,
+			expectErr: errors.New("memory limit of 64Mi is less than the space's minimum of 128Mi"),
+		},
+		"limit over ratio": {
+			resources: memory("512Mi", "2Gi"),
+			instances: 1,
+			expectErr: errors.New("memory limit of 2Gi is more than 2 times the request of 512Mi, the space's maximum ratio"),
+		},
+		"pod fits with sidecars": {
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("900m")},
+			},
+			instances: 1,
+		},
+		"pod over maximum with sidecars": {
+			resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("990m")},
+			},
+			instances: 1,
+			expectErr: errors.New("with the sidecars each instance runs, cpu request of 1025m is more than the space's maximum of 1"),
+		},
+		"instances over quota": {
+			resources: memory("2Gi", ""),
+			instances: 3,
+			expectErr: errors.New("3 instances with requests.memory of 2Gi need 6Gi, more than the space's quota of 4Gi"),
+		},
+		"unset resources aren't checked": {
+			resources: corev1.ResourceRequirements{},
+			instances: 10,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			err := ValidateResourceLimits(space, tc.resources, tc.instances)
+			testutil.AssertErrorsEqual(t, tc.expectErr, err)
+		})
+	}
+}
@@ -32,7 +32,8 @@ type Client interface {
 	// List lists the buildpacks available on the given builder image.
 	List(builderImage string) ([]Buildpack, error)
 
-	// Stacks lists the stacks available on the given builder image.
+	// Stacks lists the stacks available on the given builder image. The
+	// builder's default run image is first, followed by its mirrors.
 	Stacks(builderImage string) ([]string, error)
 }
 
@@ -89,7 +90,8 @@ func (c *client) Stacks(builderImage string) ([]string, error) {
 	var stack struct {
 		Stack struct {
 			RunImage struct {
-				Image string `json:"image"`
+				Image   string   `json:"image"`
+				Mirrors []string `json:"mirrors"`
 			} `json:"runImage"`
 		} `json:"stack"`
 	}
@@ -98,7 +100,15 @@ func (c *client) Stacks(builderImage string) ([]string, error) {
 		return nil, err
 	}
 
-	return []string{stack.Stack.RunImage.Image}, nil
+	runImage := stack.Stack.RunImage
+	stacks := []string{runImage.Image}
+	for _, mirror := range runImage.Mirrors {
+		if mirror != "" && mirror != runImage.Image {
+			stacks = append(stacks, mirror)
+		}
+	}
+
+	return stacks, nil
 }
 
 func (c *client) fetchConfig(builderImage string) (*gcrv1.ConfigFile, error) {
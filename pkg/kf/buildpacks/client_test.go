@@ -89,6 +89,23 @@ func TestClient_Stacks(t *testing.T) {
 				testutil.AssertEqual(t, "stack", "bionic", stacks[0])
 			},
 		},
+		"reads run image mirrors": {
+			RemoteImageFetcher: func(t *testing.T, ref name.Reference, options ...remote.ImageOption) (gcrv1.Image, error) {
+				fakeImage := NewFakeImage(gomock.NewController(t))
+				fakeImage.EXPECT().ConfigFile().Return(&gcrv1.ConfigFile{
+					Config: gcrv1.Config{
+						Labels: map[string]string{
+							"io.buildpacks.builder.metadata": `{"stack":{"runImage":{"image":"bionic","mirrors":["gcr.io/mirror/bionic","bionic"]}}}`,
+						},
+					},
+				}, nil)
+				return fakeImage, nil
+			},
+			HandleOutput: func(t *testing.T, output interface{}, err error) {
+				testutil.AssertNil(t, "error", err)
+				testutil.AssertEqual(t, "stacks", []string{"bionic", "gcr.io/mirror/bionic"}, output.([]string))
+			},
+		},
 	})
 }
 
@@ -56,19 +56,33 @@ func NewGetAppCommand(p *config.KfParams, appsClient apps.Client) *cobra.Command
 			describe.AppSpecInstances(w, app.Spec.Instances)
 			fmt.Fprintln(w)
 
+			describe.AppRollout(w, app.Spec.Rollout, app.Status.Rollout)
+			fmt.Fprintln(w)
+
 			describe.SourceSpec(w, app.Spec.Source)
 			fmt.Fprintln(w)
 
+			describe.SourceProvenance(w, app.Status.SourceStatusFields)
+			fmt.Fprintln(w)
+
 			describe.SectionWriter(w, "Runtime", func(w io.Writer) {
 				status := app.Status
 
-				fmt.Fprintf(w, "Image:\t%s\n", status.Image)
+				fmt.Fprintf(w, "Image:\t%s\n", status.PinnedImage())
 				if url := status.URL; url != nil {
 					fmt.Fprintf(w, "Host:\t%s\n", url.Host)
 				}
 
 				kfApp := apps.NewFromApp(app)
+				if command := kfApp.GetCommand(); command != "" {
+					fmt.Fprintf(w, "Command:\t%s\n", command)
+				}
+				if args := kfApp.GetArgs(); len(args) > 0 {
+					fmt.Fprintf(w, "Args:\t%q\n", args)
+				}
+
 				describe.HealthCheck(w, kfApp.GetHealthCheck())
+				describe.Resources(w, kfApp.GetResources())
 				describe.EnvVars(w, kfApp.GetEnvVars())
 			})
 			fmt.Fprintln(w)
@@ -0,0 +1,79 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta/table"
+)
+
+// NewAppHistoryCommand creates a command to list the revisions an app can be
+// rolled back to.
+func NewAppHistoryCommand(p *config.KfParams, appsClient apps.Client) *cobra.Command {
+	return &cobra.Command{
+		Use:     "app-history APP_NAME",
+		Short:   "List the revisions of an app that can be rolled back to",
+		Example: `  kf app-history myapp`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			cmd.SilenceUsage = true
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Getting history of app %s in namespace: %s\n", appName, p.Namespace)
+
+			app, err := appsClient.Get(p.Namespace, appName)
+			if err != nil {
+				return err
+			}
+			fmt.Fprintln(cmd.OutOrStdout())
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 8, 4, 1, ' ', tabwriter.StripEscape)
+			defer w.Flush()
+
+			current := app.Status.LatestReadyRevisionName
+			if pinned := app.Spec.Rollout.RollbackRevisionName; pinned != "" {
+				current = pinned
+			}
+
+			fmt.Fprintln(w, "Revision\tAge\tSource\tImage")
+			for _, revision := range app.Status.History {
+				name := revision.RevisionName
+				if name == current {
+					name += " (current)"
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\n",
+					name,
+					table.ConvertToHumanReadableDateType(revision.ReadyTime),
+					revision.SourceName,
+					revision.Image,
+				)
+			}
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,134 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestNewAppHistoryCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(t *testing.T, fakeApps *fake.FakeClient)
+
+		wantErr         error
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"missing namespace": {
+			args:    []string{"my-app"},
+			wantErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		"no history": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeApps *fake.FakeClient) {
+				fakeApps.
+					EXPECT().
+					Get("my-ns", "my-app").
+					Return(&v1alpha1.App{}, nil)
+			},
+			expectedStrings: []string{"Revision", "Age", "Source", "Image"},
+		},
+		"history": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeApps *fake.FakeClient) {
+				app := &v1alpha1.App{}
+				app.Status.LatestReadyRevisionName = "my-app-00002"
+				app.Status.History = []v1alpha1.AppRevision{
+					{RevisionName: "my-app-00002", SourceName: "my-app-xyz", Image: "gcr.io/image-2"},
+					{RevisionName: "my-app-00001", SourceName: "my-app-abc", Image: "gcr.io/image-1"},
+				}
+
+				fakeApps.
+					EXPECT().
+					Get("my-ns", "my-app").
+					Return(app, nil)
+			},
+			expectedStrings: []string{
+				"my-app-00002 (current)", "my-app-xyz", "gcr.io/image-2",
+				"my-app-00001", "my-app-abc", "gcr.io/image-1",
+			},
+		},
+		"rolled back": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeApps *fake.FakeClient) {
+				app := &v1alpha1.App{}
+				app.Spec.Rollout.RollbackRevisionName = "my-app-00001"
+				app.Status.LatestReadyRevisionName = "my-app-00002"
+				app.Status.History = []v1alpha1.AppRevision{
+					{RevisionName: "my-app-00002", Image: "gcr.io/image-2"},
+					{RevisionName: "my-app-00001", Image: "gcr.io/image-1"},
+				}
+
+				fakeApps.
+					EXPECT().
+					Get("my-ns", "my-app").
+					Return(app, nil)
+			},
+			expectedStrings: []string{"my-app-00001 (current)"},
+		},
+		"server failure": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeApps *fake.FakeClient) {
+				fakeApps.
+					EXPECT().
+					Get("my-ns", "my-app").
+					Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeApps := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeApps)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewAppHistoryCommand(&config.KfParams{Namespace: tc.namespace}, fakeApps)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
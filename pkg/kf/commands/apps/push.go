@@ -17,22 +17,27 @@ package apps
 import (
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/url"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/internal/envutil"
 	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/buildpacks"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	kfi "github.com/google/kf/pkg/kf/internal/kf"
 	"github.com/google/kf/pkg/kf/manifest"
 	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
+	"github.com/google/kf/pkg/kf/tasks"
 	"github.com/poy/service-catalog/cmd/svcat/output"
 	"github.com/spf13/cobra"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 )
 
 // SrcImageBuilder creates and uploads a container image that contains the
@@ -64,7 +69,7 @@ func (f SrcImageBuilderFunc) BuildSrcImage(dir, srcImage string) error {
 }
 
 // NewPushCommand creates a push command.
-func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher, b SrcImageBuilder, serviceBindingClient servicebindings.ClientInterface) *cobra.Command {
+func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher, b SrcImageBuilder, serviceBindingClient servicebindings.ClientInterface, buildpacksClient buildpacks.Client, tasksClient tasks.Client) *cobra.Command {
 	var (
 		containerRegistry  string
 		sourceImage        string
@@ -75,13 +80,26 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 		maxScale           int
 		serviceAccount     string
 		path               string
-		buildpack          string
+		buildpacks         []string
+		stack              string
+		dockerfile         string
+		gitURL             string
+		gitRevision        string
+		gitSecret          string
 		envs               []string
 		grpc               bool
 		noManifest         bool
 		noStart            bool
+		cancelOnInterrupt  bool
 		healthCheckType    string
 		healthCheckTimeout int
+		rolloutStrategy    string
+		canaryPercent      int
+		command            string
+		args               []string
+		memory             string
+		diskQuota          string
+		cpu                string
 
 		// Route Flags
 		rawRoutes         []string
@@ -96,7 +114,15 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
   kf push myapp
   kf push myapp --container-registry gcr.io/myproject
   kf push myapp --buildpack my.special.buildpack # Discover via kf buildpacks
+  kf push myapp --buildpack nodejs --buildpack python # Run buildpacks in order
+  kf push myapp --stack gcr.io/my-company/run:bionic # Discover via kf stacks
+  kf push myapp --dockerfile Dockerfile
+  kf push myapp --git-url https://github.com/my-org/myapp.git --git-revision main
   kf push myapp --env FOO=bar --env BAZ=foo
+  kf push myapp --strategy canary --canary-percent 10
+  kf push myapp --command "bundle exec rails server -p $PORT"
+  kf push myapp -m 512M -k 1G --cpu 500m
+  kf push myapp --docker-image gcr.io/my-company/server --args=--verbose
   `,
 		Args: cobra.MaximumNArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -109,6 +135,19 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 				return err
 			}
 
+			strategy, err := parseRolloutStrategy(rolloutStrategy)
+			if err != nil {
+				return err
+			}
+
+			if cmd.Flags().Lookup("canary-percent").Changed && strategy != v1alpha1.RolloutStrategyCanary {
+				return errors.New("--canary-percent can only be used with --strategy=canary")
+			}
+
+			if gitURL == "" && (gitRevision != "" || gitSecret != "") {
+				return errors.New("--git-revision and --git-credentials-secret can only be used with --git-url")
+			}
+
 			cmd.SilenceUsage = true
 
 			appName := ""
@@ -166,8 +205,18 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 				}
 				overrides.Env = envutil.EnvVarsToMap(envVars)
 
-				if buildpack != "" {
-					overrides.Buildpacks = []string{buildpack}
+				if len(buildpacks) > 0 {
+					overrides.Buildpacks = buildpacks
+				}
+
+				overrides.Stack = stack
+				overrides.Command = command
+				overrides.Memory = memory
+				overrides.DiskQuota = diskQuota
+				overrides.CPU = cpu
+
+				if len(args) > 0 {
+					overrides.Args = args
 				}
 
 				overrides.HealthCheckTimeout = healthCheckTimeout
@@ -231,6 +280,21 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 					return err
 				}
 
+				if app.Command != "" && len(app.Args) > 0 {
+					return errors.New("cannot use command and args simultaneously")
+				}
+
+				resources, err := apps.NewResourceRequirements(app.Memory, app.DiskQuota, app.CPU)
+				if err != nil {
+					return err
+				}
+
+				if resources != nil {
+					if err := apps.ValidateResourceLimits(space, *resources, minInstances(exactScale, minScale)); err != nil {
+						return err
+					}
+				}
+
 				healthCheck, err := apps.NewHealthCheck(app.HealthCheckType, app.HealthCheckHTTPEndpoint, app.HealthCheckTimeout)
 				if err != nil {
 					return err
@@ -255,10 +319,16 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 					apps.WithPushMinScale(minScale),
 					apps.WithPushMaxScale(maxScale),
 					apps.WithPushNoStart(noStart),
+					apps.WithPushCancelOnInterrupt(cancelOnInterrupt),
 					apps.WithPushRoutes(routes),
 					apps.WithPushHealthCheck(healthCheck),
 					apps.WithPushRandomRouteDomain(randomRouteDomain),
 					apps.WithPushDefaultRouteDomain(defaultRouteDomain),
+					apps.WithPushRolloutStrategy(strategy),
+					apps.WithPushCanaryPercent(canaryPercent),
+					apps.WithPushCommand(app.Command),
+					apps.WithPushArgs(app.Args),
+					apps.WithPushResources(resources),
 				}
 
 				if app.Docker.Image == "" { // buildpack app
@@ -272,9 +342,46 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 						return errors.New("container-registry is required for buildpack apps")
 					}
 
+					if dockerfile != "" && len(app.Buildpacks) > 0 {
+						return errors.New("cannot use buildpack and dockerfile simultaneously")
+					}
+
+					if dockerfile != "" && app.Stack != "" {
+						return errors.New("cannot use stack and dockerfile simultaneously")
+					}
+
+					if len(app.Buildpacks) > 0 {
+						if err := validateBuildpacks(buildpacksClient, space.Spec.BuildpackBuild.BuilderImage, app.Buildpacks); err != nil {
+							return err
+						}
+					}
+
+					if app.Stack != "" {
+						if err := validateStack(buildpacksClient, space.Spec.BuildpackBuild.BuilderImage, app.Stack); err != nil {
+							return err
+						}
+					}
+
 					var imageName string
 					srcPath := filepath.Join(path, app.Path)
+					if gitURL == "" {
+						procfile, err := manifest.CheckForProcfile(srcPath)
+						if err != nil {
+							return fmt.Errorf("error reading Procfile in %s: %v", srcPath, err)
+						}
+						app.AddProcfile(procfile)
+					}
+
 					switch {
+					case gitURL != "":
+						// The source is cloned in the cluster, the app's path
+						// is the directory within the repository.
+						pushOpts = append(pushOpts, apps.WithPushGitSource(&v1alpha1.SourceSpecGit{
+							URL:               gitURL,
+							Revision:          gitRevision,
+							SubPath:           gitSubPath(app.Path),
+							CredentialsSecret: gitSecret,
+						}))
 					case sourceImage != "":
 						imageName = sourceImage
 					default:
@@ -287,15 +394,26 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 					pushOpts = append(pushOpts,
 						apps.WithPushSourceImage(imageName),
 						apps.WithPushContainerRegistry(registry),
-						apps.WithPushBuildpack(app.Buildpack()),
+						apps.WithPushBuildpacks(app.Buildpacks),
+						apps.WithPushStack(app.Stack),
+						apps.WithPushDockerfile(dockerfile),
 					)
 				} else {
 					if containerRegistry != "" {
 						return errors.New("--container-registry can only be used with source pushes, not containers")
 					}
-					if app.Buildpack() != "" {
+					if len(app.Buildpacks) > 0 {
 						return errors.New("cannot use buildpack and docker image simultaneously")
 					}
+					if app.Stack != "" {
+						return errors.New("cannot use stack and docker image simultaneously")
+					}
+					if dockerfile != "" {
+						return errors.New("cannot use dockerfile and docker image simultaneously")
+					}
+					if gitURL != "" {
+						return errors.New("cannot use git-url and docker image simultaneously")
+					}
 					if app.Path != "" {
 						return errors.New("cannot use path and docker image simultaneously")
 					}
@@ -303,6 +421,12 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 					pushOpts = append(pushOpts, apps.WithPushContainerImage(app.Docker.Image))
 				}
 
+				processes, err := appProcesses(space, app)
+				if err != nil {
+					return err
+				}
+				pushOpts = append(pushOpts, apps.WithPushProcesses(processes))
+
 				// Bind service if set
 				for _, serviceInstance := range app.Services {
 
@@ -328,6 +452,9 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 					return err
 				}
 
+				if err := upsertJobs(cmd.OutOrStdout(), tasksClient, p.Namespace, app); err != nil {
+					return err
+				}
 			}
 
 			return nil
@@ -380,12 +507,48 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 		"Ignore the manifest file.",
 	)
 
-	pushCmd.Flags().StringVarP(
-		&buildpack,
+	pushCmd.Flags().StringArrayVarP(
+		&buildpacks,
 		"buildpack",
 		"b",
+		nil,
+		"Skip the 'detect' buildpack step and use the given name. Can be repeated to run several buildpacks in order.",
+	)
+
+	pushCmd.Flags().StringVarP(
+		&stack,
+		"stack",
+		"s",
+		"",
+		"The run image to build the app on. Must be one of the stacks of the space's builder, see kf stacks.",
+	)
+
+	pushCmd.Flags().StringVar(
+		&dockerfile,
+		"dockerfile",
+		"",
+		"Build the app with the Dockerfile at the given path, relative to the app's source, instead of buildpacks.",
+	)
+
+	pushCmd.Flags().StringVar(
+		&gitURL,
+		"git-url",
+		"",
+		"Build the app from a git repository cloned in the cluster instead of uploading the local source. The manifest's path is the app's directory within the repository.",
+	)
+
+	pushCmd.Flags().StringVar(
+		&gitRevision,
+		"git-revision",
+		"",
+		"The branch, tag or commit of the git repository to build. Defaults to the repository's default branch.",
+	)
+
+	pushCmd.Flags().StringVar(
+		&gitSecret,
+		"git-credentials-secret",
 		"",
-		"Skip the 'detect' buildpack step and use the given name.",
+		"Name of a basic-auth or ssh-auth Secret in the space used to clone the git repository.",
 	)
 
 	pushCmd.Flags().StringVar(
@@ -440,6 +603,13 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 		"Do not start an app after pushing",
 	)
 
+	pushCmd.Flags().BoolVar(
+		&cancelOnInterrupt,
+		"cancel-on-interrupt",
+		false,
+		"Cancel the build if the push is interrupted (e.g., with Ctrl-C) while the app is building",
+	)
+
 	pushCmd.Flags().StringVarP(
 		&healthCheckType,
 		"health-check-type",
@@ -477,6 +647,58 @@ func NewPushCommand(p *config.KfParams, client apps.Client, pusher apps.Pusher,
 		"Use the routes flag to provide multiple HTTP and TCP routes. Each route for this app is created if it does not already exist.",
 	)
 
+	pushCmd.Flags().StringVar(
+		&rolloutStrategy,
+		"strategy",
+		"",
+		"How traffic is sent to the new revision (immediate, canary or blue-green). Defaults to the app's current strategy.",
+	)
+
+	pushCmd.Flags().IntVar(
+		&canaryPercent,
+		"canary-percent",
+		0,
+		fmt.Sprintf("The percent of traffic sent to the new revision when using the canary strategy (default is %d).", v1alpha1.DefaultCanaryPercent),
+	)
+
+	pushCmd.Flags().StringVarP(
+		&command,
+		"command",
+		"c",
+		"",
+		"Start the app with this command instead of the buildpack's or image's default process.",
+	)
+
+	pushCmd.Flags().StringArrayVar(
+		&args,
+		"args",
+		nil,
+		"Arguments passed to the container's entrypoint. Can be repeated to pass several arguments.",
+	)
+
+	pushCmd.Flags().StringVarP(
+		&memory,
+		"memory",
+		"m",
+		"",
+		"Memory limit of each instance (e.g., 256M, 1024M, 1G).",
+	)
+
+	pushCmd.Flags().StringVarP(
+		&diskQuota,
+		"disk-quota",
+		"k",
+		"",
+		"Disk limit of each instance (e.g., 256M, 1024M, 1G).",
+	)
+
+	pushCmd.Flags().StringVar(
+		&cpu,
+		"cpu",
+		"",
+		"Number of CPU cores requested for each instance (e.g., 0.5, 500m, 2).",
+	)
+
 	return pushCmd
 }
 
@@ -495,7 +717,159 @@ func calculateScaleBounds(instances, minScale, maxScale *int) (exact, min, max *
 	}
 }
 
+// minInstances returns the least number of instances an app scaled to
+// exactly or at least the given number runs, defaulting to one.
+func minInstances(exactly, min *int) int {
+	switch {
+	case exactly != nil:
+		return *exactly
+	case min != nil:
+		return *min
+	default:
+		return 1
+	}
+}
+
+// upsertJobs creates or updates the scheduled Tasks of the jobs in the app's
+// manifest. Jobs removed from the manifest are kept, delete-job removes them.
+func upsertJobs(w io.Writer, client tasks.Client, namespace string, app manifest.Application) error {
+	for _, job := range app.Jobs {
+		if job.Name == "" {
+			return fmt.Errorf("jobs of app %s must have a name", app.Name)
+		}
+
+		schedule := v1alpha1.TaskSpecSchedule{
+			Cron:                       job.Schedule,
+			ConcurrencyPolicy:          batchv1beta1.ConcurrencyPolicy(job.ConcurrencyPolicy),
+			SuccessfulRunsHistoryLimit: job.SuccessfulHistoryLimit,
+			FailedRunsHistoryLimit:     job.FailedHistoryLimit,
+		}
+
+		task := tasks.NewScheduledTask(namespace, job.Name, app.Name, job.Command, schedule)
+		if _, err := client.Upsert(namespace, task, tasks.MergeScheduledTask); err != nil {
+			return fmt.Errorf("failed to apply job %s: %s", job.Name, err)
+		}
+
+		fmt.Fprintf(w, "Applied job %q on schedule %q\n", job.Name, job.Schedule)
+	}
+
+	return nil
+}
+
+// appProcesses converts the processes in the app's manifest to the App's.
+func appProcesses(space *v1alpha1.Space, app manifest.Application) ([]v1alpha1.AppSpecProcess, error) {
+	var processes []v1alpha1.AppSpecProcess
+	for _, process := range app.Processes {
+		if process.Type == "" {
+			return nil, fmt.Errorf("processes of app %s must have a type", app.Name)
+		}
+
+		appProcess := v1alpha1.AppSpecProcess{
+			Type:      process.Type,
+			Command:   process.Command,
+			Instances: process.Instances,
+		}
+
+		resources, err := apps.NewResourceRequirements(process.Memory, process.DiskQuota, process.CPU)
+		if err != nil {
+			return nil, fmt.Errorf("process %s: %s", process.Type, err)
+		}
+
+		if resources != nil {
+			if err := apps.ValidateResourceLimits(space, *resources, minInstances(process.Instances, nil)); err != nil {
+				return nil, fmt.Errorf("process %s: %s", process.Type, err)
+			}
+
+			appProcess.Resources = *resources
+		}
+
+		processes = append(processes, appProcess)
+	}
+
+	return processes, nil
+}
+
+// validateBuildpacks checks that the builder has every buildpack.
+func validateBuildpacks(client buildpacks.Client, builderImage string, ids []string) error {
+	available, err := client.List(builderImage)
+	if err != nil {
+		return fmt.Errorf("failed to list the buildpacks of builder %s: %s", builderImage, err)
+	}
+
+	var availableIDs []string
+	onBuilder := make(map[string]bool)
+	for _, bp := range available {
+		availableIDs = append(availableIDs, bp.ID)
+		onBuilder[bp.ID] = true
+	}
+
+	for _, id := range ids {
+		if !onBuilder[id] {
+			return fmt.Errorf("buildpack %q isn't available on builder %s, use one of: %s", id, builderImage, strings.Join(availableIDs, ", "))
+		}
+	}
+
+	return nil
+}
+
+// validateStack checks that the builder advertises the stack.
+func validateStack(client buildpacks.Client, builderImage, stack string) error {
+	stacks, err := client.Stacks(builderImage)
+	if err != nil {
+		return fmt.Errorf("failed to list the stacks of builder %s: %s", builderImage, err)
+	}
+
+	for _, s := range stacks {
+		if s == stack {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("stack %q isn't available on builder %s, use one of: %s", stack, builderImage, strings.Join(stacks, ", "))
+}
+
+// parseRolloutStrategy converts a user supplied strategy into its App
+// equivalent.
+func parseRolloutStrategy(strategy string) (string, error) {
+	switch strings.ToLower(strategy) {
+	case "":
+		return "", nil
+	case "immediate":
+		return v1alpha1.RolloutStrategyImmediate, nil
+	case "canary":
+		return v1alpha1.RolloutStrategyCanary, nil
+	case "blue-green":
+		return v1alpha1.RolloutStrategyBlueGreen, nil
+	default:
+		return "", fmt.Errorf("unknown strategy %q, must be one of: immediate, canary, blue-green", strategy)
+	}
+}
+
+// gitSubPath converts the path of an app in a manifest into a directory
+// within a git repository.
+func gitSubPath(appPath string) string {
+	subPath := filepath.ToSlash(filepath.Clean(appPath))
+	if subPath == "." {
+		return ""
+	}
+
+	return subPath
+}
+
 func createRoute(routeStr, namespace string) (v1alpha1.RouteSpecFields, error) {
+	// Routes with a port (e.g. tcp.example.com:1234) are TCP routes.
+	if u, err := url.Parse("tcp://" + routeStr); err == nil && u.Port() != "" {
+		port, err := strconv.ParseInt(u.Port(), 10, 32)
+		if err != nil {
+			return v1alpha1.RouteSpecFields{}, fmt.Errorf("failed to parse route port: %s", err)
+		}
+
+		return v1alpha1.RouteSpecFields{
+			Domain: u.Hostname(),
+			Port:   int32(port),
+		}, nil
+	}
+
 	hostname, domain, path, err := parseRouteStr(routeStr)
 	if err != nil {
 		return v1alpha1.RouteSpecFields{}, err
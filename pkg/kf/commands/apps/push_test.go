@@ -27,13 +27,19 @@ import (
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/apps"
 	appsfake "github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/buildpacks"
+	buildpacksfake "github.com/google/kf/pkg/kf/buildpacks/fake"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
 	svbFake "github.com/google/kf/pkg/kf/service-bindings/fake"
+	"github.com/google/kf/pkg/kf/tasks"
+	tasksfake "github.com/google/kf/pkg/kf/tasks/fake"
 	"github.com/google/kf/pkg/kf/testutil"
 	"github.com/poy/service-catalog/pkg/apis/servicecatalog/v1beta1"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 type routeParts struct {
@@ -70,15 +76,18 @@ func TestPushCommand(t *testing.T) {
 	}
 
 	for tn, tc := range map[string]struct {
-		args            []string
-		namespace       string
-		wantErr         error
-		pusherErr       error
-		srcImageBuilder SrcImageBuilderFunc
-		wantImagePrefix string
-		targetSpace     *v1alpha1.Space
-		wantOpts        []apps.PushOption
-		setup           func(t *testing.T, f *svbFake.FakeClientInterface)
+		args              []string
+		namespace         string
+		wantErr           error
+		pusherErr         error
+		srcImageBuilder   SrcImageBuilderFunc
+		wantImagePrefix   string
+		targetSpace       *v1alpha1.Space
+		wantOpts          []apps.PushOption
+		stacks            []string
+		builderBuildpacks []string
+		setup             func(t *testing.T, f *svbFake.FakeClientInterface)
+		setupTasks        func(t *testing.T, f *tasksfake.FakeClient)
 	}{
 		"uses configured properties": {
 			namespace: "some-namespace",
@@ -93,6 +102,7 @@ func TestPushCommand(t *testing.T) {
 				"--instances", "1",
 				"--path", "testdata/example-app",
 				"--no-start",
+				"--cancel-on-interrupt",
 				"-u", "http",
 				"-t", "28",
 			},
@@ -102,14 +112,16 @@ func TestPushCommand(t *testing.T) {
 				testutil.AssertEqual(t, "path is abs", true, filepath.IsAbs(dir))
 				return nil
 			},
+			builderBuildpacks: []string{"some-buildpack"},
 			wantOpts: append(defaultOptions,
 				apps.WithPushNamespace("some-namespace"),
 				apps.WithPushContainerRegistry("some-reg.io"),
 				apps.WithPushServiceAccount("some-service-account"),
 				apps.WithPushGrpc(true),
-				apps.WithPushBuildpack("some-buildpack"),
+				apps.WithPushBuildpacks([]string{"some-buildpack"}),
 				apps.WithPushEnvironmentVariables(map[string]string{"env1": "val1", "env2": "val2"}),
 				apps.WithPushNoStart(true),
+				apps.WithPushCancelOnInterrupt(true),
 				apps.WithPushExactScale(intPtr(1)),
 				apps.WithPushHealthCheck(&corev1.Probe{
 					TimeoutSeconds: 28,
@@ -286,10 +298,11 @@ func TestPushCommand(t *testing.T) {
 					},
 				},
 			},
+			builderBuildpacks: []string{"java", "tomcat"},
 			wantOpts: append(defaultOptions,
 				apps.WithPushNamespace("some-namespace"),
 				apps.WithPushContainerRegistry("space-reg.io"),
-				apps.WithPushBuildpack("java,tomcat"),
+				apps.WithPushBuildpacks([]string{"java", "tomcat"}),
 			),
 		},
 		"SrcImageBuilder returns an error": {
@@ -343,6 +356,98 @@ func TestPushCommand(t *testing.T) {
 			},
 			wantErr: errors.New("cannot use buildpack and docker image simultaneously"),
 		},
+		"invalid dockerfile and container image": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--docker-image", "some-image",
+				"--dockerfile", "Dockerfile",
+			},
+			wantErr: errors.New("cannot use dockerfile and docker image simultaneously"),
+		},
+		"invalid dockerfile and buildpack": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--container-registry", "some-reg.io",
+				"--buildpack", "some-buildpack",
+				"--dockerfile", "Dockerfile",
+			},
+			wantErr: errors.New("cannot use buildpack and dockerfile simultaneously"),
+		},
+		"pushes dockerfile app": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--container-registry", "some-reg.io",
+				"--dockerfile", "build/Dockerfile",
+			},
+			wantImagePrefix: "some-reg.io/src-some-namespace-example-app",
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushDockerfile("build/Dockerfile"),
+			),
+		},
+		"pushes git app": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--container-registry", "some-reg.io",
+				"--git-url", "https://github.com/google/kf.git",
+				"--git-revision", "master",
+				"--git-credentials-secret", "git-creds",
+			},
+			srcImageBuilder: func(dir, srcImage string, rebase bool) error {
+				t.Error("source shouldn't be uploaded for git apps")
+				return nil
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushGitSource(&v1alpha1.SourceSpecGit{
+					URL:               "https://github.com/google/kf.git",
+					Revision:          "master",
+					CredentialsSecret: "git-creds",
+				}),
+			),
+		},
+		"git app uses manifest path as sub path": {
+			namespace: "some-namespace",
+			args: []string{
+				"buildpack-app",
+				"--container-registry", "some-reg.io",
+				"--manifest", "testdata/manifest.yml",
+				"--git-url", "git@github.com:google/kf.git",
+			},
+			builderBuildpacks: []string{"java", "tomcat"},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushBuildpacks([]string{"java", "tomcat"}),
+				apps.WithPushGitSource(&v1alpha1.SourceSpecGit{
+					URL:     "git@github.com:google/kf.git",
+					SubPath: "example-app",
+				}),
+			),
+		},
+		"git revision without git url": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--git-revision", "master",
+			},
+			wantErr: errors.New("--git-revision and --git-credentials-secret can only be used with --git-url"),
+		},
+		"invalid git url and container image": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--docker-image", "some-image",
+				"--git-url", "https://github.com/google/kf.git",
+			},
+			wantErr: errors.New("cannot use git-url and docker image simultaneously"),
+		},
 		"invalid container registry and container image": {
 			namespace: "some-namespace",
 			args: []string{
@@ -380,12 +485,173 @@ func TestPushCommand(t *testing.T) {
 				"--manifest", "testdata/manifest.yml",
 				"--container-registry", "some-registry.io",
 			},
+			builderBuildpacks: []string{"java", "tomcat"},
 			wantOpts: append(defaultOptions,
 				apps.WithPushNamespace("some-namespace"),
-				apps.WithPushBuildpack("java,tomcat"),
+				apps.WithPushBuildpacks([]string{"java", "tomcat"}),
 				apps.WithPushContainerRegistry("some-registry.io"),
 			),
 		},
+		"jobs from manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"jobs-app",
+				"--manifest", "testdata/manifest.yml",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/jobs-app"),
+			),
+			setupTasks: func(t *testing.T, f *tasksfake.FakeClient) {
+				f.EXPECT().
+					Upsert("some-namespace", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ns string, task *v1alpha1.Task, merge tasks.Merger) (*v1alpha1.Task, error) {
+						testutil.AssertEqual(t, "name", "jobs-app-cleanup", task.Name)
+						testutil.AssertEqual(t, "app", "jobs-app", task.Spec.AppName)
+						testutil.AssertEqual(t, "command", "bin/cleanup", task.Spec.Command)
+						testutil.AssertEqual(t, "cron", "0 3 * * *", task.Spec.Schedule.Cron)
+						testutil.AssertEqual(t, "concurrency policy", batchv1beta1.ForbidConcurrent, task.Spec.Schedule.ConcurrencyPolicy)
+						return task, nil
+					})
+			},
+		},
+		"unnamed job in manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"unnamed-job-app",
+				"--manifest", "testdata/manifest.yml",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/unnamed-job-app"),
+			),
+			wantErr: errors.New("jobs of app unnamed-job-app must have a name"),
+		},
+		"processes from manifest and Procfile": {
+			namespace: "some-namespace",
+			args: []string{
+				"procfile-app",
+				"--container-registry", "some-reg.io",
+				"--path", "testdata/procfile-app",
+			},
+			wantImagePrefix: "some-reg.io/src-some-namespace-procfile-app",
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushProcesses([]v1alpha1.AppSpecProcess{
+					{Type: "worker", Command: "bundle exec sidekiq -c 10", Instances: intPtr(3)},
+					{Type: "clock", Command: "bundle exec clockwork clock.rb"},
+				}),
+			),
+		},
+		"untyped process in manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"untyped-process-app",
+				"--manifest", "testdata/manifest.yml",
+			},
+			wantErr: errors.New("processes of app untyped-process-app must have a type"),
+		},
+		"command and args from flags": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--docker-image", "gcr.io/example-app",
+				"--command", "./server --port $PORT",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/example-app"),
+				apps.WithPushCommand("./server --port $PORT"),
+			),
+		},
+		"args from flags": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--docker-image", "gcr.io/example-app",
+				"--args=--verbose",
+				"--args", "8080",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/example-app"),
+				apps.WithPushArgs([]string{"--verbose", "8080"}),
+			),
+		},
+		"command from manifest": {
+			namespace: "some-namespace",
+			args: []string{
+				"command-app",
+				"--manifest", "testdata/manifest.yml",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/command-app"),
+				apps.WithPushCommand("bin/server"),
+			),
+		},
+		"command and args together": {
+			namespace: "some-namespace",
+			args: []string{
+				"command-app",
+				"--manifest", "testdata/manifest.yml",
+				"--args", "--verbose",
+			},
+			wantErr: errors.New("cannot use command and args simultaneously"),
+		},
+		"resources from flags": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--docker-image", "gcr.io/example-app",
+				"-m", "512M",
+				"-k", "2G",
+				"--cpu", "500m",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("gcr.io/example-app"),
+				apps.WithPushResources(&corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory:           resource.MustParse("512Mi"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+						corev1.ResourceCPU:              resource.MustParse("500m"),
+					},
+					Limits: corev1.ResourceList{
+						corev1.ResourceMemory:           resource.MustParse("512Mi"),
+						corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+					},
+				}),
+			),
+		},
+		"invalid memory": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--docker-image", "gcr.io/example-app",
+				"--memory", "lots",
+			},
+			wantErr: errors.New(`memory: invalid size "lots", use a number followed by M or G e.g. 512M`),
+		},
+		"memory over the space's limit": {
+			namespace: "some-namespace",
+			targetSpace: func() *v1alpha1.Space {
+				space := &v1alpha1.Space{}
+				space.Spec.Execution = defaultSpaceSpecExecution
+				space.Spec.ResourceLimits.ResourceDefaults = []corev1.LimitRangeItem{{
+					Type: corev1.LimitTypeContainer,
+					Max:  corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")},
+				}}
+				return space
+			}(),
+			args: []string{
+				"example-app",
+				"--docker-image", "gcr.io/example-app",
+				"--memory", "2G",
+			},
+			wantErr: errors.New("memory limit of 2Gi is more than the space's maximum of 1Gi"),
+		},
 		"manifest missing app": {
 			namespace: "some-namespace",
 			args: []string{
@@ -537,6 +803,97 @@ func TestPushCommand(t *testing.T) {
 				}),
 			),
 		},
+		"canary rollout": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--docker-image", "some-image",
+				"--strategy", "canary",
+				"--canary-percent", "25",
+			},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerImage("some-image"),
+				apps.WithPushRolloutStrategy(v1alpha1.RolloutStrategyCanary),
+				apps.WithPushCanaryPercent(25),
+			),
+		},
+		"unknown strategy": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--strategy", "shadow",
+			},
+			wantErr: errors.New(`unknown strategy "shadow", must be one of: immediate, canary, blue-green`),
+		},
+		"canary percent without canary strategy": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--canary-percent", "25",
+			},
+			wantErr: errors.New("--canary-percent can only be used with --strategy=canary"),
+		},
+		"stack": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--stack", "gcr.io/mirror/bionic",
+				"--container-registry", "some-reg.io",
+			},
+			stacks:          []string{"bionic", "gcr.io/mirror/bionic"},
+			wantImagePrefix: "some-reg.io/src-some-namespace-example-app",
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushStack("gcr.io/mirror/bionic"),
+			),
+		},
+		"stack not on builder": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--stack", "windows",
+				"--container-registry", "some-reg.io",
+			},
+			stacks:  []string{"bionic"},
+			wantErr: errors.New(`stack "windows" isn't available on builder ` + v1alpha1.DefaultBuilderImage + `, use one of: bionic`),
+		},
+		"stack with docker image": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--stack", "bionic",
+				"--docker-image", "some-image",
+			},
+			wantErr: errors.New("cannot use stack and docker image simultaneously"),
+		},
+		"multiple buildpacks": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--buildpack", "python",
+				"-b", "nodejs",
+				"--container-registry", "some-reg.io",
+			},
+			builderBuildpacks: []string{"nodejs", "python"},
+			wantOpts: append(defaultOptions,
+				apps.WithPushNamespace("some-namespace"),
+				apps.WithPushContainerRegistry("some-reg.io"),
+				apps.WithPushBuildpacks([]string{"python", "nodejs"}),
+			),
+		},
+		"buildpack not on builder": {
+			namespace: "some-namespace",
+			args: []string{
+				"example-app",
+				"--buildpack", "nodejs",
+				"--buildpack", "ruby",
+				"--container-registry", "some-reg.io",
+			},
+			builderBuildpacks: []string{"nodejs", "python"},
+			wantErr:           errors.New(`buildpack "ruby" isn't available on builder ` + v1alpha1.DefaultBuilderImage + `, use one of: nodejs, python`),
+		},
 		"bad timeout": {
 			namespace: "some-namespace",
 			args: []string{
@@ -555,6 +912,15 @@ func TestPushCommand(t *testing.T) {
 			fakeApps := appsfake.NewFakeClient(ctrl)
 			fakePusher := appsfake.NewFakePusher(ctrl)
 			svbClient := svbFake.NewFakeClientInterface(ctrl)
+			fakeBuildpacks := buildpacksfake.NewFakeClient(ctrl)
+			fakeTasks := tasksfake.NewFakeClient(ctrl)
+			fakeBuildpacks.EXPECT().Stacks(gomock.Any()).Return(tc.stacks, nil).AnyTimes()
+
+			var builderBuildpacks []buildpacks.Buildpack
+			for _, id := range tc.builderBuildpacks {
+				builderBuildpacks = append(builderBuildpacks, buildpacks.Buildpack{ID: id})
+			}
+			fakeBuildpacks.EXPECT().List(gomock.Any()).Return(builderBuildpacks, nil).AnyTimes()
 
 			fakePusher.
 				EXPECT().
@@ -566,7 +932,10 @@ func TestPushCommand(t *testing.T) {
 					actualOpts := apps.PushOptions(opts)
 					testutil.AssertEqual(t, "namespace", expectOpts.Namespace(), actualOpts.Namespace())
 					testutil.AssertEqual(t, "container registry", expectOpts.ContainerRegistry(), actualOpts.ContainerRegistry())
-					testutil.AssertEqual(t, "buildpack", expectOpts.Buildpack(), actualOpts.Buildpack())
+					testutil.AssertEqual(t, "buildpacks", expectOpts.Buildpacks(), actualOpts.Buildpacks())
+					testutil.AssertEqual(t, "stack", expectOpts.Stack(), actualOpts.Stack())
+					testutil.AssertEqual(t, "dockerfile", expectOpts.Dockerfile(), actualOpts.Dockerfile())
+					testutil.AssertEqual(t, "git source", expectOpts.GitSource(), actualOpts.GitSource())
 					testutil.AssertEqual(t, "service account", expectOpts.ServiceAccount(), actualOpts.ServiceAccount())
 					testutil.AssertEqual(t, "grpc", expectOpts.Grpc(), actualOpts.Grpc())
 					testutil.AssertEqual(t, "env vars", expectOpts.EnvironmentVariables(), actualOpts.EnvironmentVariables())
@@ -574,10 +943,17 @@ func TestPushCommand(t *testing.T) {
 					testutil.AssertEqual(t, "min scale bound", expectOpts.MinScale(), actualOpts.MinScale())
 					testutil.AssertEqual(t, "max scale bound", expectOpts.MaxScale(), actualOpts.MaxScale())
 					testutil.AssertEqual(t, "no start", expectOpts.NoStart(), actualOpts.NoStart())
+					testutil.AssertEqual(t, "cancel on interrupt", expectOpts.CancelOnInterrupt(), actualOpts.CancelOnInterrupt())
 					testutil.AssertEqual(t, "routes", expectOpts.Routes(), actualOpts.Routes())
 					testutil.AssertEqual(t, "health check", expectOpts.HealthCheck(), actualOpts.HealthCheck())
 					testutil.AssertEqual(t, "default route", expectOpts.DefaultRouteDomain(), actualOpts.DefaultRouteDomain())
 					testutil.AssertEqual(t, "random route", expectOpts.RandomRouteDomain(), actualOpts.RandomRouteDomain())
+					testutil.AssertEqual(t, "rollout strategy", expectOpts.RolloutStrategy(), actualOpts.RolloutStrategy())
+					testutil.AssertEqual(t, "canary percent", expectOpts.CanaryPercent(), actualOpts.CanaryPercent())
+					testutil.AssertEqual(t, "processes", expectOpts.Processes(), actualOpts.Processes())
+					testutil.AssertEqual(t, "command", expectOpts.Command(), actualOpts.Command())
+					testutil.AssertEqual(t, "args", expectOpts.Args(), actualOpts.Args())
+					testutil.AssertEqual(t, "resources", expectOpts.Resources(), actualOpts.Resources())
 
 					if !strings.HasPrefix(actualOpts.SourceImage(), tc.wantImagePrefix) {
 						t.Errorf("Wanted srcImage to start with %s got: %s", tc.wantImagePrefix, actualOpts.SourceImage())
@@ -601,7 +977,11 @@ func TestPushCommand(t *testing.T) {
 				tc.setup(t, svbClient)
 			}
 
-			c := NewPushCommand(params, fakeApps, fakePusher, tc.srcImageBuilder, svbClient)
+			if tc.setupTasks != nil {
+				tc.setupTasks(t, fakeTasks)
+			}
+
+			c := NewPushCommand(params, fakeApps, fakePusher, tc.srcImageBuilder, svbClient, fakeBuildpacks, fakeTasks)
 			buffer := &bytes.Buffer{}
 			c.SetOutput(buffer)
 			c.SetArgs(tc.args)
@@ -632,6 +1012,7 @@ func buildTestRoutes() []v1alpha1.RouteSpecFields {
 		buildRoute("", "example.com", ""),
 		buildRoute("", "www.example.com", "/foo"),
 		buildRoute("host", "example.com", "/foo"),
+		{Domain: "tcp.example.com", Port: 1024},
 	}
 }
 
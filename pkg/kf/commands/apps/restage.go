@@ -31,6 +31,11 @@ func NewRestageCommand(
 	var restage = &cobra.Command{
 		Use:   "restage APP_NAME",
 		Short: "Restage creates a new container using the given source code and current buildpacks",
+		Long: `
+	Restage rebuilds the app from the source it was last pushed with.
+
+	Apps pushed with --git-url clone their repository again, so a branch
+	revision builds its latest commit.`,
 		Example: `
   kf restage myapp
   `,
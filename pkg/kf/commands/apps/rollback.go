@@ -0,0 +1,128 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewRollbackCommand creates a command capable of aborting the canary
+// revision of an app or rolling it back to a previous revision.
+func NewRollbackCommand(
+	p *config.KfParams,
+	client apps.Client,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "rollback APP_NAME [REVISION]",
+		Short: "Roll an app back to a previous revision",
+		Long: `
+	Rollback aborts the canary of an app if one is in progress and no REVISION
+	is given. Otherwise all traffic is sent to a revision from the app's
+	history without rebuilding until the app is pushed or changed again, e.g.
+	by scaling it or setting an environment variable. If no REVISION
+	is given the most recent revision with a different image than the one
+	serving traffic is used. Use kf app-history to list the revisions of an
+	app.`,
+		Example: `
+  kf rollback myapp
+  kf rollback myapp myapp-abcde
+  `,
+		Args: cobra.RangeArgs(1, 2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			appName := args[0]
+
+			var requestedRevision string
+			if len(args) > 1 {
+				requestedRevision = args[1]
+			}
+
+			cmd.SilenceUsage = true
+
+			var (
+				abortedRevision string
+				target          v1alpha1.AppRevision
+			)
+			mutator := func(app *v1alpha1.App) error {
+				if requestedRevision == "" && app.Status.Rollout.Phase == v1alpha1.RolloutPhaseCanary {
+					abortedRevision = app.Status.Rollout.CanaryRevisionName
+					app.Spec.Rollout.AbortedRevisionName = abortedRevision
+					return nil
+				}
+
+				revision, err := findRollbackRevision(app, requestedRevision)
+				if err != nil {
+					return err
+				}
+
+				target = *revision
+				app.Spec.Rollout.RollbackRevisionName = revision.RevisionName
+				return nil
+			}
+
+			if err := client.Transform(p.Namespace, appName, mutator); err != nil {
+				return fmt.Errorf("failed to rollback app: %s", err)
+			}
+
+			if abortedRevision != "" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Rolling back canary revision %s of app %s\n", abortedRevision, appName)
+				return nil
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Rolling back app %s to revision %s (image %s)\n", appName, target.RevisionName, target.Image)
+			return nil
+		},
+	}
+}
+
+// findRollbackRevision gets the requested revision from the App's history.
+// If no revision is requested the newest one older than the revision serving
+// traffic with a different image is returned.
+func findRollbackRevision(app *v1alpha1.App, revisionName string) (*v1alpha1.AppRevision, error) {
+	status := app.Status
+	if revisionName != "" {
+		revision := status.FindRevision(revisionName)
+		if revision == nil {
+			return nil, fmt.Errorf("revision %s isn't in the app's history", revisionName)
+		}
+
+		return revision, nil
+	}
+
+	current := 0
+	for i, revision := range status.History {
+		if revision.RevisionName == app.Spec.Rollout.RollbackRevisionName {
+			current = i
+		}
+	}
+
+	for i := current + 1; i < len(status.History); i++ {
+		if status.History[i].Image != status.History[current].Image {
+			return &status.History[i], nil
+		}
+	}
+
+	return nil, errors.New("the app has no previous revisions to roll back to")
+}
@@ -0,0 +1,202 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package apps
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestRollback(t *testing.T) {
+	t.Parallel()
+
+	canaryApp := func() *v1alpha1.App {
+		app := &v1alpha1.App{}
+		app.Status.Rollout = v1alpha1.AppStatusRollout{
+			Phase:              v1alpha1.RolloutPhaseCanary,
+			StableRevisionName: "my-app-00001",
+			CanaryRevisionName: "my-app-00002",
+			CanaryPercent:      10,
+		}
+		return app
+	}
+
+	historyApp := func() *v1alpha1.App {
+		app := &v1alpha1.App{}
+		app.Spec.Source.ServiceAccount = "some-account"
+		app.Spec.Source.BuildpackBuild.Source = "some-source-image"
+		app.Status.History = []v1alpha1.AppRevision{
+			{RevisionName: "my-app-00003", Image: "gcr.io/image-2"},
+			{RevisionName: "my-app-00002", Image: "gcr.io/image-2"},
+			{RevisionName: "my-app-00001", Image: "gcr.io/image-1"},
+		}
+		return app
+	}
+
+	cases := map[string]struct {
+		Namespace       string
+		Args            []string
+		ExpectedStrings []string
+		ExpectedErr     error
+		Setup           func(t *testing.T, fake *fake.FakeClient)
+	}{
+		"rolls back canary": {
+			Namespace:       "default",
+			Args:            []string{"my-app"},
+			ExpectedStrings: []string{"Rolling back canary revision my-app-00002 of app my-app"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					DoAndReturn(func(_, _ string, mutator apps.Mutator) error {
+						app := canaryApp()
+						err := mutator(app)
+						testutil.AssertEqual(t, "app.spec.rollout.abortedRevisionName", "my-app-00002", app.Spec.Rollout.AbortedRevisionName)
+						return err
+					})
+			},
+		},
+		"rolls back to previous image": {
+			Namespace:       "default",
+			Args:            []string{"my-app"},
+			ExpectedStrings: []string{"Rolling back app my-app to revision my-app-00001 (image gcr.io/image-1)"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					DoAndReturn(func(_, _ string, mutator apps.Mutator) error {
+						app := historyApp()
+						err := mutator(app)
+						testutil.AssertEqual(t, "app.spec.rollout.rollbackRevisionName", "my-app-00001", app.Spec.Rollout.RollbackRevisionName)
+
+						// Assert the source wasn't altered so the app can still be rebuilt
+						testutil.AssertEqual(t, "app.spec.source", historyApp().Spec.Source, app.Spec.Source)
+						return err
+					})
+			},
+		},
+		"rolls back to named revision": {
+			Namespace:       "default",
+			Args:            []string{"my-app", "my-app-00002"},
+			ExpectedStrings: []string{"Rolling back app my-app to revision my-app-00002 (image gcr.io/image-2)"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					DoAndReturn(func(_, _ string, mutator apps.Mutator) error {
+						app := historyApp()
+						app.Status.Rollout = canaryApp().Status.Rollout
+						err := mutator(app)
+						testutil.AssertEqual(t, "app.spec.rollout.rollbackRevisionName", "my-app-00002", app.Spec.Rollout.RollbackRevisionName)
+						testutil.AssertEqual(t, "app.spec.rollout.abortedRevisionName", "", app.Spec.Rollout.AbortedRevisionName)
+						return err
+					})
+			},
+		},
+		"already rolled back to the oldest revision": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to rollback app: the app has no previous revisions to roll back to"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					DoAndReturn(func(_, _ string, mutator apps.Mutator) error {
+						app := historyApp()
+						app.Spec.Rollout.RollbackRevisionName = "my-app-00001"
+						return mutator(app)
+					})
+			},
+		},
+		"unknown revision": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "my-app-00009"},
+			ExpectedErr: errors.New("failed to rollback app: revision my-app-00009 isn't in the app's history"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					DoAndReturn(func(_, _ string, mutator apps.Mutator) error {
+						return mutator(historyApp())
+					})
+			},
+		},
+		"no previous revisions": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to rollback app: the app has no previous revisions to roll back to"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					DoAndReturn(func(_, _ string, mutator apps.Mutator) error {
+						app := canaryApp()
+						app.Status.Rollout = v1alpha1.AppStatusRollout{
+							Phase:              v1alpha1.RolloutPhaseStable,
+							StableRevisionName: "my-app-00001",
+						}
+						return mutator(app)
+					})
+			},
+		},
+		"no app name": {
+			Namespace:   "default",
+			Args:        []string{},
+			ExpectedErr: errors.New("accepts between 1 and 2 arg(s), received 0"),
+		},
+		"transforming app fails": {
+			Namespace:   "default",
+			Args:        []string{"my-app"},
+			ExpectedErr: errors.New("failed to rollback app: some-error"),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("some-error"))
+			},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fake := fake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, fake)
+			}
+
+			buf := new(bytes.Buffer)
+			p := &config.KfParams{
+				Namespace: tc.Namespace,
+			}
+
+			cmd := NewRollbackCommand(p, fake)
+			cmd.SetOutput(buf)
+			cmd.SetArgs(tc.Args)
+			_, actualErr := cmd.ExecuteC()
+			if tc.ExpectedErr != nil || actualErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, actualErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buf.String(), tc.ExpectedStrings)
+			testutil.AssertEqual(t, "SilenceUsage", true, cmd.SilenceUsage)
+
+			ctrl.Finish()
+		})
+	}
+}
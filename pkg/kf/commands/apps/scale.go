@@ -24,6 +24,7 @@ import (
 	"github.com/google/kf/pkg/kf/commands/utils"
 	"github.com/google/kf/pkg/kf/describe"
 	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
 )
 
 // NewScaleCommand creates a command capable of scaling an app.
@@ -35,11 +36,14 @@ func NewScaleCommand(
 		instances    int
 		autoscaleMin int
 		autoscaleMax int
+		memory       string
+		diskQuota    string
+		cpu          string
 	)
 
 	var scale = &cobra.Command{
 		Use:   "scale APP_NAME",
-		Short: "Change or view the instance count for an app",
+		Short: "Change or view the instance count and resources of an app",
 		Example: `
   kf scale myapp # Displays current scaling
   kf scale myapp --i 3 # Scale to exactly 3 instances
@@ -47,6 +51,8 @@ func NewScaleCommand(
   kf scale myapp --min 3 # Autoscaler won't scale below 3 instances
   kf scale myapp --max 5 # Autoscaler won't scale above 5 instances
   kf scale myapp --min 3 --max 5 # Autoscaler won't below 3 or above 5 instances
+  kf scale myapp -m 1G -k 2G # Give each instance 1G of memory and 2G of disk
+  kf scale myapp --cpu 500m # Request half a CPU core for each instance
   `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -57,44 +63,82 @@ func NewScaleCommand(
 
 			appName := args[0]
 
-			if instances < 0 && autoscaleMin < 0 && autoscaleMax < 0 {
+			resources, err := apps.NewResourceRequirements(memory, diskQuota, cpu)
+			if err != nil {
+				return err
+			}
+
+			scaleInstances := instances >= 0 || autoscaleMin >= 0 || autoscaleMax >= 0
+
+			if !scaleInstances && resources == nil {
 				// Display current scaling properties.
 				app, err := client.Get(p.Namespace, appName)
 				if err != nil {
 					return fmt.Errorf("failed to get app: %s", err)
 				}
 				describe.AppSpecInstances(cmd.OutOrStderr(), app.Spec.Instances)
+				describe.Resources(cmd.OutOrStderr(), apps.NewFromApp(app).GetResources())
 
 				return nil
 			}
 
+			space, err := p.GetTargetSpaceOrDefault()
+			if err != nil {
+				return err
+			}
+
 			// Manipulate the scaling
 
 			mutator := func(app *v1alpha1.App) error {
-				app.Spec.Instances.Min = nil
-				app.Spec.Instances.Max = nil
-				app.Spec.Instances.Exactly = nil
-
-				if instances >= 0 {
-					// Exact
-					app.Spec.Instances.Exactly = &instances
+				oldInstances := minInstances(app.Spec.Instances.Exactly, app.Spec.Instances.Min)
+				oldResources := apps.NewFromApp(app).GetResources()
+				oldResources = *oldResources.DeepCopy()
+
+				if scaleInstances {
+					app.Spec.Instances.Min = nil
+					app.Spec.Instances.Max = nil
+					app.Spec.Instances.Exactly = nil
+
+					if instances >= 0 {
+						// Exact
+						app.Spec.Instances.Exactly = &instances
+					}
+
+					if autoscaleMin >= 0 {
+						// Min is set
+						app.Spec.Instances.Min = &autoscaleMin
+					}
+
+					if autoscaleMax >= 0 {
+						// Max is set
+						app.Spec.Instances.Max = &autoscaleMax
+					}
+
+					if err := app.Spec.Instances.Validate(context.Background()); err != nil {
+						return err
+					}
 				}
 
-				if autoscaleMin >= 0 {
-					// Min is set
-					app.Spec.Instances.Min = &autoscaleMin
+				kfApp := apps.NewFromApp(app)
+				if resources != nil {
+					kfApp.MergeResources(*resources)
 				}
 
-				if autoscaleMax >= 0 {
-					// Max is set
-					app.Spec.Instances.Max = &autoscaleMax
+				if err := apps.ValidateLimitRange(space, kfApp.GetResources()); err != nil {
+					return err
 				}
 
-				if err := app.Spec.Instances.Validate(context.Background()); err != nil {
-					return err
+				// Scaling down skips the quota so Apps that no longer fit in
+				// the space can be brought back within it.
+				newInstances := minInstances(app.Spec.Instances.Exactly, app.Spec.Instances.Min)
+				if newInstances > oldInstances || resourcesIncreased(oldResources, kfApp.GetResources()) {
+					if err := apps.ValidateResourceQuota(space, kfApp.GetResources(), newInstances); err != nil {
+						return err
+					}
 				}
 
 				describe.AppSpecInstances(cmd.OutOrStderr(), app.Spec.Instances)
+				describe.Resources(cmd.OutOrStderr(), kfApp.GetResources())
 
 				return nil
 			}
@@ -129,5 +173,44 @@ func NewScaleCommand(
 		"Maximum number of instances to allow the autoscaler to scale to. 0 implies the app can be scaled to ∞.",
 	)
 
+	scale.Flags().StringVarP(
+		&memory,
+		"memory",
+		"m",
+		"",
+		"Memory limit of each instance (e.g., 256M, 1024M, 1G).",
+	)
+
+	scale.Flags().StringVarP(
+		&diskQuota,
+		"disk-quota",
+		"k",
+		"",
+		"Disk limit of each instance (e.g., 256M, 1024M, 1G).",
+	)
+
+	scale.Flags().StringVar(
+		&cpu,
+		"cpu",
+		"",
+		"Number of CPU cores requested for each instance (e.g., 0.5, 500m, 2).",
+	)
+
 	return scale
 }
+
+// resourcesIncreased returns true if any of the requests or limits is larger
+// than before or wasn't set.
+func resourcesIncreased(old, new corev1.ResourceRequirements) bool {
+	increased := func(old, new corev1.ResourceList) bool {
+		for name, quantity := range new {
+			if oldQuantity, ok := old[name]; !ok || quantity.Cmp(oldQuantity) > 0 {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return increased(old.Requests, new.Requests) || increased(old.Limits, new.Limits)
+}
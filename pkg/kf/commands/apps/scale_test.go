@@ -25,6 +25,8 @@ import (
 	"github.com/google/kf/pkg/kf/apps/fake"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 func TestNewScaleCommand(t *testing.T) {
@@ -35,6 +37,7 @@ func TestNewScaleCommand(t *testing.T) {
 		Args            []string
 		ExpectedStrings []string
 		ExpectedErr     error
+		Space           *v1alpha1.Space
 		Setup           func(t *testing.T, fake *fake.FakeClient)
 	}{
 		"updates app to exact instances": {
@@ -136,6 +139,142 @@ func TestNewScaleCommand(t *testing.T) {
 					})
 			},
 		},
+		"updates app resources": {
+			Namespace:       "default",
+			Args:            []string{"my-app", "-m=512M", "--cpu=500m"},
+			ExpectedStrings: []string{"Exactly:", "2", "Memory:", "512Mi", "Memory Limit:", "512Mi", "CPU:", "500m"},
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						exactly := 2
+						app := v1alpha1.App{}
+						app.Spec.Instances.Exactly = &exactly
+						app.Spec.Template.Spec.Containers = []corev1.Container{{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceMemory:           resource.MustParse("1Gi"),
+									corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+								},
+							},
+						}}
+						testutil.AssertNil(t, "mutator error", m(&app))
+
+						// Assert instances weren't altered
+						testutil.AssertEqual(t, "app.spec.instances.exactly", 2, *app.Spec.Instances.Exactly)
+
+						resources := apps.NewFromApp(&app).GetResources()
+						testutil.AssertEqual(t, "requests", corev1.ResourceList{
+							corev1.ResourceMemory:           resource.MustParse("512Mi"),
+							corev1.ResourceEphemeralStorage: resource.MustParse("2Gi"),
+							corev1.ResourceCPU:              resource.MustParse("500m"),
+						}, resources.Requests)
+						testutil.AssertEqual(t, "limits", corev1.ResourceList{
+							corev1.ResourceMemory: resource.MustParse("512Mi"),
+						}, resources.Limits)
+					})
+			},
+		},
+		"invalid memory": {
+			Namespace:   "default",
+			Args:        []string{"my-app", "-m=lots"},
+			ExpectedErr: errors.New(`memory: invalid size "lots", use a number followed by M or G e.g. 512M`),
+		},
+		"instances over the space's quota": {
+			Namespace: "default",
+			Args:      []string{"my-app", "-i=5"},
+			Space: func() *v1alpha1.Space {
+				space := &v1alpha1.Space{}
+				space.Spec.ResourceLimits.SpaceQuota = corev1.ResourceList{
+					corev1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+				}
+				return space
+			}(),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						app := v1alpha1.App{}
+						app.Spec.Template.Spec.Containers = []corev1.Container{{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceMemory: resource.MustParse("1Gi"),
+								},
+							},
+						}}
+						testutil.AssertErrorsEqual(
+							t,
+							errors.New("5 instances with requests.memory of 1Gi need 5Gi, more than the space's quota of 4Gi"),
+							m(&app),
+						)
+					})
+			},
+		},
+		"scaling down over the space's quota": {
+			Namespace: "default",
+			Args:      []string{"my-app", "-i=5"},
+			Space: func() *v1alpha1.Space {
+				space := &v1alpha1.Space{}
+				space.Spec.ResourceLimits.SpaceQuota = corev1.ResourceList{
+					corev1.ResourceRequestsMemory: resource.MustParse("4Gi"),
+				}
+				return space
+			}(),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						exactly := 8
+						app := v1alpha1.App{}
+						app.Spec.Instances.Exactly = &exactly
+						app.Spec.Template.Spec.Containers = []corev1.Container{{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceMemory: resource.MustParse("1Gi"),
+								},
+							},
+						}}
+						testutil.AssertNil(t, "mutator error", m(&app))
+						testutil.AssertEqual(t, "app.spec.instances.exactly", 5, *app.Spec.Instances.Exactly)
+					})
+			},
+		},
+		"scaling down below the space's minimum": {
+			Namespace: "default",
+			Args:      []string{"my-app", "-m=4M"},
+			Space: func() *v1alpha1.Space {
+				space := &v1alpha1.Space{}
+				space.Spec.ResourceLimits.ResourceDefaults = []corev1.LimitRangeItem{{
+					Type: corev1.LimitTypeContainer,
+					Min: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("64Mi"),
+					},
+				}}
+				return space
+			}(),
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().
+					Transform("default", "my-app", gomock.Any()).
+					Do(func(_, _ string, m apps.Mutator) {
+						app := v1alpha1.App{}
+						app.Spec.Template.Spec.Containers = []corev1.Container{{
+							Resources: corev1.ResourceRequirements{
+								Requests: corev1.ResourceList{
+									corev1.ResourceMemory: resource.MustParse("1Gi"),
+								},
+								Limits: corev1.ResourceList{
+									corev1.ResourceMemory: resource.MustParse("1Gi"),
+								},
+							},
+						}}
+						testutil.AssertErrorsEqual(
+							t,
+							errors.New("memory request of 4Mi is less than the space's minimum of 64Mi"),
+							m(&app),
+						)
+					})
+			},
+		},
 		"updating app fails": {
 			Namespace:   "default",
 			Args:        []string{"my-app", "-i=3"},
@@ -159,8 +298,14 @@ func TestNewScaleCommand(t *testing.T) {
 			}
 
 			buf := new(bytes.Buffer)
+			space := tc.Space
+			if space == nil {
+				space = &v1alpha1.Space{}
+			}
+
 			p := &config.KfParams{
-				Namespace: tc.Namespace,
+				Namespace:   tc.Namespace,
+				TargetSpace: space,
 			}
 
 			cmd := NewScaleCommand(p, fake)
@@ -0,0 +1,55 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builds
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/sources"
+	"github.com/spf13/cobra"
+)
+
+// NewCancelBuildCommand allows users to stop a running build.
+func NewCancelBuildCommand(p *config.KfParams, client sources.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cancel-build BUILD_NAME",
+		Short: "Stop a running build",
+		Long: `
+	Cancelling a build stops it and marks it as failed. The app keeps running
+	the image of its last successful build. Push or restage the app to build
+	it again.`,
+		Example: `  kf cancel-build myapp-source-7f3e2`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			buildName := args[0]
+			if err := client.Transform(p.Namespace, buildName, sources.CancelBuild); err != nil {
+				return fmt.Errorf("failed to cancel build: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Cancelling build %q\n", buildName)
+			return nil
+		},
+	}
+
+	return cmd
+}
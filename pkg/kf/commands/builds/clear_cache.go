@@ -0,0 +1,79 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builds
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1 "k8s.io/client-go/kubernetes/typed/core/v1"
+)
+
+// NewClearBuildCacheCommand allows users to clear the build cache of an app.
+func NewClearBuildCacheCommand(p *config.KfParams, appsClient apps.Client, coreClient corev1.CoreV1Interface) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "clear-build-cache APP_NAME",
+		Short: "Remove the cached dependencies of an app's builds",
+		Long: `
+	Buildpack builds of an app share a cache so dependencies aren't downloaded
+	for each build. Clearing it makes the next build start from scratch, which
+	can fix builds that fail because of a bad cache.`,
+		Example: `  kf clear-build-cache myapp`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			appName := args[0]
+			app, err := appsClient.Get(p.Namespace, appName)
+			if err != nil {
+				return fmt.Errorf("failed to get app: %s", err)
+			}
+
+			claims := coreClient.PersistentVolumeClaims(p.Namespace)
+			list, err := claims.List(metav1.ListOptions{
+				LabelSelector: labels.Set(app.BuildCacheLabels()).String(),
+			})
+			if err != nil {
+				return fmt.Errorf("failed to get build cache: %s", err)
+			}
+
+			if len(list.Items) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "App %q has no build cache\n", appName)
+				return nil
+			}
+
+			// The cache is recreated empty once it's no longer in use.
+			for _, claim := range list.Items {
+				if err := claims.Delete(claim.Name, &metav1.DeleteOptions{}); err != nil {
+					return fmt.Errorf("failed to clear build cache: %s", err)
+				}
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Cleared the build cache of app %q\n", appName)
+			return nil
+		},
+	}
+
+	return cmd
+}
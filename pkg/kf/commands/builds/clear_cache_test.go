@@ -0,0 +1,137 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package builds
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	appsfake "github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	testclient "k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewClearBuildCacheCommand(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+
+	otherApp := &v1alpha1.App{}
+	otherApp.Name = "other-app"
+
+	makeClaim := func(name string, app *v1alpha1.App) *corev1.PersistentVolumeClaim {
+		return &corev1.PersistentVolumeClaim{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "my-ns",
+				Labels:    app.BuildCacheLabels(),
+			},
+		}
+	}
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		claims    []runtime.Object
+		setup     func(t *testing.T, fakeApps *appsfake.FakeClient)
+
+		wantErr         error
+		wantClaims      []string
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"missing namespace": {
+			args:    []string{"my-app"},
+			wantErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		"app doesn't exist": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().Get("my-ns", "my-app").Return(nil, errors.New("not found"))
+			},
+			wantErr: errors.New("failed to get app: not found"),
+		},
+		"no cache": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			claims:    []runtime.Object{makeClaim("other-cache", otherApp)},
+			setup: func(t *testing.T, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().Get("my-ns", "my-app").Return(app, nil)
+			},
+			wantClaims:      []string{"other-cache"},
+			expectedStrings: []string{`App "my-app" has no build cache`},
+		},
+		"clears cache": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			claims: []runtime.Object{
+				makeClaim("my-cache", app),
+				makeClaim("other-cache", otherApp),
+			},
+			setup: func(t *testing.T, fakeApps *appsfake.FakeClient) {
+				fakeApps.EXPECT().Get("my-ns", "my-app").Return(app, nil)
+			},
+			wantClaims:      []string{"other-cache"},
+			expectedStrings: []string{`Cleared the build cache of app "my-app"`},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeApps := appsfake.NewFakeClient(ctrl)
+			k8s := testclient.NewSimpleClientset(tc.claims...)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeApps)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewClearBuildCacheCommand(&config.KfParams{Namespace: tc.namespace}, fakeApps, k8s.CoreV1())
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			if tc.wantErr == nil {
+				list, err := k8s.CoreV1().PersistentVolumeClaims("my-ns").List(metav1.ListOptions{})
+				testutil.AssertNil(t, "list err", err)
+
+				var remaining []string
+				for _, claim := range list.Items {
+					remaining = append(remaining, claim.Name)
+				}
+				testutil.AssertEqual(t, "remaining claims", tc.wantClaims, remaining)
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -17,6 +17,7 @@ package builds
 import (
 	"fmt"
 	"text/tabwriter"
+	"time"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/commands/config"
@@ -24,6 +25,7 @@ import (
 	"github.com/google/kf/pkg/kf/sources"
 	"github.com/spf13/cobra"
 	"k8s.io/apimachinery/pkg/api/meta/table"
+	"k8s.io/apimachinery/pkg/util/duration"
 )
 
 // NewListBuildsCommand allows users to list spaces.
@@ -48,7 +50,7 @@ func NewListBuildsCommand(p *config.KfParams, client sources.Client) *cobra.Comm
 			defer w.Flush()
 
 			// Status is important here as spaces may be in a deleting status.
-			fmt.Fprintln(w, "Name\tAge\tReady\tReason\tImage")
+			fmt.Fprintln(w, "Name\tAge\tDuration\tReady\tReason\tImage\tCache\tFailure")
 			for _, source := range list {
 				ready := ""
 				reason := ""
@@ -57,12 +59,15 @@ func NewListBuildsCommand(p *config.KfParams, client sources.Client) *cobra.Comm
 					reason = cond.Reason
 				}
 
-				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s",
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s",
 					source.Name,
 					table.ConvertToHumanReadableDateType(source.CreationTimestamp),
+					buildDuration(source.Status),
 					ready,
 					reason,
 					source.Status.Image,
+					source.Spec.BuildpackBuild.CacheClaim,
+					buildFailure(source.Status),
 				)
 				fmt.Fprintln(w)
 			}
@@ -73,3 +78,32 @@ func NewListBuildsCommand(p *config.KfParams, client sources.Client) *cobra.Comm
 
 	return cmd
 }
+
+// buildDuration returns how long the latest build took, or has been running
+// for if it hasn't finished.
+func buildDuration(status v1alpha1.SourceStatus) string {
+	if status.StartTime == nil {
+		return ""
+	}
+
+	end := time.Now()
+	if status.CompletionTime != nil {
+		end = status.CompletionTime.Time
+	}
+
+	return duration.HumanDuration(end.Sub(status.StartTime.Time))
+}
+
+// buildFailure returns the step that failed the latest build and why.
+func buildFailure(status v1alpha1.SourceStatus) string {
+	step := status.FailedStep()
+	if step == nil {
+		return ""
+	}
+
+	if msg := step.FailureMessage(); msg != "" {
+		return fmt.Sprintf("%s: %s", step.Name, msg)
+	}
+
+	return fmt.Sprintf("%s: exited with code %d", step.Name, step.Terminated.ExitCode)
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/sources/fake"
 	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -55,7 +57,7 @@ func TestNewListBuildsCommand(t *testing.T) {
 					List("my-ns").
 					Return(list, nil)
 			},
-			expectedStrings: []string{"Name", "Age", "Ready", "Reason"},
+			expectedStrings: []string{"Name", "Age", "Duration", "Ready", "Reason", "Image", "Cache", "Failure"},
 		},
 		"contents": {
 			namespace: "my-ns",
@@ -68,6 +70,7 @@ func TestNewListBuildsCommand(t *testing.T) {
 					Reason: "SomeMessage",
 				}}
 				bld.Status.Image = "gcr.io/my-image"
+				bld.Spec.BuildpackBuild.CacheClaim = "my-app-build-cache"
 
 				list := []v1alpha1.Source{bld}
 				fakeSources.
@@ -75,7 +78,42 @@ func TestNewListBuildsCommand(t *testing.T) {
 					List("my-ns").
 					Return(list, nil)
 			},
-			expectedStrings: []string{"my-build", "TESTING", "SomeMessage", "gcr.io/my-image"},
+			expectedStrings: []string{"my-build", "TESTING", "SomeMessage", "gcr.io/my-image", "my-app-build-cache"},
+		},
+		"failed build": {
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeSources *fake.FakeClient) {
+				startTime := metav1.Unix(1000, 0)
+				completionTime := metav1.Unix(1090, 0)
+
+				bld := v1alpha1.Source{}
+				bld.Name = "my-build"
+				bld.Status.StartTime = &startTime
+				bld.Status.CompletionTime = &completionTime
+				bld.Status.Steps = []v1alpha1.SourceStatusStep{
+					{
+						Name: "detect",
+						ContainerState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{ExitCode: 0},
+						},
+					},
+					{
+						Name: "build",
+						ContainerState: corev1.ContainerState{
+							Terminated: &corev1.ContainerStateTerminated{
+								ExitCode: 1,
+								Message:  "npm ERR! missing script: start",
+							},
+						},
+					},
+				}
+
+				fakeSources.
+					EXPECT().
+					List("my-ns").
+					Return([]v1alpha1.Source{bld}, nil)
+			},
+			expectedStrings: []string{"my-build", "90s", "build: npm ERR! missing script: start"},
 		},
 		"server failure": {
 			namespace: "my-ns",
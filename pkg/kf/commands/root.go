@@ -69,11 +69,25 @@ func NewKfCommand() *cobra.Command {
 				InjectStop(p),
 				InjectRestart(p),
 				InjectRestage(p),
+				InjectAppHistory(p),
+				InjectPromote(p),
+				InjectRollback(p),
 				InjectScale(p),
 				InjectLogs(p),
 				InjectProxy(p),
 			},
 		},
+		{
+			Message: "Tasks",
+			Commands: []*cobra.Command{
+				InjectRunTask(p),
+				InjectTasks(p),
+				InjectTerminateTask(p),
+				InjectCreateJob(p),
+				InjectJobs(p),
+				InjectDeleteJob(p),
+			},
+		},
 		{
 			Message: "Environment Variables",
 			Commands: []*cobra.Command{
@@ -97,6 +111,9 @@ func NewKfCommand() *cobra.Command {
 				InjectDeleteRoute(p),
 				InjectMapRoute(p),
 				InjectUnmapRoute(p),
+				InjectAddNetworkPolicy(p),
+				InjectRemoveNetworkPolicy(p),
+				InjectNetworkPolicies(p),
 			},
 		},
 		{
@@ -142,6 +159,8 @@ func NewKfCommand() *cobra.Command {
 			Commands: []*cobra.Command{
 				InjectBuilds(p),
 				InjectBuildLogs(p),
+				InjectCancelBuild(p),
+				InjectClearBuildCache(p),
 			},
 		},
 		{
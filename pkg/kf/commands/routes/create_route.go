@@ -32,16 +32,29 @@ func NewCreateRouteCommand(
 	p *config.KfParams,
 	c routes.Client,
 ) *cobra.Command {
-	var hostname, urlPath string
+	var (
+		hostname, urlPath string
+		internal          bool
+		exactPath         bool
+		port              int32
+		policies          policyFlags
+	)
 
 	cmd := &cobra.Command{
-		Use:   "create-route DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "create-route DOMAIN [--hostname HOSTNAME] [--path PATH] [--exact-path] [--internal] [--port PORT] [--timeout TIMEOUT] [--retries RETRIES]",
 		Short: "Create a route",
 		Example: `
   # Using namespace (instead of SPACE)
   kf create-route example.com --hostname myapp # myapp.example.com
   kf create-route --namespace myspace example.com --hostname myapp # myapp.example.com
   kf create-route example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+  kf create-route example.com --hostname myapp --path /mypath --exact-path # only myapp.example.com/mypath, not paths below it
+  kf create-route example.com --hostname '*' # *.example.com, subdomains without a more specific route
+  kf create-route apps.internal --hostname myapp --internal # myapp.apps.internal, only reachable from other Apps
+  kf create-route tcp.example.com --port 1024 # tcp.example.com:1024, forwards TCP connections
+  kf create-route example.com --hostname myapp --timeout 30s --retries 2 --retry-timeout 10s
+  kf create-route example.com --hostname myapp --remove-request-header X-Forwarded-Host --set-response-header X-Frame-Options=DENY
+  kf create-route example.com --hostname myapp --allow-source-cidr 10.0.0.0/8 --deny-source-cidr 10.1.0.0/16
 
   # [DEPRECATED] Using SPACE to match 'cf'
   kf create-route myspace example.com --hostname myapp # myapp.example.com
@@ -66,13 +79,33 @@ Instead use the --namespace flag.`)
 				return fmt.Errorf("SPACE (argument=%q) and namespace (flag=%q) (if provided) must match", space, p.Namespace)
 			}
 
-			if hostname == "" {
+			if port != 0 {
+				// TCP routes match every connection on the port.
+				if hostname != "" || urlPath != "" {
+					return errors.New("--hostname and --path can't be used with --port")
+				}
+
+				if exactPath {
+					return errors.New("--exact-path can't be used with --port")
+				}
+			} else if hostname == "" {
 				return errors.New("--hostname is required")
+			} else {
+				urlPath = path.Join("/", urlPath)
 			}
 
 			cmd.SilenceUsage = true
 
-			urlPath = path.Join("/", urlPath)
+			fields := v1alpha1.RouteSpecFields{
+				Hostname: hostname,
+				Domain:   domain,
+				Path:     urlPath,
+				Internal: internal,
+				Port:     port,
+			}
+			if exactPath {
+				fields.PathMatch = v1alpha1.PathMatchExact
+			}
 
 			r := &v1alpha1.Route{
 				TypeMeta: metav1.TypeMeta{
@@ -80,21 +113,17 @@ Instead use the --namespace flag.`)
 				},
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: space,
-					Name: v1alpha1.GenerateRouteName(
-						hostname,
-						domain,
-						urlPath,
-					),
+					Name:      v1alpha1.GenerateRouteNameFromSpec(fields),
 				},
 				Spec: v1alpha1.RouteSpec{
-					RouteSpecFields: v1alpha1.RouteSpecFields{
-						Hostname: hostname,
-						Domain:   domain,
-						Path:     urlPath,
-					},
+					RouteSpecFields: fields,
 				},
 			}
 
+			if err := policies.Apply(cmd.Flags(), &r.Spec); err != nil {
+				return err
+			}
+
 			if _, err := c.Create(space, r); err != nil {
 				return fmt.Errorf("failed to create Route: %s", err)
 			}
@@ -114,6 +143,26 @@ Instead use the --namespace flag.`)
 		"",
 		"URL Path for the route",
 	)
+	cmd.Flags().BoolVar(
+		&internal,
+		"internal",
+		false,
+		"Only allow traffic to the route from Apps in the cluster",
+	)
+	cmd.Flags().Int32Var(
+		&port,
+		"port",
+		0,
+		"Port reserved on the domain for a TCP route",
+	)
+	cmd.Flags().BoolVar(
+		&exactPath,
+		"exact-path",
+		false,
+		"Only match the path itself rather than every path below it",
+	)
+
+	policies.Add(cmd.Flags())
 
 	return cmd
 }
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -147,6 +148,166 @@ func TestCreateRoute(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"creates internal route": {
+			Args:      []string{"apps.internal", "--hostname=some-hostname", "--internal"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().Create(gomock.Any(),
+					&v1alpha1.Route{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "Route",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "some-space",
+							Name:      v1alpha1.GenerateRouteName("some-hostname", "apps.internal", "/"),
+						},
+						Spec: v1alpha1.RouteSpec{
+							RouteSpecFields: v1alpha1.RouteSpecFields{
+								Hostname: "some-hostname",
+								Domain:   "apps.internal",
+								Path:     "/",
+								Internal: true,
+							},
+						},
+					},
+				)
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"creates tcp route": {
+			Args:      []string{"tcp.example.com", "--port=1024"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().Create(gomock.Any(),
+					&v1alpha1.Route{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "Route",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "some-space",
+							Name:      v1alpha1.GenerateTCPRouteName("tcp.example.com", 1024),
+						},
+						Spec: v1alpha1.RouteSpec{
+							RouteSpecFields: v1alpha1.RouteSpecFields{
+								Domain: "tcp.example.com",
+								Port:   1024,
+							},
+						},
+					},
+				)
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"creates exact path route": {
+			Args:      []string{"example.com", "--hostname=some-hostname", "--path=some-path", "--exact-path"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				fields := v1alpha1.RouteSpecFields{
+					Hostname:  "some-hostname",
+					Domain:    "example.com",
+					Path:      "/some-path",
+					PathMatch: v1alpha1.PathMatchExact,
+				}
+				routesfake.EXPECT().Create(gomock.Any(),
+					&v1alpha1.Route{
+						TypeMeta: metav1.TypeMeta{
+							Kind: "Route",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Namespace: "some-space",
+							Name:      v1alpha1.GenerateRouteNameFromSpec(fields),
+						},
+						Spec: v1alpha1.RouteSpec{
+							RouteSpecFields: fields,
+						},
+					},
+				)
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"creates route with policies": {
+			Args: []string{
+				"example.com",
+				"--hostname=some-hostname",
+				"--timeout=30s",
+				"--retries=2",
+				"--retry-timeout=5s",
+				"--remove-request-header=X-Forwarded-Host",
+				"--set-response-header=Strict-Transport-Security=max-age=31536000",
+			},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().Create(gomock.Any(), gomock.Any()).Do(func(_ string, r *v1alpha1.Route) {
+					testutil.AssertEqual(t, "timeout", &metav1.Duration{Duration: 30 * time.Second}, r.Spec.Timeout)
+					testutil.AssertEqual(t, "retries", &v1alpha1.RouteSpecRetries{
+						Attempts:      2,
+						PerTryTimeout: &metav1.Duration{Duration: 5 * time.Second},
+					}, r.Spec.Retries)
+					testutil.AssertEqual(t, "headers", &v1alpha1.RouteSpecHeaders{
+						Request: &v1alpha1.RouteSpecHeaderOperations{
+							Remove: []string{"X-Forwarded-Host"},
+						},
+						Response: &v1alpha1.RouteSpecHeaderOperations{
+							Set: map[string]string{"Strict-Transport-Security": "max-age=31536000"},
+						},
+					}, r.Spec.Headers)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"creates route with access policy": {
+			Args: []string{
+				"example.com",
+				"--hostname=some-hostname",
+				"--allow-source-cidr=10.0.0.0/8",
+				"--allow-source-cidr=192.168.0.0/16",
+				"--deny-source-cidr=10.1.0.0/16",
+				"--requests-per-second=50",
+			},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient) {
+				routesfake.EXPECT().Create(gomock.Any(), gomock.Any()).Do(func(_ string, r *v1alpha1.Route) {
+					testutil.AssertEqual(t, "accessPolicy", &v1alpha1.RouteSpecAccessPolicy{
+						AllowedSourceCIDRs: []string{"10.0.0.0/8", "192.168.0.0/16"},
+						DeniedSourceCIDRs:  []string{"10.1.0.0/16"},
+						RequestsPerSecond:  50,
+					}, r.Spec.AccessPolicy)
+					testutil.AssertEqual(t, "headers", (*v1alpha1.RouteSpecHeaders)(nil), r.Spec.Headers)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"malformed header": {
+			Args:      []string{"example.com", "--hostname=some-hostname", "--set-request-header=X-Some-Header"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("malformed header: X-Some-Header"), err)
+			},
+		},
+		"tcp route with exact path": {
+			Args:      []string{"tcp.example.com", "--port=1024", "--exact-path"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("--exact-path can't be used with --port"), err)
+			},
+		},
+		"tcp route with hostname": {
+			Args:      []string{"tcp.example.com", "--port=1024", "--hostname=some-hostname"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("--hostname and --path can't be used with --port"), err)
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -16,9 +16,7 @@ package routes
 
 import (
 	"fmt"
-	"path"
 
-	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	"github.com/google/kf/pkg/kf/routes"
@@ -30,14 +28,20 @@ func NewDeleteRouteCommand(
 	p *config.KfParams,
 	c routes.Client,
 ) *cobra.Command {
-	var hostname, urlPath string
+	var (
+		hostname, urlPath string
+		exactPath         bool
+		port              int32
+	)
 
 	cmd := &cobra.Command{
-		Use:   "delete-route DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "delete-route DOMAIN [--hostname HOSTNAME] [--path PATH] [--exact-path] [--port PORT]",
 		Short: "Delete a route",
 		Example: `
   kf delete-route example.com --hostname myapp # myapp.example.com
   kf delete-route example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+  kf delete-route example.com --hostname myapp --path /mypath --exact-path # myapp.example.com/mypath
+  kf delete-route tcp.example.com --port 1024 # tcp.example.com:1024
   `,
 		Args: cobra.ExactArgs(1),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -49,7 +53,7 @@ func NewDeleteRouteCommand(
 			cmd.SilenceUsage = true
 			if err := c.Delete(
 				p.Namespace,
-				v1alpha1.GenerateRouteName(hostname, domain, path.Join("/", urlPath)),
+				routeName(hostname, domain, urlPath, port, exactPath),
 			); err != nil {
 				return fmt.Errorf("failed to delete Route: %s", err)
 			}
@@ -69,6 +73,18 @@ func NewDeleteRouteCommand(
 		"",
 		"URL Path for the route",
 	)
+	cmd.Flags().Int32Var(
+		&port,
+		"port",
+		0,
+		"Port of a TCP route",
+	)
+	cmd.Flags().BoolVar(
+		&exactPath,
+		"exact-path",
+		false,
+		"Only match the path itself rather than every path below it",
+	)
 
 	return cmd
 }
@@ -86,6 +86,37 @@ func TestDeleteRoute(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"delete exact path route": {
+			Args:      []string{"example.com", "--hostname=some-hostname", "--path=some-path", "--exact-path"},
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Delete(
+					gomock.Any(),
+					v1alpha1.GenerateRouteNameFromSpec(v1alpha1.RouteSpecFields{
+						Hostname:  "some-hostname",
+						Domain:    "example.com",
+						Path:      "/some-path",
+						PathMatch: v1alpha1.PathMatchExact,
+					}),
+				)
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"delete tcp route": {
+			Args:      []string{"tcp.example.com", "--port=1024"},
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Delete(
+					gomock.Any(),
+					v1alpha1.GenerateTCPRouteName("tcp.example.com", 1024),
+				)
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
@@ -33,15 +33,26 @@ func NewMapRouteCommand(
 	routesClient routes.Client,
 	appsClient apps.Client,
 ) *cobra.Command {
-	var hostname, urlPath string
+	var (
+		hostname, urlPath string
+		weight            int
+		exactPath         bool
+		port              int32
+		policies          policyFlags
+	)
 
 	cmd := &cobra.Command{
-		Use:   "map-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "map-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH] [--exact-path] [--port PORT] [--weight WEIGHT] [--timeout TIMEOUT] [--retries RETRIES]",
 		Short: "Map a route to an app",
 		Example: `
   kf map-route myapp example.com --hostname myapp # myapp.example.com
   kf map-route --namespace myspace myapp example.com --hostname myapp # myapp.example.com
   kf map-route myapp example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+  kf map-route myapp example.com --hostname myapp --path /mypath --exact-path # only myapp.example.com/mypath
+  kf map-route myapp example.com --hostname '*' # *.example.com
+  kf map-route myapp-v2 example.com --hostname myapp --weight 10 # send myapp-v2 a share of myapp.example.com
+  kf map-route mybroker tcp.example.com --port 1024 # tcp.example.com:1024
+  kf map-route myapp example.com --hostname myapp --timeout 30s --set-response-header X-Frame-Options=DENY
   `,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -52,6 +63,11 @@ func NewMapRouteCommand(
 			}
 			appName, domain := args[0], args[1]
 
+			setWeight := cmd.Flags().Changed("weight")
+			if setWeight && weight < 0 {
+				return fmt.Errorf("weight must be >= 0, got: %d", weight)
+			}
+
 			_, err := appsClient.Get(p.Namespace, appName)
 			if err != nil {
 				return fmt.Errorf("failed to fetch app: %s", err)
@@ -60,31 +76,57 @@ func NewMapRouteCommand(
 			merger := routes.Merger(func(newR, oldR *v1alpha1.Route) *v1alpha1.Route {
 				newR.ObjectMeta = *oldR.ObjectMeta.DeepCopy()
 				newR.Spec.AppNames = append(oldR.Spec.AppNames, appName)
+				newR.Spec.AppWeights = oldR.Spec.AppWeights
+				if setWeight {
+					if newR.Spec.AppWeights == nil {
+						newR.Spec.AppWeights = make(map[string]int)
+					}
+					newR.Spec.AppWeights[appName] = weight
+				}
+
+				// Policies that weren't given keep their existing values.
+				// The flags already applied cleanly to r below, so they
+				// can't fail here.
+				spec := oldR.Spec.DeepCopy()
+				policies.Apply(cmd.Flags(), spec)
+				newR.Spec.Timeout = spec.Timeout
+				newR.Spec.Retries = spec.Retries
+				newR.Spec.Headers = spec.Headers
 				return newR
 			})
 
-			urlPath = path.Join("/", urlPath)
+			fields := v1alpha1.RouteSpecFields{
+				Hostname: hostname,
+				Domain:   domain,
+				Port:     port,
+			}
+			if !fields.IsTCP() {
+				fields.Path = path.Join("/", urlPath)
+			}
+			if exactPath {
+				fields.PathMatch = v1alpha1.PathMatchExact
+			}
+
 			r := &v1alpha1.Route{
 				TypeMeta: metav1.TypeMeta{
 					Kind: "Route",
 				},
 				ObjectMeta: metav1.ObjectMeta{
 					Namespace: p.Namespace,
-					Name: v1alpha1.GenerateRouteName(
-						hostname,
-						domain,
-						urlPath,
-					),
+					Name:      v1alpha1.GenerateRouteNameFromSpec(fields),
 				},
 				Spec: v1alpha1.RouteSpec{
-					AppNames: []string{appName},
-					RouteSpecFields: v1alpha1.RouteSpecFields{
-						Hostname: hostname,
-						Domain:   domain,
-						Path:     urlPath,
-					},
+					AppNames:        []string{appName},
+					RouteSpecFields: fields,
 				},
 			}
+			if setWeight {
+				r.Spec.AppWeights = map[string]int{appName: weight}
+			}
+
+			if err := policies.Apply(cmd.Flags(), &r.Spec); err != nil {
+				return err
+			}
 
 			if _, err := routesClient.Upsert(p.Namespace, r, merger); err != nil {
 				return fmt.Errorf("failed to map Route: %s", err)
@@ -105,6 +147,26 @@ func NewMapRouteCommand(
 		"",
 		"URL Path for the route",
 	)
+	cmd.Flags().Int32Var(
+		&port,
+		"port",
+		0,
+		"Port of a TCP route",
+	)
+	cmd.Flags().BoolVar(
+		&exactPath,
+		"exact-path",
+		false,
+		"Only match the path itself rather than every path below it",
+	)
+	cmd.Flags().IntVar(
+		&weight,
+		"weight",
+		v1alpha1.DefaultRouteWeight,
+		"Relative amount of the route's traffic the app receives when multiple apps are mapped",
+	)
+
+	policies.Add(cmd.Flags())
 
 	return cmd
 }
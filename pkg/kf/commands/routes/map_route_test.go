@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -136,6 +137,116 @@ func TestMapRoute(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"tcp Route": {
+			Args:      []string{"some-app", "tcp.example.com", "--port=1024"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&v1alpha1.App{}, nil)
+				routesfake.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, newR *v1alpha1.Route, m clientroutes.Merger) {
+					testutil.AssertEqual(t, "name", v1alpha1.GenerateTCPRouteName("tcp.example.com", 1024), newR.Name)
+					testutil.AssertEqual(t, "Spec.RouteSpecFields", v1alpha1.RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					}, newR.Spec.RouteSpecFields)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"negative weight": {
+			Args:      []string{"some-app", "example.com", "--weight=-1"},
+			Namespace: "some-space",
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertErrorsEqual(t, errors.New("weight must be >= 0, got: -1"), err)
+			},
+		},
+		"sets weight": {
+			Args:      []string{"some-app", "example.com", "--hostname=some-host", "--weight=10"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&v1alpha1.App{}, nil)
+				routesfake.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, newR *v1alpha1.Route, m clientroutes.Merger) {
+					testutil.AssertEqual(t, "new weights", map[string]int{"some-app": 10}, newR.Spec.AppWeights)
+
+					oldR := v1alpha1.Route{
+						Spec: v1alpha1.RouteSpec{
+							AppNames:   []string{"some-other-app"},
+							AppWeights: map[string]int{"some-other-app": 90},
+						},
+					}
+					m(newR, &oldR)
+					testutil.AssertEqual(t, "names", []string{"some-other-app", "some-app"}, newR.Spec.AppNames)
+					testutil.AssertEqual(t, "merged weights", map[string]int{"some-other-app": 90, "some-app": 10}, newR.Spec.AppWeights)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"keeps existing weights": {
+			Args:      []string{"some-app", "example.com", "--hostname=some-host"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&v1alpha1.App{}, nil)
+				routesfake.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, newR *v1alpha1.Route, m clientroutes.Merger) {
+					testutil.AssertEqual(t, "new weights", map[string]int(nil), newR.Spec.AppWeights)
+
+					oldR := v1alpha1.Route{
+						Spec: v1alpha1.RouteSpec{
+							AppNames:   []string{"some-app"},
+							AppWeights: map[string]int{"some-app": 5},
+						},
+					}
+					m(newR, &oldR)
+					testutil.AssertEqual(t, "merged weights", map[string]int{"some-app": 5}, newR.Spec.AppWeights)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
+		"keeps existing policies": {
+			Args:      []string{"some-app", "example.com", "--hostname=some-host", "--retries=5", "--remove-response-header=Server"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, routesfake *routesfake.FakeClient, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().Get(gomock.Any(), gomock.Any()).Return(&v1alpha1.App{}, nil)
+				routesfake.EXPECT().Upsert(gomock.Any(), gomock.Any(), gomock.Any()).Do(func(_ string, newR *v1alpha1.Route, m clientroutes.Merger) {
+					testutil.AssertEqual(t, "new retries", &v1alpha1.RouteSpecRetries{Attempts: 5}, newR.Spec.Retries)
+
+					oldR := v1alpha1.Route{
+						Spec: v1alpha1.RouteSpec{
+							AppNames: []string{"some-other-app"},
+							Timeout:  &metav1.Duration{Duration: time.Minute},
+							Retries: &v1alpha1.RouteSpecRetries{
+								Attempts:      1,
+								PerTryTimeout: &metav1.Duration{Duration: time.Second},
+							},
+							Headers: &v1alpha1.RouteSpecHeaders{
+								Response: &v1alpha1.RouteSpecHeaderOperations{
+									Remove: []string{"X-Powered-By"},
+								},
+							},
+						},
+					}
+					m(newR, &oldR)
+					testutil.AssertEqual(t, "merged timeout", &metav1.Duration{Duration: time.Minute}, newR.Spec.Timeout)
+					testutil.AssertEqual(t, "merged retries", &v1alpha1.RouteSpecRetries{
+						Attempts:      5,
+						PerTryTimeout: &metav1.Duration{Duration: time.Second},
+					}, newR.Spec.Retries)
+					testutil.AssertEqual(t, "merged headers", &v1alpha1.RouteSpecHeaders{
+						Response: &v1alpha1.RouteSpecHeaderOperations{
+							Remove: []string{"Server", "X-Powered-By"},
+						},
+					}, newR.Spec.Headers)
+					testutil.AssertEqual(t, "old headers", []string{"X-Powered-By"}, oldR.Spec.Headers.Response.Remove)
+				})
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
 		"don't re-add app": {
 			Args:      []string{"some-app", "example.com", "--hostname=some-host", "--path=some-path"},
 			Namespace: "some-space",
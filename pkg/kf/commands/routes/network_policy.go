@@ -0,0 +1,191 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"errors"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/algorithms"
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/spf13/cobra"
+)
+
+// NewAddNetworkPolicyCommand creates a command that allows an App to reach
+// another App's internal routes.
+func NewAddNetworkPolicyCommand(
+	p *config.KfParams,
+	appsClient apps.Client,
+) *cobra.Command {
+	var destinationApp string
+
+	cmd := &cobra.Command{
+		Use:   "add-network-policy SOURCE_APP --destination-app DESTINATION_APP",
+		Short: "Allow an app to reach another app on its internal routes",
+		Long: `
+  Once an app has a network policy, its internal routes only accept traffic
+  from the apps listed in its policies. Public routes aren't affected.
+  `,
+		Example: `
+  kf add-network-policy frontend --destination-app backend
+  `,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			if destinationApp == "" {
+				return errors.New("--destination-app is required")
+			}
+
+			sourceApp := args[0]
+
+			cmd.SilenceUsage = true
+
+			mutator := func(app *v1alpha1.App) error {
+				policy := &app.Spec.NetworkPolicy
+				policy.AllowedSourceApps = []string(algorithms.Merge(
+					algorithms.Strings(policy.AllowedSourceApps),
+					algorithms.Strings{sourceApp},
+				).(algorithms.Strings))
+				return nil
+			}
+
+			if err := appsClient.Transform(p.Namespace, destinationApp, mutator); err != nil {
+				return fmt.Errorf("failed to add network policy: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Allowing traffic from %s to %s\n", sourceApp, destinationApp)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&destinationApp,
+		"destination-app",
+		"",
+		"App that receives the traffic",
+	)
+
+	return cmd
+}
+
+// NewRemoveNetworkPolicyCommand creates a command that stops an App from
+// reaching another App's internal routes.
+func NewRemoveNetworkPolicyCommand(
+	p *config.KfParams,
+	appsClient apps.Client,
+) *cobra.Command {
+	var destinationApp string
+
+	cmd := &cobra.Command{
+		Use:   "remove-network-policy SOURCE_APP --destination-app DESTINATION_APP",
+		Short: "Stop an app from reaching another app on its internal routes",
+		Example: `
+  kf remove-network-policy frontend --destination-app backend
+  `,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			if destinationApp == "" {
+				return errors.New("--destination-app is required")
+			}
+
+			sourceApp := args[0]
+
+			cmd.SilenceUsage = true
+
+			mutator := func(app *v1alpha1.App) error {
+				policy := &app.Spec.NetworkPolicy
+				policy.AllowedSourceApps = []string(algorithms.Delete(
+					algorithms.Strings(policy.AllowedSourceApps),
+					algorithms.Strings{sourceApp},
+				).(algorithms.Strings))
+				if len(policy.AllowedSourceApps) == 0 {
+					policy.AllowedSourceApps = nil
+				}
+				return nil
+			}
+
+			if err := appsClient.Transform(p.Namespace, destinationApp, mutator); err != nil {
+				return fmt.Errorf("failed to remove network policy: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Removed traffic from %s to %s\n", sourceApp, destinationApp)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&destinationApp,
+		"destination-app",
+		"",
+		"App that receives the traffic",
+	)
+
+	return cmd
+}
+
+// NewNetworkPoliciesCommand creates a command that lists the network
+// policies in a space.
+func NewNetworkPoliciesCommand(
+	p *config.KfParams,
+	appsClient apps.Client,
+) *cobra.Command {
+	return &cobra.Command{
+		Use:   "network-policies",
+		Short: "List network policies in space",
+		Example: `
+  kf network-policies
+  `,
+		Args: cobra.ExactArgs(0),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Getting network policies in namespace: %s\n", p.Namespace)
+
+			appList, err := appsClient.List(p.Namespace)
+			if err != nil {
+				return fmt.Errorf("failed to fetch Apps: %s", err)
+			}
+
+			fmt.Fprintln(cmd.OutOrStdout())
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 8, 4, 2, ' ', tabwriter.StripEscape)
+			fmt.Fprintln(w, "SOURCE\tDESTINATION")
+			for _, app := range appList {
+				for _, source := range app.Spec.NetworkPolicy.AllowedSourceApps {
+					fmt.Fprintf(w, "%s\t%s\n", source, app.Name)
+				}
+			}
+
+			w.Flush()
+
+			return nil
+		},
+	}
+}
@@ -0,0 +1,169 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes_test
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps"
+	appsfake "github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/routes"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNetworkPolicyCommands(t *testing.T) {
+	t.Parallel()
+
+	appWithPolicy := func(sources ...string) *v1alpha1.App {
+		app := &v1alpha1.App{}
+		app.Name = "backend"
+		app.Spec.NetworkPolicy.AllowedSourceApps = sources
+		return app
+	}
+
+	for tn, tc := range map[string]struct {
+		Namespace       string
+		Command         func(p *config.KfParams, c apps.Client) *cobra.Command
+		Args            []string
+		Setup           func(t *testing.T, appsfake *appsfake.FakeClient)
+		ExpectedErr     error
+		ExpectedStrings []string
+	}{
+		"add without namespace": {
+			Command:     routes.NewAddNetworkPolicyCommand,
+			Args:        []string{"frontend", "--destination-app=backend"},
+			ExpectedErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"add missing destination": {
+			Namespace:   "some-space",
+			Command:     routes.NewAddNetworkPolicyCommand,
+			Args:        []string{"frontend"},
+			ExpectedErr: errors.New("--destination-app is required"),
+		},
+		"add appends source": {
+			Namespace:       "some-space",
+			Command:         routes.NewAddNetworkPolicyCommand,
+			Args:            []string{"frontend", "--destination-app=backend"},
+			ExpectedStrings: []string{"Allowing traffic from frontend to backend"},
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform("some-space", "backend", gomock.Any()).
+					DoAndReturn(func(_, _ string, mutator apps.Mutator) error {
+						app := appWithPolicy("admin", "frontend")
+						err := mutator(app)
+						testutil.AssertEqual(t, "allowedSourceApps", []string{"admin", "frontend"}, app.Spec.NetworkPolicy.AllowedSourceApps)
+						return err
+					})
+			},
+		},
+		"add fails": {
+			Namespace:   "some-space",
+			Command:     routes.NewAddNetworkPolicyCommand,
+			Args:        []string{"frontend", "--destination-app=backend"},
+			ExpectedErr: errors.New("failed to add network policy: some-error"),
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("some-error"))
+			},
+		},
+		"remove deletes source": {
+			Namespace:       "some-space",
+			Command:         routes.NewRemoveNetworkPolicyCommand,
+			Args:            []string{"frontend", "--destination-app=backend"},
+			ExpectedStrings: []string{"Removed traffic from frontend to backend"},
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform("some-space", "backend", gomock.Any()).
+					DoAndReturn(func(_, _ string, mutator apps.Mutator) error {
+						app := appWithPolicy("frontend")
+						err := mutator(app)
+						testutil.AssertEqual(t, "allowedSourceApps", []string(nil), app.Spec.NetworkPolicy.AllowedSourceApps)
+						return err
+					})
+			},
+		},
+		"remove fails": {
+			Namespace:   "some-space",
+			Command:     routes.NewRemoveNetworkPolicyCommand,
+			Args:        []string{"frontend", "--destination-app=backend"},
+			ExpectedErr: errors.New("failed to remove network policy: some-error"),
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					Transform(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(errors.New("some-error"))
+			},
+		},
+		"list policies": {
+			Namespace:       "some-space",
+			Command:         routes.NewNetworkPoliciesCommand,
+			ExpectedStrings: []string{"SOURCE", "DESTINATION", "frontend", "backend"},
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					List("some-space").
+					Return([]v1alpha1.App{
+						*appWithPolicy("frontend"),
+						{ObjectMeta: metav1.ObjectMeta{Name: "open-app"}},
+					}, nil)
+			},
+		},
+		"list fails": {
+			Namespace:   "some-space",
+			Command:     routes.NewNetworkPoliciesCommand,
+			ExpectedErr: errors.New("failed to fetch Apps: some-error"),
+			Setup: func(t *testing.T, appsfake *appsfake.FakeClient) {
+				appsfake.EXPECT().
+					List(gomock.Any()).
+					Return(nil, errors.New("some-error"))
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			appsfake := appsfake.NewFakeClient(ctrl)
+
+			if tc.Setup != nil {
+				tc.Setup(t, appsfake)
+			}
+
+			var buffer bytes.Buffer
+			cmd := tc.Command(
+				&config.KfParams{
+					Namespace: tc.Namespace,
+				},
+				appsfake,
+			)
+			cmd.SetArgs(tc.Args)
+			cmd.SetOutput(&buffer)
+
+			gotErr := cmd.Execute()
+			if tc.ExpectedErr != nil || gotErr != nil {
+				testutil.AssertErrorsEqual(t, tc.ExpectedErr, gotErr)
+				return
+			}
+
+			testutil.AssertContainsAll(t, buffer.String(), tc.ExpectedStrings)
+			ctrl.Finish()
+		})
+	}
+}
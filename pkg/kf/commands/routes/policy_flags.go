@@ -0,0 +1,199 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package routes
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/algorithms"
+	"github.com/spf13/pflag"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// policyFlags holds the flags that set the timeout, retry policy, header
+// changes and access policy of a route.
+type policyFlags struct {
+	timeout               time.Duration
+	retries               int
+	perTryTimeout         time.Duration
+	setRequestHeaders     []string
+	removeRequestHeaders  []string
+	setResponseHeaders    []string
+	removeResponseHeaders []string
+	allowedSourceCIDRs    []string
+	deniedSourceCIDRs     []string
+	requestsPerSecond     int
+}
+
+// Add registers the flags on the FlagSet.
+func (f *policyFlags) Add(flags *pflag.FlagSet) {
+	flags.DurationVar(
+		&f.timeout,
+		"timeout",
+		0,
+		"Longest a request on the route can take, including retries (e.g., 30s)",
+	)
+	flags.IntVar(
+		&f.retries,
+		"retries",
+		0,
+		"Number of times a failed request is retried",
+	)
+	flags.DurationVar(
+		&f.perTryTimeout,
+		"retry-timeout",
+		0,
+		"Longest each attempt of a request can take (e.g., 5s)",
+	)
+	flags.StringArrayVar(
+		&f.setRequestHeaders,
+		"set-request-header",
+		nil,
+		"Set a header on requests sent to the apps. Multiple can be set by using the flag multiple times (e.g., NAME=VALUE).",
+	)
+	flags.StringArrayVar(
+		&f.removeRequestHeaders,
+		"remove-request-header",
+		nil,
+		"Remove a header from requests sent to the apps. Multiple can be removed by using the flag multiple times.",
+	)
+	flags.StringArrayVar(
+		&f.setResponseHeaders,
+		"set-response-header",
+		nil,
+		"Set a header on responses sent to clients. Multiple can be set by using the flag multiple times (e.g., NAME=VALUE).",
+	)
+	flags.StringArrayVar(
+		&f.removeResponseHeaders,
+		"remove-response-header",
+		nil,
+		"Remove a header from responses sent to clients. Multiple can be removed by using the flag multiple times.",
+	)
+	flags.StringArrayVar(
+		&f.allowedSourceCIDRs,
+		"allow-source-cidr",
+		nil,
+		"Only allow clients in the IPv4 range to reach the route. Multiple can be allowed by using the flag multiple times (e.g., 10.0.0.0/8).",
+	)
+	flags.StringArrayVar(
+		&f.deniedSourceCIDRs,
+		"deny-source-cidr",
+		nil,
+		"Reject clients in the IPv4 range. Multiple can be denied by using the flag multiple times (e.g., 203.0.113.0/24).",
+	)
+	flags.IntVar(
+		&f.requestsPerSecond,
+		"requests-per-second",
+		0,
+		"Most requests per second the route accepts, 0 for no limit",
+	)
+}
+
+// Apply sets the policies given by the flags on the RouteSpec. Policies that
+// weren't given are left as-is.
+func (f *policyFlags) Apply(flags *pflag.FlagSet, spec *v1alpha1.RouteSpec) error {
+	if flags.Changed("timeout") {
+		spec.Timeout = &metav1.Duration{Duration: f.timeout}
+	}
+
+	if flags.Changed("retries") || flags.Changed("retry-timeout") {
+		if spec.Retries == nil {
+			spec.Retries = &v1alpha1.RouteSpecRetries{}
+		}
+
+		if flags.Changed("retries") {
+			spec.Retries.Attempts = f.retries
+		}
+
+		if flags.Changed("retry-timeout") {
+			spec.Retries.PerTryTimeout = &metav1.Duration{Duration: f.perTryTimeout}
+		}
+	}
+
+	if len(f.allowedSourceCIDRs) > 0 || len(f.deniedSourceCIDRs) > 0 || flags.Changed("requests-per-second") {
+		if spec.AccessPolicy == nil {
+			spec.AccessPolicy = &v1alpha1.RouteSpecAccessPolicy{}
+		}
+
+		policy := spec.AccessPolicy
+		policy.AllowedSourceCIDRs = mergeStrings(policy.AllowedSourceCIDRs, f.allowedSourceCIDRs)
+		policy.DeniedSourceCIDRs = mergeStrings(policy.DeniedSourceCIDRs, f.deniedSourceCIDRs)
+
+		if flags.Changed("requests-per-second") {
+			policy.RequestsPerSecond = f.requestsPerSecond
+		}
+	}
+
+	if len(f.setRequestHeaders)+len(f.removeRequestHeaders)+len(f.setResponseHeaders)+len(f.removeResponseHeaders) == 0 {
+		return nil
+	}
+
+	if spec.Headers == nil {
+		spec.Headers = &v1alpha1.RouteSpecHeaders{}
+	}
+
+	var err error
+	spec.Headers.Request, err = applyHeaderFlags(spec.Headers.Request, f.setRequestHeaders, f.removeRequestHeaders)
+	if err != nil {
+		return err
+	}
+
+	spec.Headers.Response, err = applyHeaderFlags(spec.Headers.Response, f.setResponseHeaders, f.removeResponseHeaders)
+	return err
+}
+
+func applyHeaderFlags(ops *v1alpha1.RouteSpecHeaderOperations, set, remove []string) (*v1alpha1.RouteSpecHeaderOperations, error) {
+	if len(set) == 0 && len(remove) == 0 {
+		return ops, nil
+	}
+
+	if ops == nil {
+		ops = &v1alpha1.RouteSpecHeaderOperations{}
+	}
+
+	for _, header := range set {
+		// Only split on the first equals, values like max-age=60 can contain
+		// them.
+		parts := strings.SplitN(header, "=", 2)
+		if len(parts) != 2 || parts[0] == "" {
+			return nil, fmt.Errorf("malformed header: %s", header)
+		}
+
+		if ops.Set == nil {
+			ops.Set = make(map[string]string)
+		}
+		ops.Set[parts[0]] = parts[1]
+	}
+
+	ops.Remove = mergeStrings(ops.Remove, remove)
+
+	return ops, nil
+}
+
+// mergeStrings adds the new values to the existing ones, leaving nil if both
+// are empty.
+func mergeStrings(existing, values []string) []string {
+	if len(existing) == 0 && len(values) == 0 {
+		return existing
+	}
+
+	return []string(algorithms.Merge(
+		algorithms.Strings(existing),
+		algorithms.Strings(values),
+	).(algorithms.Strings))
+}
@@ -19,6 +19,7 @@ import (
 	"strings"
 	"text/tabwriter"
 
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/commands/utils"
 	"github.com/google/kf/pkg/kf/routes"
@@ -55,16 +56,30 @@ func NewRoutesCommand(
 			fmt.Fprintln(cmd.OutOrStdout())
 
 			w := tabwriter.NewWriter(cmd.OutOrStdout(), 8, 4, 2, ' ', tabwriter.StripEscape)
-			fmt.Fprintln(w, "HOST\tDOMAIN\tPATH\tAPPS")
+			fmt.Fprintln(w, "HOST\tDOMAIN\tPATH\tPORT\tAPPS\tREADY\tREASON")
 			for _, route := range routes {
-				apps := strings.Join(route.Spec.AppNames, ", ")
+				ready := ""
+				reason := ""
+				if cond := route.Status.GetCondition(v1alpha1.RouteConditionReady); cond != nil {
+					ready = fmt.Sprintf("%v", cond.Status)
+					reason = cond.Reason
+				}
+
+				port := ""
+				if route.Spec.IsTCP() {
+					port = fmt.Sprintf("%d", route.Spec.Port)
+				}
+
 				fmt.Fprintf(
 					w,
-					"%s\t%s\t%s\t%s\n",
+					"%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
 					route.Spec.Hostname,
 					route.Spec.Domain,
 					route.Spec.Path,
-					apps,
+					port,
+					formatApps(route.Spec),
+					ready,
+					reason,
 				)
 			}
 
@@ -74,6 +89,36 @@ func NewRoutesCommand(
 	}
 }
 
+// formatApps lists the Apps bound to a Route along with their weights if any
+// have been set.
+func formatApps(spec v1alpha1.RouteSpec) string {
+	var apps []string
+	for _, appName := range spec.AppNames {
+		if len(spec.AppWeights) == 0 {
+			apps = append(apps, appName)
+		} else {
+			apps = append(apps, fmt.Sprintf("%s (weight %d)", appName, spec.WeightFor(appName)))
+		}
+	}
+	return strings.Join(apps, ", ")
+}
+
+// routeName gets the name of the Route for the address given to a command.
+// A port means the address is a TCP route.
+func routeName(hostname, domain, urlPath string, port int32, exactPath bool) string {
+	fields := v1alpha1.RouteSpecFields{
+		Hostname: hostname,
+		Domain:   domain,
+		Path:     urlPath,
+		Port:     port,
+	}
+	if exactPath {
+		fields.PathMatch = v1alpha1.PathMatchExact
+	}
+
+	return v1alpha1.GenerateRouteNameFromSpec(fields)
+}
+
 func splitHost(h string) (subDomain, domain string) {
 	// A subdomain implies there are at least 2 periods. If parts has a length
 	// less than 3, then we don't have a subdomain.
@@ -74,6 +74,64 @@ func TestRoutes(t *testing.T) {
 				testutil.AssertContainsAll(t, buffer.String(), []string{"host-1", "example.com", "/path1", "app-1, app-2"})
 			},
 		},
+		"display route status": {
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fakeRoute *fakeroute.FakeClient) {
+				route := v1alpha1.Route{
+					Spec: v1alpha1.RouteSpec{
+						RouteSpecFields: v1alpha1.RouteSpecFields{
+							Hostname: "host-1",
+							Domain:   "example.com",
+						},
+					},
+				}
+				route.Status.InitializeConditions()
+				route.Status.PropagateBoundApps(route.Spec, nil)
+
+				fakeRoute.EXPECT().List(gomock.Any()).Return([]v1alpha1.Route{route}, nil)
+			},
+			BufferF: func(t *testing.T, buffer *bytes.Buffer) {
+				testutil.AssertContainsAll(t, buffer.String(), []string{"READY", "REASON", "False", "NoApps"})
+			},
+		},
+		"display weights": {
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fakeRoute *fakeroute.FakeClient) {
+				fakeRoute.EXPECT().List(gomock.Any()).Return([]v1alpha1.Route{
+					{
+						Spec: v1alpha1.RouteSpec{
+							RouteSpecFields: v1alpha1.RouteSpecFields{
+								Domain: "example.com",
+							},
+							AppNames:   []string{"app-1", "app-2"},
+							AppWeights: map[string]int{"app-2": 9},
+						},
+					},
+				}, nil)
+			},
+			BufferF: func(t *testing.T, buffer *bytes.Buffer) {
+				testutil.AssertContainsAll(t, buffer.String(), []string{"app-1 (weight 1), app-2 (weight 9)"})
+			},
+		},
+		"display tcp routes": {
+			Namespace: "some-namespace",
+			Setup: func(t *testing.T, fakeRoute *fakeroute.FakeClient) {
+				fakeRoute.EXPECT().List(gomock.Any()).Return([]v1alpha1.Route{
+					{
+						Spec: v1alpha1.RouteSpec{
+							RouteSpecFields: v1alpha1.RouteSpecFields{
+								Domain: "tcp.example.com",
+								Port:   1024,
+							},
+							AppNames: []string{"broker"},
+						},
+					},
+				}, nil)
+			},
+			BufferF: func(t *testing.T, buffer *bytes.Buffer) {
+				testutil.AssertContainsAll(t, buffer.String(), []string{"PORT", "tcp.example.com", "1024", "broker"})
+			},
+		},
 	} {
 		t.Run(tn, func(t *testing.T) {
 			ctrl := gomock.NewController(t)
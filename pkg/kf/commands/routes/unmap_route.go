@@ -16,7 +16,6 @@ package routes
 
 import (
 	"fmt"
-	"path"
 
 	v1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/commands/config"
@@ -30,15 +29,21 @@ func NewUnmapRouteCommand(
 	p *config.KfParams,
 	c routes.Client,
 ) *cobra.Command {
-	var hostname, urlPath string
+	var (
+		hostname, urlPath string
+		exactPath         bool
+		port              int32
+	)
 
 	cmd := &cobra.Command{
-		Use:   "unmap-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH]",
+		Use:   "unmap-route APP_NAME DOMAIN [--hostname HOSTNAME] [--path PATH] [--exact-path] [--port PORT]",
 		Short: "Unmap a route from an app",
 		Example: `
   kf unmap-route myapp example.com --hostname myapp # myapp.example.com
   kf unmap-route --namespace myspace myapp example.com --hostname myapp # myapp.example.com
   kf unmap-route myapp example.com --hostname myapp --path /mypath # myapp.example.com/mypath
+  kf unmap-route myapp example.com --hostname myapp --path /mypath --exact-path # myapp.example.com/mypath
+  kf unmap-route mybroker tcp.example.com --port 1024 # tcp.example.com:1024
   `,
 		Args: cobra.ExactArgs(2),
 		RunE: func(cmd *cobra.Command, args []string) error {
@@ -69,8 +74,7 @@ func NewUnmapRouteCommand(
 				return nil
 			})
 
-			urlPath = path.Join("/", urlPath)
-			ksvcName := v1alpha1.GenerateRouteName(hostname, domain, urlPath)
+			ksvcName := routeName(hostname, domain, urlPath, port, exactPath)
 			if err := c.Transform(p.Namespace, ksvcName, mutator); err != nil {
 				return fmt.Errorf("failed to unmap Route: %s", err)
 			}
@@ -90,6 +94,18 @@ func NewUnmapRouteCommand(
 		"",
 		"URL Path for the route",
 	)
+	cmd.Flags().Int32Var(
+		&port,
+		"port",
+		0,
+		"Port of a TCP route",
+	)
+	cmd.Flags().BoolVar(
+		&exactPath,
+		"exact-path",
+		false,
+		"Only match the path itself rather than every path below it",
+	)
 
 	return cmd
 }
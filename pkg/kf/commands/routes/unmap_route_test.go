@@ -83,6 +83,16 @@ func TestUnmapRoute(t *testing.T) {
 				testutil.AssertNil(t, "err", err)
 			},
 		},
+		"tcp Route name": {
+			Args:      []string{"some-app", "tcp.example.com", "--port=1024"},
+			Namespace: "some-space",
+			Setup: func(t *testing.T, fake *fake.FakeClient) {
+				fake.EXPECT().Transform(gomock.Any(), v1alpha1.GenerateTCPRouteName("tcp.example.com", 1024), gomock.Any())
+			},
+			Assert: func(t *testing.T, buffer *bytes.Buffer, err error) {
+				testutil.AssertNil(t, "err", err)
+			},
+		},
 		"remove non-existent app": {
 			Args:      []string{"some-app", "example.com", "--hostname=some-host", "--path=some-path"},
 			Namespace: "some-space",
@@ -16,7 +16,9 @@ package spaces
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/internal/envutil"
@@ -25,7 +27,9 @@ import (
 	"github.com/google/kf/pkg/kf/commands/quotas"
 	"github.com/google/kf/pkg/kf/spaces"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // NewConfigSpaceCommand creates a command that can set facets of a space.
@@ -46,7 +50,16 @@ func NewConfigSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Comm
 		newUnsetBuildpackEnvMutator(),
 		newSetContainerRegistryMutator(),
 		newSetBuildpackBuilderMutator(),
+		newAllowBuilderImageMutator(),
+		newDisallowBuilderImageMutator(),
+		newSetBuildTemplateMutator(),
+		newSetBuildRetentionMutator(),
+		newSetBuildTimeoutMutator(),
 		newAppendDomainMutator(),
+		newAppendInternalDomainMutator(),
+		newReserveTCPPortMutator(),
+		newSetDomainCertMutator(),
+		newRemoveDomainCertMutator(),
 		newSetDefaultDomainMutator(),
 		newRemoveDomainMutator(),
 	}
@@ -73,10 +86,13 @@ type spaceMutator struct {
 	Short string
 	Args  []string
 	Init  func(args []string) (spaces.Mutator, error)
+
+	// Flags optionally registers flags read by Init.
+	Flags func(flags *pflag.FlagSet)
 }
 
 func (sm spaceMutator) ToCommand(client spaces.Client) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   fmt.Sprintf("%s SPACE_NAME %s", sm.Name, strings.Join(sm.Args, " ")),
 		Short: sm.Short,
 		Long:  sm.Short,
@@ -95,6 +111,12 @@ func (sm spaceMutator) ToCommand(client spaces.Client) *cobra.Command {
 			return client.Transform(spaceName, diffPrintingMutator)
 		},
 	}
+
+	if sm.Flags != nil {
+		sm.Flags(cmd.Flags())
+	}
+
+	return cmd
 }
 
 func newSetContainerRegistryMutator() spaceMutator {
@@ -131,6 +153,126 @@ func newSetBuildpackBuilderMutator() spaceMutator {
 	}
 }
 
+func newAllowBuilderImageMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "allow-builder-image",
+		Short: "Add a builder image to the images apps in the space can be built with. If no images are allowed, any can be used.",
+		Args:  []string{"BUILDER_IMAGE"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			image := args[0]
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.BuildpackBuild.AllowedBuilderImages = []string(algorithms.Merge(
+					algorithms.Strings(space.Spec.BuildpackBuild.AllowedBuilderImages),
+					algorithms.Strings{image},
+				).(algorithms.Strings))
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newDisallowBuilderImageMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "disallow-builder-image",
+		Short: "Remove a builder image from the images apps in the space can be built with.",
+		Args:  []string{"BUILDER_IMAGE"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			image := args[0]
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.BuildpackBuild.AllowedBuilderImages = []string(algorithms.Delete(
+					algorithms.Strings(space.Spec.BuildpackBuild.AllowedBuilderImages),
+					algorithms.Strings{image},
+				).(algorithms.Strings))
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newSetBuildTemplateMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-build-template",
+		Short: "Set the BuildTemplate in the space used to build apps instead of the default. An empty name restores the default.",
+		Args:  []string{"BUILD_TEMPLATE"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			template := args[0]
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.BuildpackBuild.BuildTemplate = template
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newSetBuildRetentionMutator() spaceMutator {
+	var deleteImages bool
+
+	return spaceMutator{
+		Name:  "set-build-retention",
+		Short: "Set the number of successful and failed builds kept for each app.",
+		Args:  []string{"SUCCESSFUL_BUILDS", "FAILED_BUILDS"},
+		Flags: func(flags *pflag.FlagSet) {
+			flags.BoolVar(
+				&deleteImages,
+				"delete-images",
+				false,
+				"Delete the images of removed builds from the container registry",
+			)
+		},
+		Init: func(args []string) (spaces.Mutator, error) {
+			successful, err := strconv.Atoi(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse successful builds: %s", err)
+			}
+
+			failed, err := strconv.Atoi(args[1])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse failed builds: %s", err)
+			}
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.BuildpackBuild.Retention = v1alpha1.SpaceSpecBuildRetention{
+					SuccessfulBuildsLimit: &successful,
+					FailedBuildsLimit:     &failed,
+					DeleteImages:          deleteImages,
+				}
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newSetBuildTimeoutMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "set-build-timeout",
+		Short: "Set the longest a build can run before it's stopped (e.g., 45m).",
+		Args:  []string{"TIMEOUT"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			timeout, err := time.ParseDuration(args[0])
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse timeout: %s", err)
+			}
+
+			if timeout <= 0 {
+				return nil, fmt.Errorf("timeout must be positive, got %s", timeout)
+			}
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.BuildpackBuild.Timeout = &metav1.Duration{Duration: timeout}
+
+				return nil
+			}, nil
+		},
+	}
+}
+
 func newSetEnvMutator() spaceMutator {
 	return spaceMutator{
 		Name:  "set-env",
@@ -223,6 +365,110 @@ func newAppendDomainMutator() spaceMutator {
 	}
 }
 
+func newAppendInternalDomainMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "append-internal-domain",
+		Short: "Append a domain for a space that's only reachable from Apps in the cluster",
+		Args:  []string{"DOMAIN"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain := args[0]
+
+			return func(space *v1alpha1.Space) error {
+				space.Spec.Execution.Domains = append(
+					space.Spec.Execution.Domains,
+					v1alpha1.SpaceDomain{Domain: domain, Internal: true},
+				)
+
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newReserveTCPPortMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "reserve-tcp-port",
+		Short: "Reserve a port on a domain for TCP routes",
+		Args:  []string{"DOMAIN", "PORT"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain := args[0]
+			port, err := strconv.ParseInt(args[1], 10, 32)
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse port: %s", err)
+			}
+
+			return func(space *v1alpha1.Space) error {
+				for i, d := range space.Spec.Execution.Domains {
+					if d.Domain != domain {
+						continue
+					}
+
+					if !space.Spec.Execution.ReservesTCPPort(domain, int32(port)) {
+						space.Spec.Execution.Domains[i].TCPPorts = append(d.TCPPorts, int32(port))
+					}
+					return nil
+				}
+
+				return fmt.Errorf("failed to find domain %s", domain)
+			}, nil
+		},
+	}
+}
+
+func newSetDomainCertMutator() spaceMutator {
+	var redirectHTTP bool
+
+	return spaceMutator{
+		Name:  "set-domain-cert",
+		Short: "Serve routes on a domain over HTTPS with the certificate in a kubernetes.io/tls secret",
+		Args:  []string{"DOMAIN", "SECRET_NAME"},
+		Flags: func(flags *pflag.FlagSet) {
+			flags.BoolVar(
+				&redirectHTTP,
+				"redirect-http",
+				false,
+				"Redirect HTTP requests on the domain to HTTPS",
+			)
+		},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain, secretName := args[0], args[1]
+
+			return func(space *v1alpha1.Space) error {
+				d := space.Spec.Execution.FindDomain(domain)
+				if d == nil {
+					return fmt.Errorf("failed to find domain %s", domain)
+				}
+
+				d.CertificateSecret = secretName
+				d.RedirectHTTP = redirectHTTP
+				return nil
+			}, nil
+		},
+	}
+}
+
+func newRemoveDomainCertMutator() spaceMutator {
+	return spaceMutator{
+		Name:  "remove-domain-cert",
+		Short: "Stop serving routes on a domain over HTTPS",
+		Args:  []string{"DOMAIN"},
+		Init: func(args []string) (spaces.Mutator, error) {
+			domain := args[0]
+
+			return func(space *v1alpha1.Space) error {
+				d := space.Spec.Execution.FindDomain(domain)
+				if d == nil {
+					return fmt.Errorf("failed to find domain %s", domain)
+				}
+
+				d.CertificateSecret = ""
+				d.RedirectHTTP = false
+				return nil
+			}, nil
+		},
+	}
+}
+
 func newSetDefaultDomainMutator() spaceMutator {
 	return spaceMutator{
 		Name:  "set-default-domain",
@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -26,6 +27,7 @@ import (
 	"github.com/google/kf/pkg/kf/spaces"
 	"github.com/google/kf/pkg/kf/spaces/fake"
 	"github.com/google/kf/pkg/kf/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func TestNewConfigSpaceCommand(t *testing.T) {
@@ -130,6 +132,74 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 			},
 		},
 
+		"allow-builder-image valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					BuildpackBuild: v1alpha1.SpaceSpecBuildpackBuild{
+						AllowedBuilderImages: []string{"gcr.io/corp/builder"},
+					},
+				},
+			},
+			args: []string{"allow-builder-image", space, "gcr.io/corp/builder-v2"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "allowed builder images",
+					[]string{"gcr.io/corp/builder", "gcr.io/corp/builder-v2"},
+					space.Spec.BuildpackBuild.AllowedBuilderImages)
+			},
+		},
+
+		"disallow-builder-image valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					BuildpackBuild: v1alpha1.SpaceSpecBuildpackBuild{
+						AllowedBuilderImages: []string{"gcr.io/corp/builder", "gcr.io/corp/builder-v2"},
+					},
+				},
+			},
+			args: []string{"disallow-builder-image", space, "gcr.io/corp/builder"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "allowed builder images",
+					[]string{"gcr.io/corp/builder-v2"},
+					space.Spec.BuildpackBuild.AllowedBuilderImages)
+			},
+		},
+
+		"set-build-template valid": {
+			args: []string{"set-build-template", space, "corp-buildpack"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "build template", "corp-buildpack", space.Spec.BuildpackBuild.BuildTemplate)
+			},
+		},
+
+		"set-build-retention valid": {
+			args: []string{"set-build-retention", space, "5", "2", "--delete-images"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				successful, failed := 5, 2
+				testutil.AssertEqual(t, "retention", v1alpha1.SpaceSpecBuildRetention{
+					SuccessfulBuildsLimit: &successful,
+					FailedBuildsLimit:     &failed,
+					DeleteImages:          true,
+				}, space.Spec.BuildpackBuild.Retention)
+			},
+		},
+
+		"set-build-retention invalid": {
+			wantErr: errors.New(`failed to parse failed builds: strconv.Atoi: parsing "some": invalid syntax`),
+			args:    []string{"set-build-retention", space, "5", "some"},
+		},
+
+		"set-build-timeout valid": {
+			args: []string{"set-build-timeout", space, "45m"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "timeout", &metav1.Duration{Duration: 45 * time.Minute}, space.Spec.BuildpackBuild.Timeout)
+			},
+		},
+
+		"set-build-timeout invalid": {
+			wantErr: errors.New(`timeout must be positive, got -5m0s`),
+			args:    []string{"set-build-timeout", space, "--", "-5m"},
+		},
+
 		"append-domain valid": {
 			args: []string{"append-domain", space, "example.com"},
 			validate: func(t *testing.T, space *v1alpha1.Space) {
@@ -138,6 +208,77 @@ func TestNewConfigSpaceCommand(t *testing.T) {
 			},
 		},
 
+		"append-internal-domain valid": {
+			args: []string{"append-internal-domain", space, "apps.internal"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "domains", []v1alpha1.SpaceDomain{
+					{Domain: "apps.internal", Internal: true},
+				}, space.Spec.Execution.Domains)
+			},
+		},
+
+		"reserve-tcp-port valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					Execution: v1alpha1.SpaceSpecExecution{
+						Domains: []v1alpha1.SpaceDomain{
+							{Domain: "tcp.example.com", TCPPorts: []int32{1024}},
+						},
+					},
+				},
+			},
+			args: []string{"reserve-tcp-port", space, "tcp.example.com", "1025"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "ports", []int32{1024, 1025}, space.Spec.Execution.Domains[0].TCPPorts)
+			},
+		},
+
+		"reserve-tcp-port missing domain": {
+			wantErr: errors.New("failed to find domain tcp.example.com"),
+			args:    []string{"reserve-tcp-port", space, "tcp.example.com", "1025"},
+		},
+
+		"set-domain-cert valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					Execution: v1alpha1.SpaceSpecExecution{
+						Domains: []v1alpha1.SpaceDomain{
+							{Domain: "example.com"},
+						},
+					},
+				},
+			},
+			args: []string{"set-domain-cert", space, "example.com", "example-cert", "--redirect-http"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "domains", []v1alpha1.SpaceDomain{
+					{Domain: "example.com", CertificateSecret: "example-cert", RedirectHTTP: true},
+				}, space.Spec.Execution.Domains)
+			},
+		},
+
+		"set-domain-cert missing domain": {
+			wantErr: errors.New("failed to find domain example.com"),
+			args:    []string{"set-domain-cert", space, "example.com", "example-cert"},
+		},
+
+		"remove-domain-cert valid": {
+			space: v1alpha1.Space{
+				Spec: v1alpha1.SpaceSpec{
+					Execution: v1alpha1.SpaceSpecExecution{
+						Domains: []v1alpha1.SpaceDomain{
+							{Domain: "example.com", CertificateSecret: "example-cert", RedirectHTTP: true},
+						},
+					},
+				},
+			},
+			args: []string{"remove-domain-cert", space, "example.com"},
+			validate: func(t *testing.T, space *v1alpha1.Space) {
+				testutil.AssertEqual(t, "domains", []v1alpha1.SpaceDomain{
+					{Domain: "example.com"},
+				}, space.Spec.Execution.Domains)
+			},
+		},
+
 		"set-default-domain valid": {
 			space: v1alpha1.Space{
 				Spec: v1alpha1.SpaceSpec{
@@ -17,6 +17,8 @@ package spaces
 import (
 	"fmt"
 	"io"
+	"strings"
+	"time"
 
 	"github.com/google/kf/pkg/kf/commands/config"
 	"github.com/google/kf/pkg/kf/describe"
@@ -58,6 +60,12 @@ func NewGetSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Command
 			describe.SectionWriter(w, "Build", func(w io.Writer) {
 				buildpackBuild := space.Spec.BuildpackBuild
 				fmt.Fprintf(w, "Builder Image:\t%q\n", buildpackBuild.BuilderImage)
+				if len(buildpackBuild.AllowedBuilderImages) > 0 {
+					fmt.Fprintf(w, "Allowed Builder Images:\t%s\n", strings.Join(buildpackBuild.AllowedBuilderImages, ", "))
+				}
+				if buildpackBuild.BuildTemplate != "" {
+					fmt.Fprintf(w, "Build Template:\t%q\n", buildpackBuild.BuildTemplate)
+				}
 				fmt.Fprintf(w, "Container Registry:\t%q\n", buildpackBuild.ContainerRegistry)
 				describe.EnvVars(w, buildpackBuild.Env)
 			})
@@ -73,9 +81,22 @@ func NewGetSpaceCommand(p *config.KfParams, client spaces.Client) *cobra.Command
 					}
 
 					describe.TabbedWriter(w, func(w io.Writer) {
-						fmt.Fprintln(w, "Name\tDefault?")
+						fmt.Fprintln(w, "Name\tDefault?\tCertificate\tRedirect HTTP?")
 						for _, domain := range execution.Domains {
-							fmt.Fprintf(w, "%s\t%t\n", domain.Domain, domain.Default)
+							fmt.Fprintf(w, "%s\t%t\t%s\t%t\n", domain.Domain, domain.Default, domain.CertificateSecret, domain.RedirectHTTP)
+						}
+					})
+				})
+
+				describe.SectionWriter(w, "Certificates", func(w io.Writer) {
+					if len(space.Status.Certificates) == 0 {
+						return
+					}
+
+					describe.TabbedWriter(w, func(w io.Writer) {
+						fmt.Fprintln(w, "Domain\tSecret\tExpires")
+						for _, cert := range space.Status.Certificates {
+							fmt.Fprintf(w, "%s\t%s\t%s\n", cert.Domain, cert.SecretName, cert.NotAfter.UTC().Format(time.RFC3339))
 						}
 					})
 				})
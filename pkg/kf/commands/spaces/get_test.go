@@ -18,6 +18,7 @@ import (
 	"bytes"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -25,6 +26,7 @@ import (
 	"github.com/google/kf/pkg/kf/spaces/fake"
 	"github.com/google/kf/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 )
 
@@ -46,8 +48,13 @@ func TestNewGetSpaceCommand(t *testing.T) {
 	goodSpace.Spec.Execution.Env = []corev1.EnvVar{{Name: "ExecVar", Value: "ExecVal"}}
 	goodSpace.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
 		{Domain: "domain-1.com", Default: true},
-		{Domain: "domain-2.com"},
+		{Domain: "domain-2.com", CertificateSecret: "domain-2-cert"},
 	}
+	goodSpace.Status.Certificates = []v1alpha1.SpaceStatusCertificate{{
+		Domain:     "domain-2.com",
+		SecretName: "domain-2-cert",
+		NotAfter:   metav1.NewTime(time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)),
+	}}
 
 	cases := map[string]struct {
 		wantErr    error
@@ -79,6 +86,11 @@ func TestNewGetSpaceCommand(t *testing.T) {
 			space:      goodSpace,
 			wantOutput: []string{"Execution", "ExecVar", "ExecVal", "domain-1.com", "domain-2.com"},
 		},
+		"certificates": {
+			args:       []string{"my-space"},
+			space:      goodSpace,
+			wantOutput: []string{"Certificates", "domain-2-cert", "2030-01-01T00:00:00Z"},
+		},
 		"client error": {
 			args:    []string{"my-space"},
 			space:   nil,
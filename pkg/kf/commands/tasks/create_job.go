@@ -0,0 +1,123 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/spf13/cobra"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+)
+
+// NewCreateJobCommand allows users to run commands against an app on a
+// schedule.
+func NewCreateJobCommand(
+	p *config.KfParams,
+	appsClient apps.Client,
+	tasksClient tasks.Client,
+) *cobra.Command {
+	var (
+		schedule               string
+		concurrencyPolicy      string
+		successfulHistoryLimit int32
+		failedHistoryLimit     int32
+	)
+
+	cmd := &cobra.Command{
+		Use:   "create-job APP_NAME JOB_NAME COMMAND --schedule CRON",
+		Short: "Run a command with the image and environment of an app on a schedule",
+		Long: `
+	Jobs run the command in the app's latest image with the same environment
+	variables and service bindings as the app each time the Cron schedule is
+	due. The schedule is evaluated in the cluster's time zone.`,
+		Example: `
+  kf create-job myapp cleanup "rake db:cleanup" --schedule "0 3 * * *"
+  kf create-job myapp report "bin/report" --schedule @hourly --concurrency-policy Forbid
+  kf create-job myapp sync "bin/sync" --schedule "*/5 * * * *" --failed-history-limit 5
+  `,
+		Args: cobra.ExactArgs(3),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			if schedule == "" {
+				return errors.New("--schedule is required")
+			}
+
+			cmd.SilenceUsage = true
+
+			appName, jobName, command := args[0], args[1], args[2]
+			if _, err := appsClient.Get(p.Namespace, appName); err != nil {
+				return fmt.Errorf("failed to get app: %s", err)
+			}
+
+			spec := v1alpha1.TaskSpecSchedule{
+				Cron:              schedule,
+				ConcurrencyPolicy: batchv1beta1.ConcurrencyPolicy(concurrencyPolicy),
+			}
+			if cmd.Flags().Changed("successful-history-limit") {
+				spec.SuccessfulRunsHistoryLimit = &successfulHistoryLimit
+			}
+			if cmd.Flags().Changed("failed-history-limit") {
+				spec.FailedRunsHistoryLimit = &failedHistoryLimit
+			}
+
+			task := tasks.NewScheduledTask(p.Namespace, jobName, appName, command, spec)
+			if _, err := tasksClient.Create(p.Namespace, task); err != nil {
+				return fmt.Errorf("failed to create job: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created job %q for app %q on schedule %q\n", jobName, appName, schedule)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&schedule,
+		"schedule",
+		"",
+		"Cron schedule to run the command on, e.g. \"0 3 * * *\" or @daily.",
+	)
+
+	cmd.Flags().StringVar(
+		&concurrencyPolicy,
+		"concurrency-policy",
+		"",
+		"What to do when a run is due while the previous one is still running: Allow, Forbid or Replace. Defaults to Allow.",
+	)
+
+	cmd.Flags().Int32Var(
+		&successfulHistoryLimit,
+		"successful-history-limit",
+		v1alpha1.DefaultSuccessfulRunsHistoryLimit,
+		"Number of successful runs to keep.",
+	)
+
+	cmd.Flags().Int32Var(
+		&failedHistoryLimit,
+		"failed-history-limit",
+		v1alpha1.DefaultFailedRunsHistoryLimit,
+		"Number of failed runs to keep.",
+	)
+
+	return cmd
+}
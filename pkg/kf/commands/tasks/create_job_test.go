@@ -0,0 +1,123 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	appsfake "github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+)
+
+func TestNewCreateJobCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(t *testing.T, fakeApps *appsfake.FakeClient, fakeTasks *fake.FakeClient)
+
+		wantErr         error
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{"my-app", "cleanup"},
+			wantErr: errors.New("accepts 3 arg(s), received 2"),
+		},
+		"missing namespace": {
+			args:    []string{"my-app", "cleanup", "rake db:cleanup", "--schedule", "@daily"},
+			wantErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		"missing schedule": {
+			args:      []string{"my-app", "cleanup", "rake db:cleanup"},
+			namespace: "my-ns",
+			wantErr:   errors.New("--schedule is required"),
+		},
+		"app not found": {
+			args:      []string{"my-app", "cleanup", "rake db:cleanup", "--schedule", "@daily"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeApps *appsfake.FakeClient, fakeTasks *fake.FakeClient) {
+				fakeApps.EXPECT().Get("my-ns", "my-app").Return(nil, errors.New("not found"))
+			},
+			wantErr: errors.New("failed to get app: not found"),
+		},
+		"creates the job": {
+			args: []string{
+				"my-app", "cleanup", "rake db:cleanup",
+				"--schedule", "0 3 * * *",
+				"--concurrency-policy", "Forbid",
+				"--failed-history-limit", "5",
+			},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeApps *appsfake.FakeClient, fakeTasks *fake.FakeClient) {
+				fakeApps.EXPECT().Get("my-ns", "my-app").Return(&v1alpha1.App{}, nil)
+				fakeTasks.
+					EXPECT().
+					Create("my-ns", gomock.Any()).
+					DoAndReturn(func(ns string, task *v1alpha1.Task) (*v1alpha1.Task, error) {
+						testutil.AssertEqual(t, "name", "cleanup", task.Name)
+						testutil.AssertEqual(t, "app", "my-app", task.Spec.AppName)
+						testutil.AssertEqual(t, "command", "rake db:cleanup", task.Spec.Command)
+						testutil.AssertEqual(t, "cron", "0 3 * * *", task.Spec.Schedule.Cron)
+						testutil.AssertEqual(t, "concurrency policy", batchv1beta1.ForbidConcurrent, task.Spec.Schedule.ConcurrencyPolicy)
+						testutil.AssertEqual(t, "successful history", (*int32)(nil), task.Spec.Schedule.SuccessfulRunsHistoryLimit)
+						testutil.AssertEqual(t, "failed history", int32(5), *task.Spec.Schedule.FailedRunsHistoryLimit)
+						return task, nil
+					})
+			},
+			expectedStrings: []string{`Created job "cleanup" for app "my-app" on schedule "0 3 * * *"`},
+		},
+		"server failure": {
+			args:      []string{"my-app", "cleanup", "rake db:cleanup", "--schedule", "@daily"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeApps *appsfake.FakeClient, fakeTasks *fake.FakeClient) {
+				fakeApps.EXPECT().Get("my-ns", "my-app").Return(&v1alpha1.App{}, nil)
+				fakeTasks.EXPECT().Create("my-ns", gomock.Any()).Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("failed to create job: some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeApps := appsfake.NewFakeClient(ctrl)
+			fakeTasks := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeApps, fakeTasks)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewCreateJobCommand(&config.KfParams{Namespace: tc.namespace}, fakeApps, fakeTasks)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/spf13/cobra"
+)
+
+// NewDeleteJobCommand allows users to remove a scheduled job.
+func NewDeleteJobCommand(p *config.KfParams, client tasks.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "delete-job JOB_NAME",
+		Short:   "Delete a scheduled job and stop its running commands",
+		Example: `  kf delete-job cleanup`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			jobName := args[0]
+			task, err := client.Get(p.Namespace, jobName)
+			if err != nil {
+				return fmt.Errorf("failed to get job: %s", err)
+			}
+
+			if !task.Spec.IsScheduled() {
+				return fmt.Errorf("%s is a task not a job, use terminate-task to stop it", jobName)
+			}
+
+			if err := client.Delete(p.Namespace, jobName); err != nil {
+				return fmt.Errorf("failed to delete job: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Deleting job %q\n", jobName)
+			return nil
+		},
+	}
+
+	return cmd
+}
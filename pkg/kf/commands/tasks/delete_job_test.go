@@ -0,0 +1,102 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestNewDeleteJobCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(t *testing.T, fakeTasks *fake.FakeClient)
+
+		wantErr         error
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"missing namespace": {
+			args:    []string{"cleanup"},
+			wantErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		"deletes the job": {
+			args:      []string{"cleanup"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				job := tasks.NewScheduledTask("my-ns", "cleanup", "my-app", "rake db:cleanup", v1alpha1.TaskSpecSchedule{
+					Cron: "@daily",
+				})
+
+				fakeTasks.EXPECT().Get("my-ns", "cleanup").Return(job, nil)
+				fakeTasks.EXPECT().Delete("my-ns", "cleanup").Return(nil)
+			},
+			expectedStrings: []string{`Deleting job "cleanup"`},
+		},
+		"one-off task": {
+			args:      []string{"my-app-migrate"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.EXPECT().Get("my-ns", "my-app-migrate").Return(&v1alpha1.Task{}, nil)
+			},
+			wantErr: errors.New("my-app-migrate is a task not a job, use terminate-task to stop it"),
+		},
+		"server failure": {
+			args:      []string{"cleanup"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.EXPECT().Get("my-ns", "cleanup").Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("failed to get job: some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeTasks := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeTasks)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewDeleteJobCommand(&config.KfParams{Namespace: tc.namespace}, fakeTasks)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
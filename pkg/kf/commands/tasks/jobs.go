@@ -0,0 +1,91 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta/table"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// NewListJobsCommand allows users to list the scheduled jobs in a space.
+func NewListJobsCommand(p *config.KfParams, client tasks.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "jobs [APP_NAME]",
+		Short: "List the scheduled jobs in the targeted space",
+		Example: `
+  kf jobs
+  kf jobs myapp
+  `,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			var appName string
+			if len(args) == 1 {
+				appName = args[0]
+			}
+
+			list, err := client.List(p.Namespace)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 8, 4, 1, ' ', tabwriter.StripEscape)
+			defer w.Flush()
+
+			fmt.Fprintln(w, "Name\tApp\tSchedule\tLast Run\tActive\tReason\tCommand")
+			for _, task := range list {
+				if !task.Spec.IsScheduled() {
+					continue
+				}
+
+				if appName != "" && task.Spec.AppName != appName {
+					continue
+				}
+
+				lastRun := metav1.Time{}
+				if task.Status.LastScheduleTime != nil {
+					lastRun = *task.Status.LastScheduleTime
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%d\t%s\t%s",
+					task.Name,
+					task.Spec.AppName,
+					task.Spec.Schedule.Cron,
+					table.ConvertToHumanReadableDateType(lastRun),
+					task.Status.ActiveRuns,
+					taskReason(task.Status),
+					task.Spec.Command,
+				)
+				fmt.Fprintln(w)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
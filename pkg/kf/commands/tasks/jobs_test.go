@@ -0,0 +1,123 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestNewListJobsCommand(t *testing.T) {
+	t.Parallel()
+
+	cleanup := *tasks.NewScheduledTask("my-ns", "my-app-cleanup", "my-app", "rake db:cleanup", v1alpha1.TaskSpecSchedule{
+		Cron: "0 3 * * *",
+	})
+	cleanup.Status.ActiveRuns = 1
+
+	report := *tasks.NewScheduledTask("my-ns", "other-app-report", "other-app", "bin/report", v1alpha1.TaskSpecSchedule{
+		Cron: "@hourly",
+	})
+
+	oneOff := v1alpha1.Task{}
+	oneOff.Name = "my-app-migrate"
+	oneOff.Spec.AppName = "my-app"
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(t *testing.T, fakeTasks *fake.FakeClient)
+
+		wantErr           error
+		expectedStrings   []string
+		unexpectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{"my-app", "other-app"},
+			wantErr: errors.New("accepts at most 1 arg(s), received 2"),
+		},
+		"missing namespace": {
+			args:    []string{},
+			wantErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		"all jobs": {
+			args:      []string{},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.EXPECT().List("my-ns").Return([]v1alpha1.Task{cleanup, report, oneOff}, nil)
+			},
+			expectedStrings: []string{
+				"Name", "App", "Schedule", "Last Run", "Active", "Reason", "Command",
+				"my-app-cleanup", "0 3 * * *", "rake db:cleanup",
+				"other-app-report", "@hourly", "bin/report",
+			},
+			unexpectedStrings: []string{"my-app-migrate"},
+		},
+		"jobs of an app": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.EXPECT().List("my-ns").Return([]v1alpha1.Task{cleanup, report, oneOff}, nil)
+			},
+			expectedStrings:   []string{"my-app-cleanup"},
+			unexpectedStrings: []string{"other-app-report", "my-app-migrate"},
+		},
+		"server failure": {
+			args:      []string{},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.EXPECT().List("my-ns").Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeTasks := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeTasks)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewListJobsCommand(&config.KfParams{Namespace: tc.namespace}, fakeTasks)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+			for _, unexpected := range tc.unexpectedStrings {
+				if strings.Contains(buffer.String(), unexpected) {
+					t.Errorf("expected output not to contain %q, got:\n%s", unexpected, buffer.String())
+				}
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
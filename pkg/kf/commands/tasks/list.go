@@ -0,0 +1,98 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/api/meta/table"
+)
+
+// NewListTasksCommand allows users to list the tasks of an app.
+func NewListTasksCommand(p *config.KfParams, client tasks.Client) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "tasks APP_NAME",
+		Short: "List the one-off tasks of an app",
+		Example: `
+  kf tasks myapp
+  `,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			appName := args[0]
+			list, err := client.List(p.Namespace)
+			if err != nil {
+				return err
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 8, 4, 1, ' ', tabwriter.StripEscape)
+			defer w.Flush()
+
+			fmt.Fprintln(w, "Name\tAge\tState\tReason\tCommand")
+			for _, task := range list {
+				// Scheduled Tasks are listed by the jobs command.
+				if task.Spec.AppName != appName || task.Spec.IsScheduled() {
+					continue
+				}
+
+				fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s",
+					task.Name,
+					table.ConvertToHumanReadableDateType(task.CreationTimestamp),
+					taskState(task.Status),
+					taskReason(task.Status),
+					task.Spec.Command,
+				)
+				fmt.Fprintln(w)
+			}
+
+			return nil
+		},
+	}
+
+	return cmd
+}
+
+// taskState returns the CF name of the state of a Task.
+func taskState(status v1alpha1.TaskStatus) string {
+	finished, err := tasks.TaskStatus(v1alpha1.Task{Status: status})
+	switch {
+	case !finished:
+		return "RUNNING"
+	case err != nil:
+		return "FAILED"
+	default:
+		return "SUCCEEDED"
+	}
+}
+
+// taskReason returns why a Task is in its state.
+func taskReason(status v1alpha1.TaskStatus) string {
+	if cond := status.GetCondition(v1alpha1.TaskConditionSucceeded); cond != nil {
+		return cond.Reason
+	}
+
+	return ""
+}
@@ -0,0 +1,124 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestNewListTasksCommand(t *testing.T) {
+	t.Parallel()
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(t *testing.T, fakeTasks *fake.FakeClient)
+
+		wantErr           error
+		expectedStrings   []string
+		unexpectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{},
+			wantErr: errors.New("accepts 1 arg(s), received 0"),
+		},
+		"missing namespace": {
+			args:    []string{"my-app"},
+			wantErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		"contents": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				running := v1alpha1.Task{}
+				running.Name = "my-app-running"
+				running.Spec.AppName = "my-app"
+				running.Spec.Command = "sleep 1000"
+
+				failed := *finishedTask("my-app-failed", corev1.ConditionFalse)
+				failed.Spec.AppName = "my-app"
+
+				other := v1alpha1.Task{}
+				other.Name = "other-app-task"
+				other.Spec.AppName = "other-app"
+
+				scheduled := v1alpha1.Task{}
+				scheduled.Name = "my-app-scheduled"
+				scheduled.Spec.AppName = "my-app"
+				scheduled.Spec.Schedule = &v1alpha1.TaskSpecSchedule{Cron: "@daily"}
+
+				fakeTasks.
+					EXPECT().
+					List("my-ns").
+					Return([]v1alpha1.Task{running, failed, other, scheduled}, nil)
+			},
+			expectedStrings: []string{
+				"Name", "Age", "State", "Reason", "Command",
+				"my-app-running", "RUNNING", "sleep 1000",
+				"my-app-failed", "FAILED", "SomeReason",
+			},
+			unexpectedStrings: []string{"other-app-task", "my-app-scheduled"},
+		},
+		"server failure": {
+			args:      []string{"my-app"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.
+					EXPECT().
+					List("my-ns").
+					Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeTasks := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeTasks)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewListTasksCommand(&config.KfParams{Namespace: tc.namespace}, fakeTasks)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+			for _, unexpected := range tc.unexpectedStrings {
+				if strings.Contains(buffer.String(), unexpected) {
+					t.Errorf("expected output not to contain %q, got:\n%s", unexpected, buffer.String())
+				}
+			}
+
+			ctrl.Finish()
+		})
+	}
+}
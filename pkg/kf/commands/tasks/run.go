@@ -0,0 +1,152 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/apps"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/logs"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/wait"
+)
+
+// taskStatusTimeout is how long run-task waits for the Task to report its
+// result after the command's logs end.
+const taskStatusTimeout = time.Minute
+
+// NewRunTaskCommand allows users to run one-off commands against an app.
+func NewRunTaskCommand(
+	p *config.KfParams,
+	appsClient apps.Client,
+	tasksClient tasks.Client,
+	tailer logs.Tailer,
+) *cobra.Command {
+	var (
+		name  string
+		async bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "run-task APP_NAME COMMAND",
+		Short: "Run a one-off command with the image and environment of an app",
+		Long: `
+	Tasks run the command once in the app's latest image with the same
+	environment variables and service bindings as the app. The logs of the
+	command are streamed until it exits unless --async is given. Interrupting
+	the stream doesn't stop the task, use terminate-task to stop it.`,
+		Example: `
+  kf run-task myapp "rake db:migrate"
+  kf run-task myapp "bin/cleanup --dry-run" --name cleanup
+  kf run-task myapp "rake db:seed" --async
+  `,
+		Args: cobra.ExactArgs(2),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := utils.ValidateNamespace(p); err != nil {
+				return err
+			}
+
+			cmd.SilenceUsage = true
+
+			appName := args[0]
+			if _, err := appsClient.Get(p.Namespace, appName); err != nil {
+				return fmt.Errorf("failed to get app: %s", err)
+			}
+
+			task := &v1alpha1.Task{}
+			task.Namespace = p.Namespace
+			if name != "" {
+				task.Name = name
+			} else {
+				task.GenerateName = appName + "-"
+			}
+			task.Spec.AppName = appName
+			task.Spec.Command = args[1]
+
+			created, err := tasksClient.Create(p.Namespace, task)
+			if err != nil {
+				return fmt.Errorf("failed to create task: %s", err)
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Created task %q for app %q\n", created.Name, appName)
+			if async {
+				return nil
+			}
+
+			if err := tailer.Tail(
+				context.Background(),
+				appName,
+				cmd.OutOrStdout(),
+				logs.WithTailNamespace(p.Namespace),
+				logs.WithTailTask(created.Name),
+				logs.WithTailFollow(true),
+				logs.WithTailNumberLines(0),
+			); err != nil {
+				return fmt.Errorf("failed to tail logs: %s", err)
+			}
+
+			if err := waitForTask(tasksClient, p.Namespace, created.Name); err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Task %q succeeded\n", created.Name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(
+		&name,
+		"name",
+		"",
+		"Name of the task, generated from the app name if not given.",
+	)
+
+	cmd.Flags().BoolVar(
+		&async,
+		"async",
+		false,
+		"Don't wait for the task to finish and don't stream its logs.",
+	)
+
+	return cmd
+}
+
+// waitForTask waits for the Task to report that it finished and returns its
+// error if it didn't succeed.
+func waitForTask(client tasks.Client, namespace, name string) error {
+	var taskErr error
+	err := wait.PollImmediate(time.Second, taskStatusTimeout, func() (bool, error) {
+		task, err := client.Get(namespace, name)
+		if err != nil {
+			return false, err
+		}
+
+		finished, err := tasks.TaskStatus(*task)
+		taskErr = err
+		return finished, nil
+	})
+
+	if err != nil {
+		return fmt.Errorf("failed to get the result of task %s: %s", name, err)
+	}
+
+	return taskErr
+}
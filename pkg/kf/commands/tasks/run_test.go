@@ -0,0 +1,183 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	appsfake "github.com/google/kf/pkg/kf/apps/fake"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/commands/utils"
+	"github.com/google/kf/pkg/kf/logs"
+	logsfake "github.com/google/kf/pkg/kf/logs/fake"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"knative.dev/pkg/apis"
+)
+
+func finishedTask(name string, status corev1.ConditionStatus) *v1alpha1.Task {
+	task := &v1alpha1.Task{}
+	task.Name = name
+	task.Status.Conditions = []apis.Condition{{
+		Type:    v1alpha1.TaskConditionSucceeded,
+		Status:  status,
+		Reason:  "SomeReason",
+		Message: "some message",
+	}}
+	return task
+}
+
+func TestNewRunTaskCommand(t *testing.T) {
+	t.Parallel()
+
+	type fakes struct {
+		apps   *appsfake.FakeClient
+		tasks  *fake.FakeClient
+		tailer *logsfake.FakeTailer
+	}
+
+	expectCreate := func(t *testing.T, f fakes, wantName string) {
+		f.apps.EXPECT().Get("my-ns", "my-app").Return(&v1alpha1.App{}, nil)
+		f.tasks.
+			EXPECT().
+			Create("my-ns", gomock.Any()).
+			DoAndReturn(func(ns string, task *v1alpha1.Task, opts ...tasks.CreateOption) (*v1alpha1.Task, error) {
+				testutil.AssertEqual(t, "AppName", "my-app", task.Spec.AppName)
+				testutil.AssertEqual(t, "Command", "rake db:migrate", task.Spec.Command)
+				testutil.AssertEqual(t, "Name", wantName, task.Name)
+
+				created := task.DeepCopy()
+				if created.Name == "" {
+					created.Name = created.GenerateName + "x7k2p"
+				}
+				return created, nil
+			})
+	}
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(t *testing.T, f fakes)
+
+		wantErr         error
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{"my-app"},
+			wantErr: errors.New("accepts 2 arg(s), received 1"),
+		},
+		"missing namespace": {
+			args:    []string{"my-app", "rake db:migrate"},
+			wantErr: errors.New(utils.EmptyNamespaceError),
+		},
+		"app doesn't exist": {
+			args:      []string{"my-app", "rake db:migrate"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, f fakes) {
+				f.apps.EXPECT().Get("my-ns", "my-app").Return(nil, errors.New("not found"))
+			},
+			wantErr: errors.New("failed to get app: not found"),
+		},
+		"async": {
+			args:      []string{"my-app", "rake db:migrate", "--async", "--name", "migrate"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, f fakes) {
+				expectCreate(t, f, "migrate")
+			},
+			expectedStrings: []string{`Created task "migrate" for app "my-app"`},
+		},
+		"streams logs": {
+			args:      []string{"my-app", "rake db:migrate"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, f fakes) {
+				expectCreate(t, f, "")
+				f.tailer.
+					EXPECT().
+					Tail(gomock.Any(), "my-app", gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ interface{}, _ string, out io.Writer, opts ...logs.TailOption) error {
+						testutil.AssertEqual(t, "task", "my-app-x7k2p", logs.TailOptions(opts).Task())
+						testutil.AssertEqual(t, "follow", true, logs.TailOptions(opts).Follow())
+						_, err := io.WriteString(out, "Migrating...\n")
+						return err
+					})
+				f.tasks.
+					EXPECT().
+					Get("my-ns", "my-app-x7k2p").
+					Return(finishedTask("my-app-x7k2p", corev1.ConditionTrue), nil)
+			},
+			expectedStrings: []string{
+				`Created task "my-app-x7k2p" for app "my-app"`,
+				"Migrating...",
+				`Task "my-app-x7k2p" succeeded`,
+			},
+		},
+		"task fails": {
+			args:      []string{"my-app", "rake db:migrate"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, f fakes) {
+				expectCreate(t, f, "")
+				f.tailer.EXPECT().Tail(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+				f.tasks.
+					EXPECT().
+					Get("my-ns", "my-app-x7k2p").
+					Return(finishedTask("my-app-x7k2p", corev1.ConditionFalse), nil)
+			},
+			wantErr: errors.New("task failed for reason: SomeReason with message: some message"),
+		},
+		"create fails": {
+			args:      []string{"my-app", "rake db:migrate"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, f fakes) {
+				f.apps.EXPECT().Get("my-ns", "my-app").Return(&v1alpha1.App{}, nil)
+				f.tasks.EXPECT().Create("my-ns", gomock.Any()).Return(nil, errors.New("some-server-error"))
+			},
+			wantErr: errors.New("failed to create task: some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			f := fakes{
+				apps:   appsfake.NewFakeClient(ctrl),
+				tasks:  fake.NewFakeClient(ctrl),
+				tailer: logsfake.NewFakeTailer(ctrl),
+			}
+
+			if tc.setup != nil {
+				tc.setup(t, f)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewRunTaskCommand(&config.KfParams{Namespace: tc.namespace}, f.apps, f.tasks, f.tailer)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/commands/config"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/google/kf/pkg/kf/tasks/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestNewTerminateTaskCommand(t *testing.T) {
+	t.Parallel()
+
+	transformTask := func(t *testing.T, task *v1alpha1.Task) func(ns, name string, mutator tasks.Mutator) error {
+		return func(ns, name string, mutator tasks.Mutator) error {
+			if err := mutator(task); err != nil {
+				return err
+			}
+
+			testutil.AssertEqual(t, "terminated", true, task.Spec.Terminated)
+			return nil
+		}
+	}
+
+	cases := map[string]struct {
+		args      []string
+		namespace string
+		setup     func(t *testing.T, fakeTasks *fake.FakeClient)
+
+		wantErr         error
+		expectedStrings []string
+	}{
+		"invalid number of args": {
+			args:    []string{"my-app"},
+			wantErr: errors.New("accepts 2 arg(s), received 1"),
+		},
+		"missing namespace": {
+			args:    []string{"my-app", "my-task"},
+			wantErr: errors.New("no space targeted, use 'kf target --space SPACE' to target a space"),
+		},
+		"terminates the task": {
+			args:      []string{"my-app", "my-task"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				task := &v1alpha1.Task{}
+				task.Spec.AppName = "my-app"
+
+				fakeTasks.
+					EXPECT().
+					Transform("my-ns", "my-task", gomock.Any()).
+					DoAndReturn(transformTask(t, task))
+			},
+			expectedStrings: []string{`Terminating task "my-task"`},
+		},
+		"task of another app": {
+			args:      []string{"my-app", "my-task"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				task := &v1alpha1.Task{}
+				task.Spec.AppName = "other-app"
+
+				fakeTasks.
+					EXPECT().
+					Transform("my-ns", "my-task", gomock.Any()).
+					DoAndReturn(transformTask(t, task))
+			},
+			wantErr: errors.New("failed to terminate task: task my-task doesn't belong to app my-app"),
+		},
+		"server failure": {
+			args:      []string{"my-app", "my-task"},
+			namespace: "my-ns",
+			setup: func(t *testing.T, fakeTasks *fake.FakeClient) {
+				fakeTasks.
+					EXPECT().
+					Transform("my-ns", "my-task", gomock.Any()).
+					Return(errors.New("some-server-error"))
+			},
+			wantErr: errors.New("failed to terminate task: some-server-error"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			fakeTasks := fake.NewFakeClient(ctrl)
+
+			if tc.setup != nil {
+				tc.setup(t, fakeTasks)
+			}
+
+			buffer := &bytes.Buffer{}
+
+			c := NewTerminateTaskCommand(&config.KfParams{Namespace: tc.namespace}, fakeTasks)
+			c.SetOutput(buffer)
+			c.SetArgs(tc.args)
+
+			gotErr := c.Execute()
+			testutil.AssertErrorsEqual(t, tc.wantErr, gotErr)
+			testutil.AssertContainsAll(t, buffer.String(), tc.expectedStrings)
+
+			ctrl.Finish()
+		})
+	}
+}
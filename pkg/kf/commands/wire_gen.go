@@ -21,6 +21,7 @@ import (
 	servicebindings2 "github.com/google/kf/pkg/kf/commands/service-bindings"
 	services2 "github.com/google/kf/pkg/kf/commands/services"
 	spaces2 "github.com/google/kf/pkg/kf/commands/spaces"
+	tasks2 "github.com/google/kf/pkg/kf/commands/tasks"
 	"github.com/google/kf/pkg/kf/logs"
 	"github.com/google/kf/pkg/kf/routes"
 	"github.com/google/kf/pkg/kf/service-bindings"
@@ -28,6 +29,7 @@ import (
 	"github.com/google/kf/pkg/kf/sources"
 	"github.com/google/kf/pkg/kf/spaces"
 	"github.com/google/kf/pkg/kf/systemenvinjector"
+	"github.com/google/kf/pkg/kf/tasks"
 	"github.com/google/wire"
 	logs2 "github.com/knative/build/pkg/logs"
 	"github.com/poy/kontext"
@@ -55,7 +57,9 @@ func InjectPush(p *config.KfParams) *cobra.Command {
 	servicecatalogV1beta1Interface := config.GetServiceCatalogClient(p)
 	clientInterface := config.GetSecretClient(p)
 	servicebindingsClientInterface := servicebindings.NewClient(servicecatalogV1beta1Interface, clientInterface)
-	command := apps2.NewPushCommand(p, appsClient, pusher, srcImageBuilder, servicebindingsClientInterface)
+	buildpacksClient := InjectBuildpacksClient(p)
+	tasksClient := tasks.NewClient(kfV1alpha1Interface)
+	command := apps2.NewPushCommand(p, appsClient, pusher, srcImageBuilder, servicebindingsClientInterface, buildpacksClient, tasksClient)
 	return command
 }
 
@@ -155,6 +159,42 @@ func InjectRestage(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectAppHistory(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	command := apps2.NewAppHistoryCommand(p, appsClient)
+	return command
+}
+
+func InjectPromote(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	command := apps2.NewPromoteCommand(p, appsClient)
+	return command
+}
+
+func InjectRollback(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	command := apps2.NewRollbackCommand(p, appsClient)
+	return command
+}
+
 func InjectProxy(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	appsGetter := provideAppsGetter(kfV1alpha1Interface)
@@ -418,6 +458,42 @@ func InjectUnmapRoute(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectAddNetworkPolicy(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	command := routes2.NewAddNetworkPolicyCommand(p, appsClient)
+	return command
+}
+
+func InjectRemoveNetworkPolicy(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	command := routes2.NewRemoveNetworkPolicyCommand(p, appsClient)
+	return command
+}
+
+func InjectNetworkPolicies(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	command := routes2.NewNetworkPoliciesCommand(p, appsClient)
+	return command
+}
+
 func InjectBuilds(p *config.KfParams) *cobra.Command {
 	kfV1alpha1Interface := config.GetKfClient(p)
 	sourcesGetter := provideKfSources(kfV1alpha1Interface)
@@ -436,6 +512,84 @@ func InjectBuildLogs(p *config.KfParams) *cobra.Command {
 	return command
 }
 
+func InjectCancelBuild(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	command := builds.NewCancelBuildCommand(p, client)
+	return command
+}
+
+func InjectClearBuildCache(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	coreV1Interface := provideCoreV1(p)
+	command := builds.NewClearBuildCacheCommand(p, appsClient, coreV1Interface)
+	return command
+}
+
+func InjectRunTask(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	tasksClient := tasks.NewClient(kfV1alpha1Interface)
+	coreV1Interface := provideCoreV1(p)
+	tailer := logs.NewTailer(coreV1Interface)
+	command := tasks2.NewRunTaskCommand(p, appsClient, tasksClient, tailer)
+	return command
+}
+
+func InjectTasks(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := tasks.NewClient(kfV1alpha1Interface)
+	command := tasks2.NewListTasksCommand(p, client)
+	return command
+}
+
+func InjectTerminateTask(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := tasks.NewClient(kfV1alpha1Interface)
+	command := tasks2.NewTerminateTaskCommand(p, client)
+	return command
+}
+
+func InjectCreateJob(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	appsGetter := provideAppsGetter(kfV1alpha1Interface)
+	systemEnvInjectorInterface := provideSystemEnvInjector(p)
+	sourcesGetter := provideKfSources(kfV1alpha1Interface)
+	buildTailer := provideSourcesBuildTailer()
+	client := sources.NewClient(sourcesGetter, buildTailer)
+	appsClient := apps.NewClient(appsGetter, systemEnvInjectorInterface, client)
+	tasksClient := tasks.NewClient(kfV1alpha1Interface)
+	command := tasks2.NewCreateJobCommand(p, appsClient, tasksClient)
+	return command
+}
+
+func InjectJobs(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := tasks.NewClient(kfV1alpha1Interface)
+	command := tasks2.NewListJobsCommand(p, client)
+	return command
+}
+
+func InjectDeleteJob(p *config.KfParams) *cobra.Command {
+	kfV1alpha1Interface := config.GetKfClient(p)
+	client := tasks.NewClient(kfV1alpha1Interface)
+	command := tasks2.NewDeleteJobCommand(p, client)
+	return command
+}
+
 // wire_injector.go:
 
 func provideSrcImageBuilder() apps2.SrcImageBuilder {
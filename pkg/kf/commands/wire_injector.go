@@ -32,6 +32,7 @@ import (
 	servicebindingscmd "github.com/google/kf/pkg/kf/commands/service-bindings"
 	servicescmd "github.com/google/kf/pkg/kf/commands/services"
 	cspaces "github.com/google/kf/pkg/kf/commands/spaces"
+	ctasks "github.com/google/kf/pkg/kf/commands/tasks"
 	kflogs "github.com/google/kf/pkg/kf/logs"
 	"github.com/google/kf/pkg/kf/routes"
 	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
@@ -39,6 +40,7 @@ import (
 	"github.com/google/kf/pkg/kf/sources"
 	"github.com/google/kf/pkg/kf/spaces"
 	"github.com/google/kf/pkg/kf/systemenvinjector"
+	"github.com/google/kf/pkg/kf/tasks"
 	"github.com/google/wire"
 	"github.com/knative/build/pkg/logs"
 	"github.com/poy/kontext"
@@ -77,6 +79,8 @@ func InjectPush(p *config.KfParams) *cobra.Command {
 		servicebindings.NewClient,
 		config.GetServiceCatalogClient,
 		config.GetSecretClient,
+		InjectBuildpacksClient,
+		tasks.NewClient,
 		AppsSet,
 	)
 	return nil
@@ -125,6 +129,21 @@ func InjectRestage(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectAppHistory(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewAppHistoryCommand, AppsSet)
+	return nil
+}
+
+func InjectPromote(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewPromoteCommand, AppsSet)
+	return nil
+}
+
+func InjectRollback(p *config.KfParams) *cobra.Command {
+	wire.Build(capps.NewRollbackCommand, AppsSet)
+	return nil
+}
+
 func InjectProxy(p *config.KfParams) *cobra.Command {
 	wire.Build(
 		capps.NewProxyCommand,
@@ -419,6 +438,21 @@ func InjectUnmapRoute(p *config.KfParams) *cobra.Command {
 	return nil
 }
 
+func InjectAddNetworkPolicy(p *config.KfParams) *cobra.Command {
+	wire.Build(croutes.NewAddNetworkPolicyCommand, AppsSet)
+	return nil
+}
+
+func InjectRemoveNetworkPolicy(p *config.KfParams) *cobra.Command {
+	wire.Build(croutes.NewRemoveNetworkPolicyCommand, AppsSet)
+	return nil
+}
+
+func InjectNetworkPolicies(p *config.KfParams) *cobra.Command {
+	wire.Build(croutes.NewNetworkPoliciesCommand, AppsSet)
+	return nil
+}
+
 ////////////////////
 // Builds Command //
 ////////////////////
@@ -444,3 +478,67 @@ func InjectBuildLogs(p *config.KfParams) *cobra.Command {
 
 	return nil
 }
+
+func InjectCancelBuild(p *config.KfParams) *cobra.Command {
+	wire.Build(cbuilds.NewCancelBuildCommand, SourcesSet)
+
+	return nil
+}
+
+func InjectClearBuildCache(p *config.KfParams) *cobra.Command {
+	wire.Build(cbuilds.NewClearBuildCacheCommand, AppsSet, provideCoreV1)
+
+	return nil
+}
+
+///////////////////
+// Task Commands //
+///////////////////
+
+var TasksSet = wire.NewSet(config.GetKfClient, tasks.NewClient)
+
+func InjectRunTask(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		ctasks.NewRunTaskCommand,
+		AppsSet,
+		tasks.NewClient,
+		kflogs.NewTailer,
+		provideCoreV1,
+	)
+
+	return nil
+}
+
+func InjectTasks(p *config.KfParams) *cobra.Command {
+	wire.Build(ctasks.NewListTasksCommand, TasksSet)
+
+	return nil
+}
+
+func InjectTerminateTask(p *config.KfParams) *cobra.Command {
+	wire.Build(ctasks.NewTerminateTaskCommand, TasksSet)
+
+	return nil
+}
+
+func InjectCreateJob(p *config.KfParams) *cobra.Command {
+	wire.Build(
+		ctasks.NewCreateJobCommand,
+		AppsSet,
+		tasks.NewClient,
+	)
+
+	return nil
+}
+
+func InjectJobs(p *config.KfParams) *cobra.Command {
+	wire.Build(ctasks.NewListJobsCommand, TasksSet)
+
+	return nil
+}
+
+func InjectDeleteJob(p *config.KfParams) *cobra.Command {
+	wire.Build(ctasks.NewDeleteJobCommand, TasksSet)
+
+	return nil
+}
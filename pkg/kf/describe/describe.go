@@ -157,6 +157,8 @@ func SourceSpec(w io.Writer, spec kfv1alpha1.SourceSpec) {
 			fmt.Fprintln(w, "Build Type:\tcontainer")
 		case spec.IsBuildpackBuild():
 			fmt.Fprintln(w, "Build Type:\tbuildpack")
+		case spec.IsDockerfileBuild():
+			fmt.Fprintln(w, "Build Type:\tdockerfile")
 		default:
 			fmt.Fprintln(w, "Build Type:\tunknown")
 		}
@@ -177,13 +179,98 @@ func SourceSpec(w io.Writer, spec kfv1alpha1.SourceSpec) {
 			SectionWriter(w, "Buildpack Build", func(w io.Writer) {
 				buildpackBuild := spec.BuildpackBuild
 
-				fmt.Fprintf(w, "Source:\t%s\n", buildpackBuild.Source)
+				if buildpackBuild.Git != nil {
+					GitSource(w, buildpackBuild.Git)
+				} else {
+					fmt.Fprintf(w, "Source:\t%s\n", buildpackBuild.Source)
+				}
 				fmt.Fprintf(w, "Stack:\t%s\n", buildpackBuild.Stack)
 				fmt.Fprintf(w, "Bulider:\t%s\n", buildpackBuild.BuildpackBuilder)
 				fmt.Fprintf(w, "Registry:\t%s\n", buildpackBuild.Registry)
+				if buildpackBuild.CacheClaim != "" {
+					fmt.Fprintf(w, "Cache:\t%s\n", buildpackBuild.CacheClaim)
+				}
 				EnvVars(w, buildpackBuild.Env)
 			})
 		}
+
+		if spec.IsDockerfileBuild() {
+			SectionWriter(w, "Dockerfile Build", func(w io.Writer) {
+				dockerfile := spec.Dockerfile
+
+				if dockerfile.Git != nil {
+					GitSource(w, dockerfile.Git)
+				} else {
+					fmt.Fprintf(w, "Source:\t%s\n", dockerfile.Source)
+				}
+				fmt.Fprintf(w, "Path:\t%s\n", dockerfile.Path)
+				if dockerfile.Target != "" {
+					fmt.Fprintf(w, "Target:\t%s\n", dockerfile.Target)
+				}
+				fmt.Fprintf(w, "Registry:\t%s\n", dockerfile.Registry)
+
+				SectionWriter(w, "Build Args", func(w io.Writer) {
+					var names []string
+					for name := range dockerfile.BuildArgs {
+						names = append(names, name)
+					}
+					sort.Strings(names)
+
+					for _, name := range names {
+						fmt.Fprintf(w, "%s:\t%s\n", name, dockerfile.BuildArgs[name])
+					}
+				})
+			})
+		}
+	})
+}
+
+// GitSource describes source code that is cloned from a git repository.
+func GitSource(w io.Writer, git *kfv1alpha1.SourceSpecGit) {
+
+	SectionWriter(w, "Git", func(w io.Writer) {
+		fmt.Fprintf(w, "URL:\t%s\n", git.URL)
+		if git.Revision != "" {
+			fmt.Fprintf(w, "Revision:\t%s\n", git.Revision)
+		}
+		if git.SubPath != "" {
+			fmt.Fprintf(w, "Sub Path:\t%s\n", git.SubPath)
+		}
+		if git.CredentialsSecret != "" {
+			fmt.Fprintf(w, "Credentials Secret:\t%s\n", git.CredentialsSecret)
+		}
+	})
+}
+
+// SourceProvenance describes what the latest image was built from.
+func SourceProvenance(w io.Writer, status kfv1alpha1.SourceStatusFields) {
+
+	SectionWriter(w, "Provenance", func(w io.Writer) {
+		if status.ImageDigest != "" {
+			fmt.Fprintf(w, "Image Digest:\t%s\n", status.ImageDigest)
+		}
+
+		provenance := status.Provenance
+		if provenance == nil {
+			return
+		}
+
+		if provenance.BuilderImage != "" {
+			fmt.Fprintf(w, "Builder:\t%s\n", provenance.BuilderImage)
+			fmt.Fprintf(w, "Builder Digest:\t%s\n", provenance.BuilderImageDigest)
+		}
+		if provenance.SourceImage != "" {
+			fmt.Fprintf(w, "Source Image:\t%s\n", provenance.SourceImage)
+			fmt.Fprintf(w, "Source Digest:\t%s\n", provenance.SourceImageDigest)
+		}
+
+		if len(provenance.Buildpacks) > 0 {
+			SectionWriter(w, "Buildpacks", func(w io.Writer) {
+				for _, buildpack := range provenance.Buildpacks {
+					fmt.Fprintf(w, "%s:\t%s\n", buildpack.ID, buildpack.Version)
+				}
+			})
+		}
 	})
 }
 
@@ -213,6 +300,30 @@ func AppSpecInstances(w io.Writer, instances kfv1alpha1.AppSpecInstances) {
 	})
 }
 
+// AppRollout describes how traffic is sent to the revisions of the app.
+func AppRollout(w io.Writer, spec kfv1alpha1.AppSpecRollout, status kfv1alpha1.AppStatusRollout) {
+
+	SectionWriter(w, "Rollout", func(w io.Writer) {
+		fmt.Fprintf(w, "Strategy:\t%s\n", spec.Strategy)
+
+		if status.Phase != "" {
+			fmt.Fprintf(w, "Phase:\t%s\n", status.Phase)
+		}
+
+		if status.StableRevisionName != "" {
+			fmt.Fprintf(w, "Stable Revision:\t%s\n", status.StableRevisionName)
+		}
+
+		if status.CanaryRevisionName != "" {
+			fmt.Fprintf(w, "Canary Revision:\t%s\n", status.CanaryRevisionName)
+		}
+
+		if status.IsCanaryActive() {
+			fmt.Fprintf(w, "Canary Percent:\t%d%%\n", status.CanaryPercent)
+		}
+	})
+}
+
 // HealthCheck prints a Readiness Probe in a friendly manner
 func HealthCheck(w io.Writer, healthCheck *corev1.Probe) {
 	SectionWriter(w, "Health Check", func(w io.Writer) {
@@ -234,3 +345,26 @@ func HealthCheck(w io.Writer, healthCheck *corev1.Probe) {
 		}
 	})
 }
+
+// Resources prints the compute resources requested and limited for each
+// instance of an App.
+func Resources(w io.Writer, resources corev1.ResourceRequirements) {
+	SectionWriter(w, "Resources", func(w io.Writer) {
+		for _, resource := range []struct {
+			label string
+			name  corev1.ResourceName
+		}{
+			{"Memory", corev1.ResourceMemory},
+			{"Disk", corev1.ResourceEphemeralStorage},
+			{"CPU", corev1.ResourceCPU},
+		} {
+			if quantity, ok := resources.Requests[resource.name]; ok {
+				fmt.Fprintf(w, "%s:\t%s\n", resource.label, quantity.String())
+			}
+
+			if quantity, ok := resources.Limits[resource.name]; ok {
+				fmt.Fprintf(w, "%s Limit:\t%s\n", resource.label, quantity.String())
+			}
+		}
+	})
+}
@@ -24,6 +24,7 @@ import (
 	"github.com/google/kf/pkg/kf/testutil"
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"knative.dev/pkg/apis"
 	duckv1beta1 "knative.dev/pkg/apis/duck/v1beta1"
@@ -279,6 +280,143 @@ func ExampleSourceSpec_docker() {
 	//     Image:  mysql/mysql
 }
 
+func ExampleSourceSpec_dockerfile() {
+	spec := kfv1alpha1.SourceSpec{
+		ServiceAccount: "builder-account",
+		Dockerfile: kfv1alpha1.SourceSpecDockerfile{
+			Source:   "gcr.io/my-registry/src-mysource",
+			Path:     "build/Dockerfile",
+			Target:   "release",
+			Registry: "gcr.io/my-registry",
+			BuildArgs: map[string]string{
+				"VERSION": "1.0",
+				"MOTD":    "hello",
+			},
+		},
+	}
+
+	describe.SourceSpec(os.Stdout, spec)
+
+	// Output: Source:
+	//   Build Type:       dockerfile
+	//   Service Account:  builder-account
+	//   Dockerfile Build:
+	//     Source:    gcr.io/my-registry/src-mysource
+	//     Path:      build/Dockerfile
+	//     Target:    release
+	//     Registry:  gcr.io/my-registry
+	//     Build Args:
+	//       MOTD:     hello
+	//       VERSION:  1.0
+}
+
+func ExampleSourceSpec_git() {
+	spec := kfv1alpha1.SourceSpec{
+		BuildpackBuild: kfv1alpha1.SourceSpecBuildpackBuild{
+			Git: &kfv1alpha1.SourceSpecGit{
+				URL:               "https://github.com/google/kf.git",
+				Revision:          "master",
+				SubPath:           "samples/apps/helloworld",
+				CredentialsSecret: "git-creds",
+			},
+			Stack:            "cflinuxfs3",
+			BuildpackBuilder: "gcr.io/my-registry/my-builder:latest",
+			Registry:         "gcr.io/my-registry",
+		},
+	}
+
+	describe.SourceSpec(os.Stdout, spec)
+
+	// Output: Source:
+	//   Build Type:  buildpack
+	//   Buildpack Build:
+	//     Git:
+	//       URL:                 https://github.com/google/kf.git
+	//       Revision:            master
+	//       Sub Path:            samples/apps/helloworld
+	//       Credentials Secret:  git-creds
+	//     Stack:     cflinuxfs3
+	//     Bulider:   gcr.io/my-registry/my-builder:latest
+	//     Registry:  gcr.io/my-registry
+	//     Environment: <empty>
+}
+
+func ExampleSourceProvenance() {
+	status := kfv1alpha1.SourceStatusFields{
+		Image:       "gcr.io/my-project/app-my-space-my-app:3",
+		ImageDigest: "sha256:4c1e9a",
+		Provenance: &kfv1alpha1.SourceProvenance{
+			BuilderImage:       "gcr.io/kf-releases/buildpack-builder:latest",
+			BuilderImageDigest: "sha256:8d2f07",
+			SourceImage:        "gcr.io/my-project/src-my-space-my-app",
+			SourceImageDigest:  "sha256:b75e31",
+			Buildpacks: []kfv1alpha1.SourceProvenanceBuildpack{
+				{ID: "org.cloudfoundry.nodejs", Version: "0.0.2"},
+				{ID: "org.cloudfoundry.python", Version: "0.1.0"},
+			},
+		},
+	}
+
+	describe.SourceProvenance(os.Stdout, status)
+
+	// Output: Provenance:
+	//   Image Digest:    sha256:4c1e9a
+	//   Builder:         gcr.io/kf-releases/buildpack-builder:latest
+	//   Builder Digest:  sha256:8d2f07
+	//   Source Image:    gcr.io/my-project/src-my-space-my-app
+	//   Source Digest:   sha256:b75e31
+	//   Buildpacks:
+	//     org.cloudfoundry.nodejs:  0.0.2
+	//     org.cloudfoundry.python:  0.1.0
+}
+
+func ExampleSourceProvenance_unresolved() {
+	describe.SourceProvenance(os.Stdout, kfv1alpha1.SourceStatusFields{
+		Image: "gcr.io/my-project/app-my-space-my-app:3",
+	})
+
+	// Output: Provenance: <empty>
+}
+
+func ExampleAppRollout_immediate() {
+	spec := kfv1alpha1.AppSpecRollout{
+		Strategy: kfv1alpha1.RolloutStrategyImmediate,
+	}
+	status := kfv1alpha1.AppStatusRollout{
+		Phase:              kfv1alpha1.RolloutPhaseStable,
+		StableRevisionName: "myapp-00001",
+	}
+
+	describe.AppRollout(os.Stdout, spec, status)
+
+	// Output: Rollout:
+	//   Strategy:         Immediate
+	//   Phase:            Stable
+	//   Stable Revision:  myapp-00001
+}
+
+func ExampleAppRollout_canary() {
+	spec := kfv1alpha1.AppSpecRollout{
+		Strategy:      kfv1alpha1.RolloutStrategyCanary,
+		CanaryPercent: 10,
+	}
+	status := kfv1alpha1.AppStatusRollout{
+		Phase:              kfv1alpha1.RolloutPhaseCanary,
+		StableRevisionName: "myapp-00001",
+		CanaryRevisionName: "myapp-00002",
+		CanaryPercent:      10,
+	}
+
+	describe.AppRollout(os.Stdout, spec, status)
+
+	// Output: Rollout:
+	//   Strategy:         Canary
+	//   Phase:            Canary
+	//   Stable Revision:  myapp-00001
+	//   Canary Revision:  myapp-00002
+	//   Canary Percent:   10%
+}
+
 func ExampleHealthCheck_nil() {
 	describe.HealthCheck(os.Stdout, nil)
 
@@ -311,3 +449,20 @@ func ExampleHealthCheck_tcp() {
 	//   Timeout:  42s
 	//   Type:     port (tcp)
 }
+
+func ExampleResources() {
+	describe.Resources(os.Stdout, corev1.ResourceRequirements{
+		Requests: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+			corev1.ResourceCPU:    resource.MustParse("500m"),
+		},
+		Limits: corev1.ResourceList{
+			corev1.ResourceMemory: resource.MustParse("512Mi"),
+		},
+	})
+
+	// Output: Resources:
+	//   Memory:        512Mi
+	//   Memory Limit:  512Mi
+	//   CPU:           500m
+}
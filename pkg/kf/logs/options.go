@@ -23,6 +23,8 @@ type tailConfig struct {
 	Namespace string
 	// NumberLines is number of lines
 	NumberLines int
+	// Task is the name of the App's Task to read the logs of instead of the App's
+	Task string
 }
 
 // TailOption is a single option for configuring a tailConfig
@@ -69,6 +71,12 @@ func (opts TailOptions) NumberLines() int {
 	return opts.toConfig().NumberLines
 }
 
+// Task returns the last set value for Task or the empty value
+// if not set.
+func (opts TailOptions) Task() string {
+	return opts.toConfig().Task
+}
+
 // WithTailFollow creates an Option that sets stream the logs
 func WithTailFollow(val bool) TailOption {
 	return func(cfg *tailConfig) {
@@ -90,6 +98,13 @@ func WithTailNumberLines(val int) TailOption {
 	}
 }
 
+// WithTailTask creates an Option that sets the name of the App's Task to read the logs of instead of the App's
+func WithTailTask(val string) TailOption {
+	return func(cfg *tailConfig) {
+		cfg.Task = val
+	}
+}
+
 // TailOptionDefaults gets the default values for Tail.
 func TailOptionDefaults() TailOptions {
 	return TailOptions{
@@ -22,6 +22,7 @@ import (
 	"log"
 	"time"
 
+	kfv1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/watch"
@@ -72,15 +73,21 @@ func (t *tailer) Tail(ctx context.Context, appName string, out io.Writer, opts .
 		logOpts.TailLines = &(n)
 	}
 
-	if err := t.watchForPods(ctx, namespace, appName, out, logOpts); err != nil {
+	selector := "serving.knative.dev/service=" + appName
+	if cfg.Task != "" {
+		// Task Pods run the command once so their logs end when it exits.
+		selector = kfv1alpha1.TaskNameLabel + "=" + cfg.Task
+	}
+
+	if err := t.watchForPods(ctx, namespace, selector, cfg.Task != "", out, logOpts); err != nil {
 		return fmt.Errorf("failed to watch pods: %s", err)
 	}
 	return nil
 }
 
-func (t *tailer) watchForPods(ctx context.Context, namespace, appName string, out io.Writer, opts v1.PodLogOptions) error {
+func (t *tailer) watchForPods(ctx context.Context, namespace, selector string, runsOnce bool, out io.Writer, opts v1.PodLogOptions) error {
 	w, err := t.client.Pods(namespace).Watch(metav1.ListOptions{
-		LabelSelector: "serving.knative.dev/service=" + appName,
+		LabelSelector: selector,
 	})
 	if err != nil {
 		return err
@@ -98,6 +105,10 @@ func (t *tailer) watchForPods(ctx context.Context, namespace, appName string, ou
 	ctx, cancel := context.WithCancel(ctx)
 	defer cancel()
 
+	// Logs can't be read until the Pod's containers start, Pods are
+	// followed from the first event that they're no longer pending.
+	reading := make(map[string]bool)
+
 	for {
 		select {
 		case <-ctx.Done():
@@ -106,24 +117,31 @@ func (t *tailer) watchForPods(ctx context.Context, namespace, appName string, ou
 			// Time out waiting for a log.
 			return nil
 		case e := <-w.ResultChan():
-			if e.Type != watch.Added {
+			if e.Type != watch.Added && e.Type != watch.Modified {
 				continue
 			}
-			go func(e watch.Event) {
-				t.readLogs(ctx, e.Object.(*v1.Pod).Name, namespace, out, opts)
+
+			pod := e.Object.(*v1.Pod)
+			if pod.Status.Phase == v1.PodPending || reading[pod.Name] {
+				continue
+			}
+			reading[pod.Name] = true
+
+			go func(pod *v1.Pod) {
+				t.readLogs(ctx, pod.Name, namespace, runsOnce, out, opts)
 				cancel()
-			}(e)
+			}(pod)
 		}
 	}
 }
 
-func (t *tailer) readLogs(ctx context.Context, name, namespace string, out io.Writer, opts v1.PodLogOptions) {
+func (t *tailer) readLogs(ctx context.Context, name, namespace string, runsOnce bool, out io.Writer, opts v1.PodLogOptions) {
 	for ctx.Err() == nil {
 		if err := t.readStream(ctx, name, namespace, out, opts); err != nil {
 			log.Printf("[WARN] %s", err)
 		}
 
-		if !opts.Follow {
+		if !opts.Follow || runsOnce {
 			return
 		}
 	}
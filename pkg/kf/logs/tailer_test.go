@@ -93,6 +93,20 @@ func TestTailer_Tail(t *testing.T) {
 				}))
 			},
 		},
+		"uses task selector": {
+			AppName: "some-app",
+			Opts: []logs.TailOption{
+				logs.WithTailTask("some-task"),
+			},
+			Setup: func(t *testing.T, fake *fake.FakeCoreV1) {
+				fake.AddWatchReactor("*", ktesting.WatchReactionFunc(func(action ktesting.Action) (handled bool, ret watch.Interface, err error) {
+					labels := action.(ktesting.WatchActionImpl).WatchRestrictions.Labels
+					testutil.AssertEqual(t, "labels", "kf.dev/task=some-task", labels.String())
+
+					return false, nil, nil
+				}))
+			},
+		},
 		"writes logs to the writer": {
 			AppName: "some-app",
 			Setup: func(t *testing.T, fake *fake.FakeCoreV1) {
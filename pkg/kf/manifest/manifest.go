@@ -33,11 +33,31 @@ type Application struct {
 	Name       string            `yaml:"name,omitempty"`
 	Path       string            `yaml:"path,omitempty"`
 	Buildpacks []string          `yaml:"buildpacks,omitempty"`
+	Stack      string            `yaml:"stack,omitempty"`
 	Docker     AppDockerImage    `yaml:"docker,omitempty"`
 	Env        map[string]string `yaml:"env,omitempty"`
 	Services   []string          `yaml:"services,omitempty"`
 	Instances  *int              `yaml:"instances,omitempty"`
 
+	// Memory and DiskQuota are the memory and disk of each instance, e.g.
+	// 512M or 1G.
+	Memory    string `yaml:"memory,omitempty"`
+	DiskQuota string `yaml:"disk_quota,omitempty"`
+
+	// CPU is the number of cores requested for each instance, e.g. 0.5 or
+	// 500m. This isn't CF proper, CloudFoundry allocates CPU proportional to
+	// memory.
+	CPU string `yaml:"cpu,omitempty"`
+
+	// Command is the shell command that starts the application instead of
+	// the buildpack's or image's default process.
+	Command string `yaml:"command,omitempty"`
+
+	// Args are passed to the container's entrypoint. These aren't CF proper,
+	// they're for container images that expect arguments rather than a
+	// command.
+	Args []string `yaml:"args,omitempty"`
+
 	// TODO(#95): These aren't CF proper. How do we expose these in the
 	// manifest?
 	MinScale *int `yaml:"min-scale,omitempty"`
@@ -58,6 +78,38 @@ type Application struct {
 	// HealthCheckHTTPEndpoint holds the HTTP endpoint that will receive the
 	// get requests to determine liveness if HealthCheckType is http.
 	HealthCheckHTTPEndpoint string `yaml:"health-check-http-endpoint,omitempty"`
+
+	// Jobs holds commands run against the application on a schedule.
+	Jobs []Job `yaml:"jobs,omitempty"`
+
+	// Processes holds commands that run alongside the web process, such as
+	// workers. They're also read from the application's Procfile.
+	Processes []Process `yaml:"processes,omitempty"`
+}
+
+// Process is a long running command of an application that doesn't receive
+// traffic.
+type Process struct {
+	Type      string `yaml:"type,omitempty"`
+	Command   string `yaml:"command,omitempty"`
+	Instances *int   `yaml:"instances,omitempty"`
+	Memory    string `yaml:"memory,omitempty"`
+	DiskQuota string `yaml:"disk_quota,omitempty"`
+	CPU       string `yaml:"cpu,omitempty"`
+}
+
+// Job is a command run against an application on a Cron schedule.
+type Job struct {
+	Name     string `yaml:"name,omitempty"`
+	Command  string `yaml:"command,omitempty"`
+	Schedule string `yaml:"schedule,omitempty"`
+
+	// ConcurrencyPolicy is one of Allow, Forbid or Replace, blank means
+	// Allow.
+	ConcurrencyPolicy string `yaml:"concurrency-policy,omitempty"`
+
+	SuccessfulHistoryLimit *int32 `yaml:"successful-history-limit,omitempty"`
+	FailedHistoryLimit     *int32 `yaml:"failed-history-limit,omitempty"`
 }
 
 // AppDockerImage is the struct for docker configuration.
@@ -44,6 +44,21 @@ applications:
 				},
 			},
 		},
+		"stack": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  stack: gcr.io/my-company/run:bionic
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name:  "MY-APP",
+						Stack: "gcr.io/my-company/run:bionic",
+					},
+				},
+			},
+		},
 		"docker": {
 			fileContent: `---
 applications:
@@ -62,6 +77,94 @@ applications:
 				},
 			},
 		},
+		"jobs": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  jobs:
+  - name: cleanup
+    command: rake db:cleanup
+    schedule: "0 3 * * *"
+    concurrency-policy: Forbid
+    failed-history-limit: 5
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name: "MY-APP",
+						Jobs: []manifest.Job{
+							{
+								Name:               "cleanup",
+								Command:            "rake db:cleanup",
+								Schedule:           "0 3 * * *",
+								ConcurrencyPolicy:  "Forbid",
+								FailedHistoryLimit: int32Ptr(5),
+							},
+						},
+					},
+				},
+			},
+		},
+		"command": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  command: bundle exec rails server -p $PORT
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name:    "MY-APP",
+						Command: "bundle exec rails server -p $PORT",
+					},
+				},
+			},
+		},
+		"resources": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  memory: 512M
+  disk_quota: 1G
+  cpu: 500m
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name:      "MY-APP",
+						Memory:    "512M",
+						DiskQuota: "1G",
+						CPU:       "500m",
+					},
+				},
+			},
+		},
+		"processes": {
+			fileContent: `---
+applications:
+- name: MY-APP
+  processes:
+  - type: worker
+    command: bundle exec sidekiq
+    instances: 2
+    memory: 256M
+`,
+			expected: &manifest.Manifest{
+				Applications: []manifest.Application{
+					{
+						Name: "MY-APP",
+						Processes: []manifest.Process{
+							{
+								Type:      "worker",
+								Command:   "bundle exec sidekiq",
+								Instances: intPtr(2),
+								Memory:    "256M",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for tn, tc := range cases {
@@ -178,3 +281,11 @@ func ExampleApplication_Buildpack() {
 	// Output: One: java
 	// Two: maven,java
 }
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
+
+func intPtr(i int) *int {
+	return &i
+}
@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Procfile maps the process types of an application to their commands.
+type Procfile map[string]string
+
+// NewProcfileFromReader creates a Procfile from a reader. Each line of a
+// Procfile has the form TYPE: COMMAND, blank lines and lines starting with #
+// are ignored.
+func NewProcfileFromReader(reader io.Reader) (Procfile, error) {
+	procfile := Procfile{}
+
+	scanner := bufio.NewScanner(reader)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("Procfile line %d: expected TYPE: COMMAND", lineNumber)
+		}
+
+		processType := strings.TrimSpace(parts[0])
+		command := strings.TrimSpace(parts[1])
+		if processType == "" || command == "" {
+			return nil, fmt.Errorf("Procfile line %d: expected TYPE: COMMAND", lineNumber)
+		}
+
+		if _, ok := procfile[processType]; ok {
+			return nil, fmt.Errorf("Procfile line %d: duplicate process type %q", lineNumber, processType)
+		}
+
+		procfile[processType] = command
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return procfile, nil
+}
+
+// CheckForProcfile will optionally return a Procfile given a directory.
+func CheckForProcfile(directory string) (Procfile, error) {
+	reader, err := os.Open(filepath.Join(directory, "Procfile"))
+	switch {
+	case os.IsNotExist(err):
+		return nil, nil
+	case err != nil:
+		return nil, err
+	}
+	defer reader.Close()
+
+	return NewProcfileFromReader(reader)
+}
+
+// AddProcfile adds the processes in the Procfile that aren't in the
+// application's manifest. The web process is skipped because buildpacks
+// already use it as the application's start command.
+func (app *Application) AddProcfile(procfile Procfile) {
+	declared := make(map[string]bool)
+	for _, process := range app.Processes {
+		declared[process.Type] = true
+	}
+
+	var types []string
+	for processType := range procfile {
+		if processType != "web" && !declared[processType] {
+			types = append(types, processType)
+		}
+	}
+	sort.Strings(types)
+
+	for _, processType := range types {
+		app.Processes = append(app.Processes, Process{
+			Type:    processType,
+			Command: procfile[processType],
+		})
+	}
+}
@@ -0,0 +1,116 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package manifest_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/kf/pkg/kf/manifest"
+	"github.com/google/kf/pkg/kf/testutil"
+)
+
+func TestNewProcfileFromReader(t *testing.T) {
+	cases := map[string]struct {
+		fileContent string
+		expected    manifest.Procfile
+		expectedErr error
+	}{
+		"processes": {
+			fileContent: `
+# comments and blank lines are skipped
+web: bundle exec rails server -p $PORT
+
+worker: bundle exec sidekiq -c 5
+clock:bundle exec clockwork clock.rb
+`,
+			expected: manifest.Procfile{
+				"web":    "bundle exec rails server -p $PORT",
+				"worker": "bundle exec sidekiq -c 5",
+				"clock":  "bundle exec clockwork clock.rb",
+			},
+		},
+		"missing command": {
+			fileContent: "web: rails server\nworker:\n",
+			expectedErr: errors.New("Procfile line 2: expected TYPE: COMMAND"),
+		},
+		"missing separator": {
+			fileContent: "bundle exec sidekiq\n",
+			expectedErr: errors.New("Procfile line 1: expected TYPE: COMMAND"),
+		},
+		"duplicate type": {
+			fileContent: "worker: a\nworker: b\n",
+			expectedErr: errors.New(`Procfile line 2: duplicate process type "worker"`),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			actual, err := manifest.NewProcfileFromReader(strings.NewReader(tc.fileContent))
+			if tc.expectedErr != nil {
+				testutil.AssertErrorsEqual(t, tc.expectedErr, err)
+				return
+			}
+
+			testutil.AssertNil(t, "error", err)
+			testutil.AssertEqual(t, "procfile", tc.expected, actual)
+		})
+	}
+}
+
+func TestCheckForProcfile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "kf-procfile-test")
+	testutil.AssertNil(t, "error creating test directory", err)
+	defer func() {
+		testutil.AssertNil(t, "error deleting test directory", os.RemoveAll(dir))
+	}()
+
+	actual, err := manifest.CheckForProcfile(dir)
+	testutil.AssertNil(t, "error", err)
+	testutil.AssertEqual(t, "missing procfile", manifest.Procfile(nil), actual)
+
+	err = ioutil.WriteFile(filepath.Join(dir, "Procfile"), []byte("worker: ./worker\n"), 0644)
+	testutil.AssertNil(t, "error writing Procfile", err)
+
+	actual, err = manifest.CheckForProcfile(dir)
+	testutil.AssertNil(t, "error", err)
+	testutil.AssertEqual(t, "procfile", manifest.Procfile{"worker": "./worker"}, actual)
+}
+
+func TestApplication_AddProcfile(t *testing.T) {
+	app := manifest.Application{
+		Name: "MY-APP",
+		Processes: []manifest.Process{
+			{Type: "worker", Command: "from manifest"},
+		},
+	}
+
+	app.AddProcfile(manifest.Procfile{
+		"web":    "rails server",
+		"worker": "from procfile",
+		"mailer": "./mailer",
+		"clock":  "./clock",
+	})
+
+	testutil.AssertEqual(t, "processes", []manifest.Process{
+		{Type: "worker", Command: "from manifest"},
+		{Type: "clock", Command: "./clock"},
+		{Type: "mailer", Command: "./mailer"},
+	}, app.Processes)
+}
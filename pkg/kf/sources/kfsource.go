@@ -64,6 +64,17 @@ func (k *KfSource) SetBuildpackBuildSource(sourceImage string) {
 	k.Spec.BuildpackBuild.Source = sourceImage
 }
 
+// SetBuildpackBuildGit sets the git repository that contains the source code.
+func (k *KfSource) SetBuildpackBuildGit(git *v1alpha1.SourceSpecGit) {
+	k.Spec.BuildpackBuild.Git = git
+}
+
+// GetBuildpackBuildGit returns the git repository that contains the source
+// code if this is a buildpack style build.
+func (k *KfSource) GetBuildpackBuildGit() *v1alpha1.SourceSpecGit {
+	return k.Spec.BuildpackBuild.Git
+}
+
 // SetBuildpackBuildRegistry sets the container registry that the built code
 // will be pushed to.
 func (k *KfSource) SetBuildpackBuildRegistry(registry string) {
@@ -96,6 +107,74 @@ func (k *KfSource) GetBuildpackBuildBuildpack() string {
 	return k.Spec.BuildpackBuild.Buildpack
 }
 
+// SetBuildpackBuildBuildpacks sets the ordered buildpacks for a buildpack
+// build.
+func (k *KfSource) SetBuildpackBuildBuildpacks(buildpacks []string) {
+	k.Spec.BuildpackBuild.Buildpacks = buildpacks
+}
+
+// GetBuildpackBuildBuildpacks gets the ordered buildpacks for a buildpack
+// build.
+func (k *KfSource) GetBuildpackBuildBuildpacks() []string {
+	return k.Spec.BuildpackBuild.Buildpacks
+}
+
+// SetBuildpackBuildStack sets the run image for a buildpack build.
+func (k *KfSource) SetBuildpackBuildStack(stack string) {
+	k.Spec.BuildpackBuild.Stack = stack
+}
+
+// GetBuildpackBuildStack gets the run image for a buildpack build.
+func (k *KfSource) GetBuildpackBuildStack() string {
+	return k.Spec.BuildpackBuild.Stack
+}
+
+// SetDockerfileSource sets the image that contains the source code and
+// Dockerfile.
+func (k *KfSource) SetDockerfileSource(sourceImage string) {
+	k.Spec.Dockerfile.Source = sourceImage
+}
+
+// GetDockerfileSource returns the image that contains the build source if
+// this is a Dockerfile style build.
+func (k *KfSource) GetDockerfileSource() string {
+	return k.Spec.Dockerfile.Source
+}
+
+// SetDockerfileGit sets the git repository that contains the source code and
+// Dockerfile.
+func (k *KfSource) SetDockerfileGit(git *v1alpha1.SourceSpecGit) {
+	k.Spec.Dockerfile.Git = git
+}
+
+// GetDockerfileGit returns the git repository that contains the source code
+// if this is a Dockerfile style build.
+func (k *KfSource) GetDockerfileGit() *v1alpha1.SourceSpecGit {
+	return k.Spec.Dockerfile.Git
+}
+
+// SetDockerfilePath sets the path of the Dockerfile within the source.
+func (k *KfSource) SetDockerfilePath(path string) {
+	k.Spec.Dockerfile.Path = path
+}
+
+// GetDockerfilePath gets the path of the Dockerfile within the source.
+func (k *KfSource) GetDockerfilePath() string {
+	return k.Spec.Dockerfile.Path
+}
+
+// SetDockerfileRegistry sets the container registry that the built image
+// will be pushed to.
+func (k *KfSource) SetDockerfileRegistry(registry string) {
+	k.Spec.Dockerfile.Registry = registry
+}
+
+// GetDockerfileRegistry returns the container registry that the built image
+// will be pushed to.
+func (k *KfSource) GetDockerfileRegistry() string {
+	return k.Spec.Dockerfile.Registry
+}
+
 // ToSource casts this alias back into a Namespace.
 func (k *KfSource) ToSource() *v1alpha1.Source {
 	return (*v1alpha1.Source)(k)
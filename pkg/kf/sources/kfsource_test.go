@@ -17,6 +17,7 @@ package sources
 import (
 	"fmt"
 
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
 )
 
@@ -48,6 +49,15 @@ func ExampleKfSource_buildpack() {
 	// Env: JAVA_VERSION = 11
 }
 
+func ExampleKfSource_buildpacks() {
+	source := NewKfSource()
+	source.SetBuildpackBuildBuildpacks([]string{"nodejs", "python"})
+
+	fmt.Println("Buildpacks:", source.GetBuildpackBuildBuildpacks())
+
+	// Output: Buildpacks: [nodejs python]
+}
+
 func ExampleKfSource_docker() {
 	source := NewKfSource()
 
@@ -63,3 +73,45 @@ func ExampleKfSource_docker() {
 	// Namespace: my-namespace
 	// Source: mysql/mysql
 }
+
+func ExampleKfSource_dockerfile() {
+	source := NewKfSource()
+
+	source.SetName("my-dockerfile-build")
+	source.SetNamespace("my-namespace")
+	source.SetDockerfileSource("gcr.io/my-source-code-image")
+	source.SetDockerfilePath("build/Dockerfile")
+	source.SetDockerfileRegistry("gcr.io/some-registry")
+
+	fmt.Println("Name:", source.GetName())
+	fmt.Println("Namespace:", source.GetNamespace())
+	fmt.Println("Source:", source.GetDockerfileSource())
+	fmt.Println("Path:", source.GetDockerfilePath())
+	fmt.Println("Registry:", source.GetDockerfileRegistry())
+
+	// Output: Name: my-dockerfile-build
+	// Namespace: my-namespace
+	// Source: gcr.io/my-source-code-image
+	// Path: build/Dockerfile
+	// Registry: gcr.io/some-registry
+}
+
+func ExampleKfSource_git() {
+	source := NewKfSource()
+
+	source.SetName("my-git-build")
+	source.SetBuildpackBuildGit(&v1alpha1.SourceSpecGit{
+		URL:      "https://github.com/google/kf.git",
+		Revision: "master",
+	})
+
+	fmt.Println("Name:", source.GetName())
+	fmt.Println("URL:", source.GetBuildpackBuildGit().URL)
+	fmt.Println("Revision:", source.GetBuildpackBuildGit().Revision)
+	fmt.Println("Buildpack Build?:", source.Spec.IsBuildpackBuild())
+
+	// Output: Name: my-git-build
+	// URL: https://github.com/google/kf.git
+	// Revision: master
+	// Buildpack Build?: true
+}
@@ -46,6 +46,21 @@ func SourceStatus(source v1alpha1.Source) (finished bool, err error) {
 	}
 }
 
+// CancelBuild is a Mutator that cancels the build of the Source. It fails if
+// the build has already finished.
+func CancelBuild(source *v1alpha1.Source) error {
+	if finished, _ := SourceStatus(*source); finished {
+		return fmt.Errorf("build %s has already finished", source.Name)
+	}
+
+	if source.Annotations == nil {
+		source.Annotations = make(map[string]string)
+	}
+	source.Annotations[v1alpha1.SourceCancelledAnnotation] = "true"
+
+	return nil
+}
+
 // BuildTailerFunc converts a func into a BuildTailer.
 type BuildTailerFunc func(ctx context.Context, out io.Writer, buildName, namespace string) error
 
@@ -86,3 +86,39 @@ func TestBuildStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestCancelBuild(t *testing.T) {
+	cases := map[string]struct {
+		status          corev1.ConditionStatus
+		expectCancelled bool
+		expectErr       error
+	}{
+		"still building": {
+			status:          corev1.ConditionUnknown,
+			expectCancelled: true,
+		},
+		"succeeded": {
+			status:    corev1.ConditionTrue,
+			expectErr: errors.New("build my-source has already finished"),
+		},
+		"failed": {
+			status:    corev1.ConditionFalse,
+			expectErr: errors.New("build my-source has already finished"),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			source := &v1alpha1.Source{}
+			source.Name = "my-source"
+			source.Status.Conditions = duck.Conditions{
+				{Type: v1alpha1.SourceConditionSucceeded, Status: tc.status},
+			}
+
+			err := sources.CancelBuild(source)
+
+			testutil.AssertErrorsEqual(t, tc.expectErr, err)
+			testutil.AssertEqual(t, "cancelled", tc.expectCancelled, source.IsCancelled())
+		})
+	}
+}
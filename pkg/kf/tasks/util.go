@@ -0,0 +1,80 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// TaskStatus gets the status of the given task.
+// Complete will be set to true if the command has stopped running.
+// Error will be set if the command failed or was terminated.
+// A successful result is one that completed and error is nil.
+func TaskStatus(task v1alpha1.Task) (finished bool, err error) {
+	condition := task.Status.GetCondition(v1alpha1.TaskConditionSucceeded)
+	if condition == nil {
+		// no success condition means the task hasn't been reconciled yet
+		return false, nil
+	}
+
+	switch condition.Status {
+	case corev1.ConditionTrue:
+		return true, nil
+
+	case corev1.ConditionFalse:
+		return true, fmt.Errorf("task failed for reason: %s with message: %s", condition.Reason, condition.Message)
+
+	default: // the task is waiting or running
+		return false, nil
+	}
+}
+
+// TerminateTask is a Mutator that stops the command of the Task. It fails if
+// the Task has already finished.
+func TerminateTask(task *v1alpha1.Task) error {
+	if finished, _ := TaskStatus(*task); finished {
+		return fmt.Errorf("task %s has already finished", task.Name)
+	}
+
+	task.Spec.Terminated = true
+
+	return nil
+}
+
+// NewScheduledTask creates a Task that runs the command against the App on
+// the schedule.
+func NewScheduledTask(namespace, name, appName, command string, schedule v1alpha1.TaskSpecSchedule) *v1alpha1.Task {
+	task := &v1alpha1.Task{}
+	task.Namespace = namespace
+	task.Name = name
+	task.Spec.AppName = appName
+	task.Spec.Command = command
+	task.Spec.Schedule = &schedule
+
+	return task
+}
+
+// MergeScheduledTask is a Merger that updates an existing scheduled Task
+// with the App, command and schedule of a new one.
+func MergeScheduledTask(newObj, oldObj *v1alpha1.Task) *v1alpha1.Task {
+	oldObj.Spec.AppName = newObj.Spec.AppName
+	oldObj.Spec.Command = newObj.Spec.Command
+	oldObj.Spec.Schedule = newObj.Spec.Schedule
+
+	return oldObj
+}
@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tasks_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/tasks"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	duck "knative.dev/pkg/apis/duck/v1beta1"
+)
+
+func taskWithCondition(status corev1.ConditionStatus, reason, message string) v1alpha1.Task {
+	task := v1alpha1.Task{}
+	task.Name = "my-task"
+	task.Status.Status = duck.Status{
+		Conditions: duck.Conditions{
+			{Type: v1alpha1.TaskConditionSucceeded, Status: status, Reason: reason, Message: message},
+		},
+	}
+	return task
+}
+
+func TestTaskStatus(t *testing.T) {
+	cases := map[string]struct {
+		task           v1alpha1.Task
+		expectFinished bool
+		expectErr      error
+	}{
+		"not reconciled": {
+			task:           v1alpha1.Task{},
+			expectFinished: false,
+			expectErr:      nil,
+		},
+		"failed": {
+			task:           taskWithCondition(corev1.ConditionFalse, "BackoffLimitExceeded", "Task failed"),
+			expectFinished: true,
+			expectErr:      errors.New("task failed for reason: BackoffLimitExceeded with message: Task failed"),
+		},
+		"succeeded": {
+			task:           taskWithCondition(corev1.ConditionTrue, "", ""),
+			expectFinished: true,
+			expectErr:      nil,
+		},
+		"running": {
+			task:           taskWithCondition(corev1.ConditionUnknown, "Running", "Task is running"),
+			expectFinished: false,
+			expectErr:      nil,
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			finished, err := tasks.TaskStatus(tc.task)
+
+			testutil.AssertEqual(t, "finished", tc.expectFinished, finished)
+			testutil.AssertErrorsEqual(t, tc.expectErr, err)
+		})
+	}
+}
+
+func TestTerminateTask(t *testing.T) {
+	running := taskWithCondition(corev1.ConditionUnknown, "Running", "Task is running")
+	testutil.AssertNil(t, "err", tasks.TerminateTask(&running))
+	testutil.AssertEqual(t, "Terminated", true, running.Spec.Terminated)
+
+	finished := taskWithCondition(corev1.ConditionTrue, "", "")
+	err := tasks.TerminateTask(&finished)
+	testutil.AssertErrorsEqual(t, errors.New("task my-task has already finished"), err)
+	testutil.AssertEqual(t, "Terminated", false, finished.Spec.Terminated)
+}
+
+func TestMergeScheduledTask(t *testing.T) {
+	oldObj := tasks.NewScheduledTask("my-space", "cleanup", "my-app", "rake db:cleanup", v1alpha1.TaskSpecSchedule{
+		Cron: "@daily",
+	})
+	oldObj.ResourceVersion = "42"
+	oldObj.Status.JobName = "cleanup"
+
+	newObj := tasks.NewScheduledTask("my-space", "cleanup", "my-app", "rake db:vacuum", v1alpha1.TaskSpecSchedule{
+		Cron: "@hourly",
+	})
+
+	merged := tasks.MergeScheduledTask(newObj, oldObj)
+
+	testutil.AssertEqual(t, "ResourceVersion", "42", merged.ResourceVersion)
+	testutil.AssertEqual(t, "JobName", "cleanup", merged.Status.JobName)
+	testutil.AssertEqual(t, "Command", "rake db:vacuum", merged.Spec.Command)
+	testutil.AssertEqual(t, "Cron", "@hourly", merged.Spec.Schedule.Cron)
+}
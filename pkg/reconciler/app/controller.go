@@ -22,6 +22,9 @@ import (
 	routeinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/route"
 	sourceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/source"
 	spaceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/space"
+	deploymentinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/deployment"
+	networkpolicyinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/networkpolicy"
+	pvcinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/persistentvolumeclaim"
 	servicebindinginformer "github.com/google/kf/pkg/client/servicecatalog/injection/informers/servicecatalog/v1beta1/servicebinding"
 	"github.com/google/kf/pkg/kf/secrets"
 	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
@@ -50,6 +53,9 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	spaceInformer := spaceinformer.Get(ctx)
 	routeInformer := routeinformer.Get(ctx)
 	serviceBindingInformer := servicebindinginformer.Get(ctx)
+	pvcInformer := pvcinformer.Get(ctx)
+	deploymentInformer := deploymentinformer.Get(ctx)
+	networkPolicyInformer := networkpolicyinformer.Get(ctx)
 
 	// TODO(#397): replace all of this code which eventually gets the
 	// systemEnvInjector with informers once service-binding creation is server
@@ -80,9 +86,16 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		spaceLister:           spaceInformer.Lister(),
 		systemEnvInjector:     systemEnvInjector,
 		routeLister:           routeInformer.Lister(),
+
+		persistentVolumeClaimLister: pvcInformer.Lister(),
+		deploymentLister:            deploymentInformer.Lister(),
+		networkPolicyLister:         networkPolicyInformer.Lister(),
 	}
 
+	c.deleteImage = c.deleteRemoteImage
+
 	impl := controller.NewImpl(c, logger, "Apps")
+	c.enqueueAfter = impl.EnqueueAfter
 
 	c.Logger.Info("Setting up event handlers")
 
@@ -104,5 +117,21 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	// Recreate build caches when they're cleared.
+	pvcInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("App")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	deploymentInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("App")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	networkPolicyInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("App")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
 	return impl
 }
@@ -0,0 +1,80 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package app
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+)
+
+// imageDeletionTimeout bounds how long the controller waits on a registry to
+// delete an image.
+const imageDeletionTimeout = time.Minute
+
+// deleteRemoteImage removes the Source's built image from its container
+// registry using the credentials of the Source's service account. The image
+// is deleted by the digest the Source recorded so a tag that was pushed again
+// since isn't affected.
+func (r *Reconciler) deleteRemoteImage(source *v1alpha1.Source) error {
+	if source.Status.ImageDigest == "" {
+		return fmt.Errorf("the digest of image %s is unknown", source.Status.Image)
+	}
+
+	tagged, err := name.ParseReference(source.Status.Image, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	ref, err := name.NewDigest(tagged.Context().String()+"@"+source.Status.ImageDigest, name.WeakValidation)
+	if err != nil {
+		return err
+	}
+
+	keychain, err := k8schain.New(r.KubeClientSet, k8schain.Options{
+		Namespace:          source.Namespace,
+		ServiceAccountName: source.Spec.ServiceAccount,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to read registry credentials: %s", err)
+	}
+
+	auth, err := keychain.Resolve(ref.Context().Registry)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), imageDeletionTimeout)
+	defer cancel()
+
+	return remote.Delete(ref, auth, &contextTransport{ctx: ctx, inner: http.DefaultTransport})
+}
+
+// contextTransport sends requests with a context so they can be abandoned.
+type contextTransport struct {
+	ctx   context.Context
+	inner http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *contextTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	return t.inner.RoundTrip(req.WithContext(t.ctx))
+}
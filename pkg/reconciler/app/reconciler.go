@@ -20,9 +20,11 @@ import (
 	"reflect"
 	"sort"
 	"strconv"
+	"time"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	kflisters "github.com/google/kf/pkg/client/listers/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/algorithms"
 	"github.com/google/kf/pkg/kf/systemenvinjector"
 	"github.com/google/kf/pkg/reconciler"
 	"github.com/google/kf/pkg/reconciler/app/resources"
@@ -30,10 +32,16 @@ import (
 	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
 	servinglisters "github.com/knative/serving/pkg/client/listers/serving/v1alpha1"
 	"go.uber.org/zap"
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	networkinglisters "k8s.io/client-go/listers/networking/v1"
 	"k8s.io/client-go/tools/cache"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmp"
@@ -50,6 +58,17 @@ type Reconciler struct {
 	spaceLister           kflisters.SpaceLister
 	routeLister           kflisters.RouteLister
 	systemEnvInjector     systemenvinjector.SystemEnvInjectorInterface
+
+	persistentVolumeClaimLister corelisters.PersistentVolumeClaimLister
+	deploymentLister            appslisters.DeploymentLister
+	networkPolicyLister         networkinglisters.NetworkPolicyLister
+
+	// deleteImage removes the image of a garbage collected Source from its
+	// container registry.
+	deleteImage func(source *v1alpha1.Source) error
+
+	// enqueueAfter is used to check on Apps with timed rollout steps.
+	enqueueAfter func(obj interface{}, after time.Duration)
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -81,6 +100,15 @@ func (r *Reconciler) reconcileApp(ctx context.Context, namespace, name string, l
 		return nil
 	}
 
+	// Rolled back Apps stay pinned to the old revision only until their
+	// template changes, otherwise the revisions created by scaling the App or
+	// changing its environment would never receive traffic. Updating the App
+	// causes it to be reconciled again without the pin.
+	if original.Status.RollbackSuperseded(&original.Spec) {
+		logger.Infof("App %q changed after being rolled back, removing the rollback", name)
+		return r.clearRollback(original)
+	}
+
 	// Don't modify the informers copy
 	toReconcile := original.DeepCopy()
 
@@ -116,6 +144,58 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 	}
 	app.Status.MarkSpaceHealthy()
 
+	// reconcile build cache
+	if desired := resources.MakeBuildCache(app); desired != nil {
+		r.Logger.Info("reconciling build cache")
+		condition := app.Status.SourceCondition()
+
+		actual, err := r.persistentVolumeClaimLister.PersistentVolumeClaims(desired.Namespace).Get(desired.Name)
+		if apierrs.IsNotFound(err) {
+			// The cache doesn't exist or was cleared, make a new one.
+			if _, err := r.KubeClientSet.CoreV1().PersistentVolumeClaims(desired.Namespace).Create(desired); err != nil {
+				return condition.MarkReconciliationError("creating build cache for", err)
+			}
+		} else if err != nil {
+			return condition.MarkReconciliationError("getting build cache for", err)
+		} else if !metav1.IsControlledBy(actual, app) {
+			return condition.MarkChildNotOwned(desired.Name)
+		}
+	}
+
+	// reconcile network policy
+	{
+		r.Logger.Info("reconciling NetworkPolicy")
+		condition := app.Status.NetworkPolicyCondition()
+		desired := resources.MakeNetworkPolicy(app)
+
+		actual, err := r.networkPolicyLister.NetworkPolicies(app.Namespace).Get(resources.NetworkPolicyName(app))
+		if apierrs.IsNotFound(err) {
+			// NetworkPolicy doesn't exist, make one if the App has an allow
+			// list.
+			if desired != nil {
+				if _, err := r.KubeClientSet.NetworkingV1().NetworkPolicies(desired.Namespace).Create(desired); err != nil {
+					return condition.MarkReconciliationError("creating", err)
+				}
+			}
+		} else if err != nil {
+			return condition.MarkReconciliationError("getting latest", err)
+		} else if !metav1.IsControlledBy(actual, app) {
+			return condition.MarkChildNotOwned(actual.Name)
+		} else if desired == nil {
+			// The allow list was removed, every App can reach this one again.
+			if err := r.KubeClientSet.
+				NetworkingV1().
+				NetworkPolicies(actual.Namespace).
+				Delete(actual.Name, &metav1.DeleteOptions{}); err != nil {
+				return condition.MarkReconciliationError("deleting", err)
+			}
+		} else if _, err := r.reconcileNetworkPolicy(desired, actual); err != nil {
+			return condition.MarkReconciliationError("updating existing", err)
+		}
+
+		app.Status.MarkNetworkPolicyReady()
+	}
+
 	// reconcile source
 	{
 		r.Logger.Info("reconciling Source")
@@ -127,6 +207,15 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 
 		actual, err := r.latestSource(app)
 		if apierrs.IsNotFound(err) || !r.sourcesAreSemanticallyEqual(desired, actual) {
+			// The build cache can only be mounted on one node at a time, so
+			// a build that starts while the previous one still uses it goes
+			// without rather than waiting for it.
+			if actual != nil && actual.Spec.BuildpackBuild.CacheClaim != "" {
+				if cond := actual.Status.GetCondition(v1alpha1.SourceConditionSucceeded); cond == nil || cond.IsUnknown() {
+					desired.Spec.BuildpackBuild.CacheClaim = ""
+				}
+			}
+
 			// Source doesn't exist or it's for the wrong version, make a new one.
 			actual, err = r.KfClientSet.KfV1alpha1().Sources(app.Namespace).Create(desired)
 			if err != nil {
@@ -187,6 +276,50 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 		}
 
 		app.Status.PropagateKnativeServiceStatus(actual)
+
+		// Changes to the rollout status update the App which causes the
+		// traffic on the Knative Service to be adjusted on the next pass.
+		if requeueAfter := app.Status.PropagateRolloutStatus(&app.Spec, time.Now()); requeueAfter > 0 {
+			r.enqueueAfter(app, requeueAfter)
+		}
+
+		r.recordRevisionHistory(app)
+	}
+
+	// reconcile processes
+	{
+		r.Logger.Info("reconciling processes")
+		condition := app.Status.ProcessesCondition()
+		desiredDeployments, err := resources.MakeDeployments(app, space, r.systemEnvInjector)
+		if err != nil {
+			return condition.MarkTemplateError(err)
+		}
+
+		var actualDeployments []*appsv1.Deployment
+		for _, desired := range desiredDeployments {
+			actual, err := r.deploymentLister.Deployments(desired.Namespace).Get(desired.Name)
+			if apierrs.IsNotFound(err) {
+				// Deployment doesn't exist, make one.
+				actual, err = r.KubeClientSet.AppsV1().Deployments(desired.Namespace).Create(desired)
+				if err != nil {
+					return condition.MarkReconciliationError("creating", err)
+				}
+			} else if err != nil {
+				return condition.MarkReconciliationError("getting latest", err)
+			} else if !metav1.IsControlledBy(actual, app) {
+				return condition.MarkChildNotOwned(desired.Name)
+			} else if actual, err = r.reconcileDeployment(desired, actual); err != nil {
+				return condition.MarkReconciliationError("updating existing", err)
+			}
+
+			actualDeployments = append(actualDeployments, actual)
+		}
+
+		if err := r.gcDeployments(app, desiredDeployments); err != nil {
+			return condition.MarkReconciliationError("deleting removed", err)
+		}
+
+		app.Status.PropagateProcessesStatus(actualDeployments)
 	}
 
 	// Route Reconciler
@@ -220,6 +353,10 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, app *v1alpha1.App) error
 	// Making it to the bottom of the reconciler means we've synchronized.
 	app.Status.ObservedGeneration = app.Generation
 
+	if err := r.gcSources(app, space); err != nil {
+		return err
+	}
+
 	return r.gcRevisions(ctx, app)
 }
 
@@ -249,11 +386,22 @@ func (r *Reconciler) latestSource(app *v1alpha1.App) (*v1alpha1.Source, error) {
 
 func (*Reconciler) sourcesAreSemanticallyEqual(desired, actual *v1alpha1.Source) bool {
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
-	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(buildInputs(desired.Spec), buildInputs(actual.Spec))
 
 	return semanticEqual
 }
 
+// buildInputs returns the parts of the SourceSpec that change the image it
+// builds. The build cache and timeout are set by the controller on every
+// Source, so changing them mustn't rebuild existing Apps.
+func buildInputs(spec v1alpha1.SourceSpec) v1alpha1.SourceSpec {
+	inputs := spec.DeepCopy()
+	inputs.Timeout = nil
+	inputs.BuildpackBuild.CacheClaim = ""
+
+	return *inputs
+}
+
 func (r *Reconciler) reconcileKnativeService(desired, actual *serving.Service) (*serving.Service, error) {
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
@@ -276,7 +424,56 @@ func (r *Reconciler) reconcileKnativeService(desired, actual *serving.Service) (
 	return r.ServingClientSet.ServingV1alpha1().Services(existing.Namespace).Update(existing)
 }
 
+func (r *Reconciler) reconcileDeployment(desired, actual *appsv1.Deployment) (*appsv1.Deployment, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Spec, actual.Spec); err != nil {
+		return nil, fmt.Errorf("failed to diff deployment: %v", err)
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec = desired.Spec
+	return r.KubeClientSet.AppsV1().Deployments(existing.Namespace).Update(existing)
+}
+
+func (r *Reconciler) reconcileNetworkPolicy(desired, actual *networkingv1.NetworkPolicy) (*networkingv1.NetworkPolicy, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec = desired.Spec
+	return r.KubeClientSet.NetworkingV1().NetworkPolicies(existing.Namespace).Update(existing)
+}
+
 func (r *Reconciler) reconcileRoute(desired, actual *v1alpha1.Route) (*v1alpha1.Route, error) {
+	// Routes can be shared by multiple Apps, so keep the Apps and weights
+	// that are already bound.
+	desired = desired.DeepCopy()
+	desired.Spec.AppWeights = actual.Spec.AppWeights
+	desired.Spec.AppNames = []string(algorithms.Merge(
+		algorithms.Strings(actual.Spec.AppNames),
+		algorithms.Strings(desired.Spec.AppNames),
+	).(algorithms.Strings))
+
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
 	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
@@ -298,6 +495,53 @@ func (r *Reconciler) reconcileRoute(desired, actual *v1alpha1.Route) (*v1alpha1.
 	return r.KfClientSet.KfV1alpha1().Routes(existing.Namespace).Update(existing)
 }
 
+// recordRevisionHistory adds the latest ready revision along with the Source
+// and image that produced it to the App's history so it can be rolled back to
+// without rebuilding.
+func (r *Reconciler) recordRevisionHistory(app *v1alpha1.App) {
+	if app.Spec.Instances.Stopped || app.Status.LatestReadyRevisionName == "" {
+		return
+	}
+
+	if cond := app.Status.GetCondition(v1alpha1.AppConditionKnativeServiceReady); cond == nil || !cond.IsTrue() {
+		return
+	}
+
+	rev, err := r.knativeRevisionLister.Revisions(app.Namespace).Get(app.Status.LatestReadyRevisionName)
+	if err != nil {
+		r.Logger.Warnf("Couldn't get Revision %s for history: %s", app.Status.LatestReadyRevisionName, err)
+		return
+	}
+
+	var image string
+	if len(rev.Spec.Containers) > 0 {
+		image = rev.Spec.Containers[0].Image
+	}
+
+	limit := v1alpha1.DefaultRevisionHistoryLimit
+	if app.Spec.RevisionHistoryLimit != nil {
+		limit = *app.Spec.RevisionHistoryLimit
+	}
+
+	app.Status.PropagateRevisionHistory(v1alpha1.AppRevision{
+		RevisionName: rev.Name,
+		SourceName:   rev.Annotations[resources.SourceNameAnnotation],
+		Image:        image,
+		ReadyTime:    metav1.Now(),
+	}, limit)
+}
+
+// clearRollback removes the revision the App was rolled back to so traffic
+// follows the latest revision again.
+func (r *Reconciler) clearRollback(original *v1alpha1.App) error {
+	// Don't modify the informers copy.
+	existing := original.DeepCopy()
+	existing.Spec.Rollout.RollbackRevisionName = ""
+
+	_, err := r.KfClientSet.KfV1alpha1().Apps(existing.GetNamespace()).Update(existing)
+	return err
+}
+
 func (r *Reconciler) updateStatus(desired *v1alpha1.App) (*v1alpha1.App, error) {
 	r.Logger.Info("updating status")
 	actual, err := r.appLister.Apps(desired.GetNamespace()).Get(desired.Name)
@@ -316,12 +560,115 @@ func (r *Reconciler) updateStatus(desired *v1alpha1.App) (*v1alpha1.App, error)
 	return r.KfClientSet.KfV1alpha1().Apps(existing.GetNamespace()).UpdateStatus(existing)
 }
 
+// gcSources deletes the App's Sources that are past the build retention
+// limits of the space. Their Builds are owned by the Sources so they're
+// deleted along with them.
+func (r *Reconciler) gcSources(app *v1alpha1.App, space *v1alpha1.Space) error {
+	selector := labels.Set(resources.MakeSourceLabels(app)).AsSelector()
+	sources, err := r.sourceLister.Sources(app.Namespace).List(selector)
+	if err != nil {
+		return err
+	}
+
+	retention := space.Spec.BuildpackBuild.Retention
+	for _, source := range resources.ExpiredSources(app, sources, retention) {
+		// Container builds run an image that kf didn't build so it must
+		// never be deleted.
+		image := source.Status.Image
+		if retention.DeleteImages && !source.Spec.IsContainerBuild() && image != "" {
+			// Registries may be slow or not allow deletes, so neither the
+			// App nor the Source wait for it.
+			go func(source *v1alpha1.Source) {
+				r.Logger.Infof("Deleting image %s of Source %s...", image, source.Name)
+				if err := r.deleteImage(source); err != nil {
+					r.Logger.Warnf("Couldn't delete image %s: %s", image, err)
+				}
+			}(source.DeepCopy())
+		}
+
+		r.Logger.Infof("Garbage collecting Source %s...", source.Name)
+		err := r.KfClientSet.KfV1alpha1().Sources(app.Namespace).Delete(source.Name, &metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// gcDeployments deletes the Deployments of processes that were removed from
+// the App.
+func (r *Reconciler) gcDeployments(app *v1alpha1.App, desired []*appsv1.Deployment) error {
+	selector := labels.Set(resources.ProcessSelector(app)).AsSelector()
+	deployments, err := r.deploymentLister.Deployments(app.Namespace).List(selector)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool)
+	for _, d := range desired {
+		keep[d.Name] = true
+	}
+
+	for _, deployment := range deployments {
+		if keep[deployment.Name] || !metav1.IsControlledBy(deployment, app) {
+			continue
+		}
+
+		r.Logger.Infof("Garbage collecting Deployment %s...", deployment.Name)
+		err := r.KubeClientSet.AppsV1().Deployments(app.Namespace).Delete(deployment.Name, &metav1.DeleteOptions{})
+		if err != nil && !apierrs.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scaleRevision sets the minScale of the Revision's PodAutoscaler. Knative
+// only copies the Revision's annotations to the PodAutoscaler when it's
+// created, so the PodAutoscaler is updated directly. An empty minScale lets
+// the Revision scale to zero.
+func (r *Reconciler) scaleRevision(rev *serving.Revision, minScale string) error {
+	paClient := r.ServingClientSet.AutoscalingV1alpha1().PodAutoscalers(rev.Namespace)
+	pa, err := paClient.Get(rev.Name, metav1.GetOptions{})
+	if apierrs.IsNotFound(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if pa.Annotations[autoscaling.MinScaleAnnotationKey] == minScale {
+		return nil
+	}
+
+	pa = pa.DeepCopy()
+	if minScale == "" {
+		delete(pa.Annotations, autoscaling.MinScaleAnnotationKey)
+	} else {
+		if pa.Annotations == nil {
+			pa.Annotations = make(map[string]string)
+		}
+		pa.Annotations[autoscaling.MinScaleAnnotationKey] = minScale
+	}
+
+	r.Logger.Infof("Setting %s of Revision %s to %q...", autoscaling.MinScaleAnnotationKey, rev.Name, minScale)
+	_, err = paClient.Update(pa)
+	return err
+}
+
 // gcRevisions is necessary because Knative won't scale down revisions
 // that have a `minScale` greater than 0. Therefore we are going to delete the
 // older revisions. The revisions are keeping pods around when app has been
 // scaled up. Therefore, if we don't GC the revisions, we leak pods.
 // TODO: Reevaluate once https://github.com/knative/serving/issues/4183 is
 // resolved.
+//
+// The stable revision of a canary rollout is kept so it can continue
+// receiving traffic until the canary is promoted, as is the revision a rolled
+// back App is pinned to. Other revisions in the App's history are kept so they
+// can be rolled back to, but are scaled to zero while they serve no traffic.
 func (r *Reconciler) gcRevisions(ctx context.Context, app *v1alpha1.App) error {
 	r.Logger.Debugf("Checking for revisions that need to adjust %s...", autoscaling.MinScaleAnnotationKey)
 	defer r.Logger.Debugf("Done checking for revisions that need to adjust %s.", autoscaling.MinScaleAnnotationKey)
@@ -363,8 +710,31 @@ func (r *Reconciler) gcRevisions(ctx context.Context, app *v1alpha1.App) error {
 		return parseGeneration(revs[j]) < parseGeneration(revs[i])
 	})
 
+	live := sets.NewString(
+		app.Status.Rollout.StableRevisionName,
+		app.Spec.Rollout.RollbackRevisionName,
+	)
+	history := sets.NewString()
+	for _, revision := range app.Status.History {
+		history.Insert(revision.RevisionName)
+	}
+
 	// delete everything after the latest generation
 	for _, rev := range revs[1:] {
+		if live.Has(rev.Name) {
+			if err := r.scaleRevision(rev, rev.Annotations[autoscaling.MinScaleAnnotationKey]); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if history.Has(rev.Name) {
+			if err := r.scaleRevision(rev, ""); err != nil {
+				return err
+			}
+			continue
+		}
+
 		r.Logger.Infof("Garbage collecting Revision %s...", rev.Name)
 		if err := revisionClient.Delete(rev.Name, &metav1.DeleteOptions{}); err != nil {
 			return err
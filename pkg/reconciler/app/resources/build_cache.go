@@ -0,0 +1,63 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// BuildCacheSize is the storage requested for each App's build cache.
+var BuildCacheSize = resource.MustParse("1Gi")
+
+// BuildCacheName gets the name of the PersistentVolumeClaim that caches an
+// App's buildpack builds.
+func BuildCacheName(app *v1alpha1.App) string {
+	return v1alpha1.GenerateName(app.Name, "build-cache")
+}
+
+// MakeBuildCache creates a PersistentVolumeClaim that holds the layers of an
+// App's buildpack builds so dependencies aren't downloaded for each build. It
+// returns nil if the App isn't built with buildpacks.
+func MakeBuildCache(app *v1alpha1.App) *corev1.PersistentVolumeClaim {
+	if !app.Spec.Source.IsBuildpackBuild() {
+		return nil
+	}
+
+	return &corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      BuildCacheName(app),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(app),
+			},
+			Labels: resources.UnionMaps(app.GetLabels(), app.BuildCacheLabels()),
+		},
+		Spec: corev1.PersistentVolumeClaimSpec{
+			AccessModes: []corev1.PersistentVolumeAccessMode{
+				corev1.ReadWriteOnce,
+			},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceStorage: BuildCacheSize,
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,68 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+)
+
+func ExampleMakeBuildCache() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.Namespace = "my-space"
+	app.Spec.Source.BuildpackBuild.Source = "some-source-image"
+
+	cache := MakeBuildCache(app)
+	size := cache.Spec.Resources.Requests["storage"]
+
+	fmt.Println("Name:", cache.Name == BuildCacheName(app))
+	fmt.Println("Namespace:", cache.Namespace)
+	fmt.Println("Component:", cache.Labels[v1alpha1.ComponentLabel])
+	fmt.Println("Owner:", cache.OwnerReferences[0].Kind, cache.OwnerReferences[0].Name)
+	fmt.Println("Size:", size.String())
+
+	// Output: Name: true
+	// Namespace: my-space
+	// Component: build-cache
+	// Owner: App my-app
+	// Size: 1Gi
+}
+
+func ExampleMakeBuildCache_container() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.Spec.Source.ContainerImage.Image = "mysql"
+
+	fmt.Println("Cache:", MakeBuildCache(app))
+
+	// Output: Cache: <nil>
+}
+
+func ExampleMakeSource_buildCache() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.Spec.Source.BuildpackBuild.Source = "some-source-image"
+
+	source, err := MakeSource(app, &v1alpha1.Space{})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Cache Claim:", source.Spec.BuildpackBuild.CacheClaim == BuildCacheName(app))
+
+	// Output: Cache Claim: true
+}
@@ -0,0 +1,34 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// SetContainerCommand makes the container run the shell command instead of
+// the image's default process.
+func SetContainerCommand(app *v1alpha1.App, container *corev1.Container, command string) {
+	if app.Spec.Source.IsBuildpackBuild() {
+		// The buildpack launcher is the image's entrypoint, it runs its
+		// argument in a shell with the environment the buildpacks set up.
+		container.Command = nil
+		container.Args = []string{command}
+	} else {
+		container.Command = []string{"/bin/sh", "-c"}
+		container.Args = []string{command}
+	}
+}
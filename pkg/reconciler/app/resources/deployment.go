@@ -0,0 +1,185 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+	"fmt"
+	"hash/crc64"
+	"strconv"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/systemenvinjector"
+	"github.com/knative/serving/pkg/resources"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"knative.dev/pkg/kmeta"
+)
+
+// UpdateRequestsAnnotation is the annotation on the Pods of processes that
+// holds the App's UpdateRequests so they restart with the App.
+const UpdateRequestsAnnotation = "kf.dev/update-requests"
+
+// DeploymentName gets the name of the Deployment running one of the App's
+// processes. App names and process types can both contain hyphens, so the
+// name ends with a checksum of the pair to keep e.g. the "b-c" process of App
+// "a" and the "c" process of App "a-b" apart. Names fit in a DNS label so
+// they can be used as label values.
+func DeploymentName(app *v1alpha1.App, processType string) string {
+	checksum := strconv.FormatUint(
+		crc64.Checksum(
+			[]byte(app.Name+"/"+processType),
+			crc64.MakeTable(crc64.ECMA),
+		),
+		36)
+
+	prefix := fmt.Sprintf("%s-%s", app.Name, processType)
+
+	// Subtract an extra 1 for the hyphen between the prefix and checksum.
+	maxPrefixLen := validation.DNS1123LabelMaxLength - 1 - len(checksum)
+	if len(prefix) > maxPrefixLen {
+		prefix = strings.TrimRight(prefix[:maxPrefixLen], "-")
+	}
+
+	return fmt.Sprintf("%s-%s", prefix, checksum)
+}
+
+// ProcessSelector gets the labels that select every Deployment running one
+// of the App's processes other than web.
+func ProcessSelector(app *v1alpha1.App) map[string]string {
+	return app.ComponentLabels("process")
+}
+
+// ProcessLabels gets the labels of the Deployment and Pods running one of the
+// App's processes.
+func ProcessLabels(app *v1alpha1.App, processType string) map[string]string {
+	return resources.UnionMaps(ProcessSelector(app), map[string]string{
+		v1alpha1.ProcessTypeLabel: processType,
+	})
+}
+
+// MakeDeployments creates a Deployment for each of the App's processes other
+// than web. The processes run the App's latest image with the same
+// environment as the web process.
+func MakeDeployments(
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+	systemEnvInjector systemenvinjector.SystemEnvInjectorInterface,
+) ([]*appsv1.Deployment, error) {
+	if len(app.Spec.Processes) == 0 {
+		return nil, nil
+	}
+
+	image := app.Status.PinnedImage()
+	if image == "" {
+		return nil, errors.New("waiting for source image in latestReadySource")
+	}
+
+	var deployments []*appsv1.Deployment
+	for _, process := range app.Spec.Processes {
+		deployment, err := makeDeployment(app, process, space, systemEnvInjector, image)
+		if err != nil {
+			return nil, err
+		}
+
+		deployments = append(deployments, deployment)
+	}
+
+	return deployments, nil
+}
+
+func makeDeployment(
+	app *v1alpha1.App,
+	process v1alpha1.AppSpecProcess,
+	space *v1alpha1.Space,
+	systemEnvInjector systemenvinjector.SystemEnvInjectorInterface,
+	image string,
+) (*appsv1.Deployment, error) {
+
+	// don't modify the spec on the app
+	podSpec := app.Spec.Template.Spec.DeepCopy()
+	if len(podSpec.Containers) == 0 {
+		podSpec.Containers = append(podSpec.Containers, corev1.Container{})
+	}
+
+	env, err := MakeRuntimeEnv(app, space, systemEnvInjector, podSpec.Containers[0].Env)
+	if err != nil {
+		return nil, err
+	}
+
+	container := &podSpec.Containers[0]
+	container.Name = process.Type
+	container.Image = image
+	container.Env = env
+	SetContainerCommand(app, container, process.Command)
+
+	// Processes other than web don't receive traffic so they aren't
+	// reachable or probed.
+	container.Ports = nil
+	container.ReadinessProbe = nil
+	container.LivenessProbe = nil
+
+	for name, quantity := range process.Resources.Requests {
+		if container.Resources.Requests == nil {
+			container.Resources.Requests = corev1.ResourceList{}
+		}
+		container.Resources.Requests[name] = quantity
+	}
+
+	for name, quantity := range process.Resources.Limits {
+		if container.Resources.Limits == nil {
+			container.Resources.Limits = corev1.ResourceList{}
+		}
+		container.Resources.Limits[name] = quantity
+	}
+
+	replicas := int32(v1alpha1.DefaultProcessInstances)
+	switch {
+	case app.Spec.Instances.Stopped:
+		replicas = 0
+	case process.Instances != nil:
+		replicas = int32(*process.Instances)
+	}
+
+	labels := ProcessLabels(app, process.Type)
+
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      DeploymentName(app, process.Type),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(app),
+			},
+			Labels: resources.UnionMaps(app.GetLabels(), labels),
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas: &replicas,
+			Selector: metav1.SetAsLabelSelector(labels),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: labels,
+					Annotations: map[string]string{
+						SourceNameAnnotation:     app.Status.LatestReadySourceName,
+						UpdateRequestsAnnotation: strconv.Itoa(app.Spec.Template.UpdateRequests),
+					},
+				},
+				Spec: *podSpec,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,181 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	systemenvinjectorfake "github.com/google/kf/pkg/kf/systemenvinjector/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+func ExampleDeploymentName() {
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+
+	fmt.Println(DeploymentName(app, "worker"))
+
+	// Output: my-app-worker-2eppupckzs5gs
+}
+
+func TestDeploymentName(t *testing.T) {
+	t.Parallel()
+
+	newApp := func(name string) *v1alpha1.App {
+		app := &v1alpha1.App{}
+		app.Name = name
+		return app
+	}
+
+	t.Run("hyphenated names", func(t *testing.T) {
+		a := DeploymentName(newApp("a"), "b-c")
+		b := DeploymentName(newApp("a-b"), "c")
+
+		testutil.AssertEqual(t, "names differ", true, a != b)
+	})
+
+	t.Run("long names", func(t *testing.T) {
+		name := DeploymentName(newApp(strings.Repeat("a", 63)), strings.Repeat("b", 63))
+
+		testutil.AssertEqual(t, "errors", []string(nil), validation.IsDNS1123Label(name))
+	})
+}
+
+func TestMakeDeployments(t *testing.T) {
+	t.Parallel()
+
+	newApp := func() *v1alpha1.App {
+		instances := 3
+
+		app := &v1alpha1.App{}
+		app.Name = "my-app"
+		app.Namespace = "my-space"
+		app.Spec.Source.BuildpackBuild.Source = "some-source"
+		app.Spec.Template.UpdateRequests = 2
+		app.Spec.Template.Spec.Containers = []corev1.Container{{
+			Env:            []corev1.EnvVar{{Name: "GREETING", Value: "hello"}},
+			Ports:          []corev1.ContainerPort{{ContainerPort: 8080}},
+			ReadinessProbe: &corev1.Probe{},
+			Resources: corev1.ResourceRequirements{
+				Requests: corev1.ResourceList{
+					corev1.ResourceCPU:    resource.MustParse("1"),
+					corev1.ResourceMemory: resource.MustParse("1Gi"),
+				},
+			},
+		}}
+		app.Spec.Processes = []v1alpha1.AppSpecProcess{
+			{
+				Type:      "worker",
+				Command:   "bin/worker",
+				Instances: &instances,
+				Resources: corev1.ResourceRequirements{
+					Requests: corev1.ResourceList{
+						corev1.ResourceMemory: resource.MustParse("2Gi"),
+					},
+				},
+			},
+			{Type: "clock", Command: "bin/clock"},
+		}
+		app.Status.Image = "gcr.io/my-project/app:1"
+		app.Status.LatestReadySourceName = "my-app-source"
+		return app
+	}
+
+	t.Run("processes", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		injector := systemenvinjectorfake.NewFakeSystemEnvInjector(ctrl)
+		injector.EXPECT().ComputeSystemEnv(gomock.Any()).Return([]corev1.EnvVar{
+			{Name: "VCAP_SERVICES", Value: "{}"},
+		}, nil).Times(2)
+
+		app := newApp()
+		deployments, err := MakeDeployments(app, &v1alpha1.Space{}, injector)
+		testutil.AssertNil(t, "err", err)
+		testutil.AssertEqual(t, "count", 2, len(deployments))
+
+		worker := deployments[0]
+		testutil.AssertEqual(t, "name", "my-app-worker-2eppupckzs5gs", worker.Name)
+		testutil.AssertEqual(t, "namespace", "my-space", worker.Namespace)
+		testutil.AssertEqual(t, "replicas", int32(3), *worker.Spec.Replicas)
+		testutil.AssertEqual(t, "selector", ProcessLabels(app, "worker"), worker.Spec.Selector.MatchLabels)
+		testutil.AssertEqual(t, "pod labels", ProcessLabels(app, "worker"), worker.Spec.Template.Labels)
+		testutil.AssertEqual(t, "update requests", "2", worker.Spec.Template.Annotations[UpdateRequestsAnnotation])
+
+		container := worker.Spec.Template.Spec.Containers[0]
+		testutil.AssertEqual(t, "container name", "worker", container.Name)
+		testutil.AssertEqual(t, "image", "gcr.io/my-project/app:1", container.Image)
+		testutil.AssertEqual(t, "command", []string(nil), container.Command)
+		testutil.AssertEqual(t, "args", []string{"bin/worker"}, container.Args)
+		testutil.AssertEqual(t, "ports", []corev1.ContainerPort(nil), container.Ports)
+		testutil.AssertEqual(t, "readiness probe", (*corev1.Probe)(nil), container.ReadinessProbe)
+		testutil.AssertEqual(t, "env", []corev1.EnvVar{
+			{Name: "GREETING", Value: "hello"},
+			{Name: "VCAP_SERVICES", Value: "{}"},
+		}, container.Env)
+
+		memory := container.Resources.Requests[corev1.ResourceMemory]
+		cpu := container.Resources.Requests[corev1.ResourceCPU]
+		testutil.AssertEqual(t, "memory", "2Gi", memory.String())
+		testutil.AssertEqual(t, "cpu", "1", cpu.String())
+
+		clock := deployments[1]
+		testutil.AssertEqual(t, "name", "my-app-clock-2x8mvocafa6sd", clock.Name)
+		testutil.AssertEqual(t, "default replicas", int32(v1alpha1.DefaultProcessInstances), *clock.Spec.Replicas)
+
+		// The App's template isn't modified.
+		appMemory := app.Spec.Template.Spec.Containers[0].Resources.Requests[corev1.ResourceMemory]
+		testutil.AssertEqual(t, "app memory", "1Gi", appMemory.String())
+	})
+
+	t.Run("stopped", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+
+		injector := systemenvinjectorfake.NewFakeSystemEnvInjector(ctrl)
+		injector.EXPECT().ComputeSystemEnv(gomock.Any()).Return(nil, nil).AnyTimes()
+
+		app := newApp()
+		app.Spec.Instances.Stopped = true
+
+		deployments, err := MakeDeployments(app, &v1alpha1.Space{}, injector)
+		testutil.AssertNil(t, "err", err)
+		for _, deployment := range deployments {
+			testutil.AssertEqual(t, "replicas", int32(0), *deployment.Spec.Replicas)
+		}
+	})
+
+	t.Run("no processes", func(t *testing.T) {
+		deployments, err := MakeDeployments(&v1alpha1.App{}, &v1alpha1.Space{}, nil)
+		testutil.AssertNil(t, "err", err)
+		testutil.AssertEqual(t, "count", 0, len(deployments))
+	})
+
+	t.Run("no image", func(t *testing.T) {
+		app := newApp()
+		app.Status.Image = ""
+
+		_, err := MakeDeployments(app, &v1alpha1.Space{}, nil)
+		testutil.AssertErrorsEqual(t, fmt.Errorf("waiting for source image in latestReadySource"), err)
+	})
+}
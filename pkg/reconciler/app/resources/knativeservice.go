@@ -22,6 +22,7 @@ import (
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/internal/envutil"
 	"github.com/google/kf/pkg/kf/systemenvinjector"
+	"github.com/knative/serving/pkg/apis/autoscaling"
 	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
 	servingv1beta1 "github.com/knative/serving/pkg/apis/serving/v1beta1"
 	"github.com/knative/serving/pkg/resources"
@@ -30,6 +31,10 @@ import (
 	"knative.dev/pkg/kmeta"
 )
 
+// SourceNameAnnotation is the annotation on revisions that holds the name of
+// the Source that built the revision's image.
+const SourceNameAnnotation = "kf.dev/source-name"
+
 // KnativeServiceName gets the name of a Knative Service given the route.
 func KnativeServiceName(app *v1alpha1.App) string {
 	return app.Name
@@ -42,7 +47,7 @@ func MakeKnativeService(
 	systemEnvInjector systemenvinjector.SystemEnvInjectorInterface,
 ) (*serving.Service, error) {
 
-	image := app.Status.Image
+	image := app.Status.PinnedImage()
 	if image == "" {
 		return nil, errors.New("waiting for source image in latestReadySource")
 	}
@@ -66,18 +71,16 @@ func MakeKnativeService(
 		podSpec.Containers = append(podSpec.Containers, corev1.Container{})
 	}
 	podSpec.Containers[0].Image = image
-	// Execution environment variables come before others because they're built
-	// to be overridden.
-	podSpec.Containers[0].Env = append(space.Spec.Execution.Env, podSpec.Containers[0].Env...)
 
-	computedEnv, err := systemEnvInjector.ComputeSystemEnv(app)
+	if command := app.Spec.Template.Command; command != "" {
+		SetContainerCommand(app, &podSpec.Containers[0], command)
+	}
+
+	env, err := MakeRuntimeEnv(app, space, systemEnvInjector, podSpec.Containers[0].Env)
 	if err != nil {
 		return nil, err
 	}
-
-	podSpec.Containers[0].Env = append(podSpec.Containers[0].Env, computedEnv...)
-
-	podSpec.Containers[0].Env = envutil.DeduplicateEnvVars(podSpec.Containers[0].Env)
+	podSpec.Containers[0].Env = env
 
 	return &serving.Service{
 		ObjectMeta: metav1.ObjectMeta{
@@ -92,8 +95,13 @@ func MakeKnativeService(
 			ConfigurationSpec: serving.ConfigurationSpec{
 				Template: &serving.RevisionTemplateSpec{
 					ObjectMeta: metav1.ObjectMeta{
-						Labels:      app.ComponentLabels("app-server"),
-						Annotations: app.Spec.Instances.ScalingAnnotations(),
+						Labels: app.ComponentLabels("app-server"),
+						Annotations: resources.UnionMaps(
+							scalingAnnotations(app),
+							map[string]string{
+								SourceNameAnnotation: app.Status.LatestReadySourceName,
+							},
+						),
 					},
 					Spec: serving.RevisionSpec{
 						RevisionSpec: servingv1beta1.RevisionSpec{
@@ -102,6 +110,111 @@ func MakeKnativeService(
 					},
 				},
 			},
+			RouteSpec: serving.RouteSpec{
+				Traffic: MakeTraffic(app),
+			},
 		},
 	}, nil
 }
+
+// scalingAnnotations returns the autoscaling annotations of the App's
+// revisions. TCP routes and internal routes to Apps that only allow some
+// sources reach the App directly rather than through the Knative activator,
+// so those Apps keep an instance running.
+func scalingAnnotations(app *v1alpha1.App) map[string]string {
+	annotations := app.Spec.Instances.ScalingAnnotations()
+	if app.Spec.Instances.Stopped || !connectedDirectly(app) {
+		return annotations
+	}
+
+	if minScale, _ := strconv.Atoi(annotations[autoscaling.MinScaleAnnotationKey]); minScale < 1 {
+		annotations[autoscaling.MinScaleAnnotationKey] = "1"
+	}
+
+	return annotations
+}
+
+// connectedDirectly returns true if any of the App's routes bypass Knative.
+func connectedDirectly(app *v1alpha1.App) bool {
+	if len(app.Spec.NetworkPolicy.AllowedSourceApps) > 0 {
+		return true
+	}
+
+	for _, route := range app.Spec.Routes {
+		if route.IsTCP() {
+			return true
+		}
+	}
+
+	return false
+}
+
+// MakeRuntimeEnv creates the environment the App's code runs with from the
+// space's execution environment, the given container environment and the
+// computed system environment (VCAP_APPLICATION, VCAP_SERVICES, etc.).
+func MakeRuntimeEnv(
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+	systemEnvInjector systemenvinjector.SystemEnvInjectorInterface,
+	containerEnv []corev1.EnvVar,
+) ([]corev1.EnvVar, error) {
+	computedEnv, err := systemEnvInjector.ComputeSystemEnv(app)
+	if err != nil {
+		return nil, err
+	}
+
+	// Execution environment variables come before others because they're built
+	// to be overridden.
+	var env []corev1.EnvVar
+	env = append(env, space.Spec.Execution.Env...)
+	env = append(env, containerEnv...)
+	env = append(env, computedEnv...)
+
+	return envutil.DeduplicateEnvVars(env), nil
+}
+
+// CanaryTrafficTag is the tag given to the canary revision so it can be
+// reached directly.
+const CanaryTrafficTag = "canary"
+
+// MakeTraffic creates the traffic targets for the App's Knative Service. Apps
+// that were rolled back send all traffic to the pinned revision. Apps with an
+// Immediate rollout send all traffic to the latest revision. Other rollouts
+// pin traffic to the stable revision and split it with the canary while one is
+// in progress.
+func MakeTraffic(app *v1alpha1.App) []serving.TrafficTarget {
+	if pinned := app.Spec.Rollout.RollbackRevisionName; pinned != "" {
+		return []serving.TrafficTarget{
+			makeRevisionTarget("", pinned, 100),
+		}
+	}
+
+	rollout := app.Status.Rollout
+	if !app.Spec.Rollout.HoldsStableRevision() || rollout.StableRevisionName == "" {
+		return nil
+	}
+
+	if !rollout.IsCanaryActive() {
+		return []serving.TrafficTarget{
+			makeRevisionTarget("", rollout.StableRevisionName, 100),
+		}
+	}
+
+	return []serving.TrafficTarget{
+		makeRevisionTarget("", rollout.StableRevisionName, 100-rollout.CanaryPercent),
+		makeRevisionTarget(CanaryTrafficTag, rollout.CanaryRevisionName, rollout.CanaryPercent),
+	}
+}
+
+func makeRevisionTarget(tag, revisionName string, percent int) serving.TrafficTarget {
+	latestRevision := false
+
+	return serving.TrafficTarget{
+		TrafficTarget: servingv1beta1.TrafficTarget{
+			Tag:            tag,
+			RevisionName:   revisionName,
+			LatestRevision: &latestRevision,
+			Percent:        percent,
+		},
+	}
+}
@@ -0,0 +1,306 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	systemenvinjectorfake "github.com/google/kf/pkg/kf/systemenvinjector/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/knative/serving/pkg/apis/autoscaling"
+	serving "github.com/knative/serving/pkg/apis/serving/v1alpha1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestMakeKnativeService_image(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		status   v1alpha1.SourceStatusFields
+		expected string
+	}{
+		"deploys by digest": {
+			status: v1alpha1.SourceStatusFields{
+				Image:       "gcr.io/my-project/app-my-space-my-app:3",
+				ImageDigest: "sha256:f3a6c8",
+			},
+			expected: "gcr.io/my-project/app-my-space-my-app@sha256:f3a6c8",
+		},
+		"falls back to tag": {
+			status: v1alpha1.SourceStatusFields{
+				Image: "gcr.io/my-project/app-my-space-my-app:3",
+			},
+			expected: "gcr.io/my-project/app-my-space-my-app:3",
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			injector := systemenvinjectorfake.NewFakeSystemEnvInjector(ctrl)
+			injector.EXPECT().ComputeSystemEnv(gomock.Any()).Return(nil, nil)
+
+			app := &v1alpha1.App{}
+			app.Spec.Template.Spec.Containers = []corev1.Container{{}}
+			app.Status.SourceStatusFields = tc.status
+
+			service, err := MakeKnativeService(app, &v1alpha1.Space{}, injector)
+			testutil.AssertNil(t, "err", err)
+
+			testutil.AssertEqual(t, "image", tc.expected, service.Spec.Template.Spec.Containers[0].Image)
+		})
+	}
+}
+
+func TestMakeKnativeService_command(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		source          v1alpha1.SourceSpec
+		command         string
+		args            []string
+		expectedCommand []string
+		expectedArgs    []string
+	}{
+		"buildpack app runs the command with the launcher": {
+			source:       v1alpha1.SourceSpec{BuildpackBuild: v1alpha1.SourceSpecBuildpackBuild{Source: "some-source"}},
+			command:      "bundle exec rails server",
+			expectedArgs: []string{"bundle exec rails server"},
+		},
+		"container app runs the command in a shell": {
+			source:          v1alpha1.SourceSpec{ContainerImage: v1alpha1.SourceSpecContainerImage{Image: "some-image"}},
+			command:         "./server --port $PORT",
+			expectedCommand: []string{"/bin/sh", "-c"},
+			expectedArgs:    []string{"./server --port $PORT"},
+		},
+		"no command keeps the container args": {
+			source:       v1alpha1.SourceSpec{ContainerImage: v1alpha1.SourceSpecContainerImage{Image: "some-image"}},
+			args:         []string{"--verbose"},
+			expectedArgs: []string{"--verbose"},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			injector := systemenvinjectorfake.NewFakeSystemEnvInjector(ctrl)
+			injector.EXPECT().ComputeSystemEnv(gomock.Any()).Return(nil, nil)
+
+			app := &v1alpha1.App{}
+			app.Spec.Source = tc.source
+			app.Spec.Template.Command = tc.command
+			app.Spec.Template.Spec.Containers = []corev1.Container{{Args: tc.args}}
+			app.Status.Image = "some-image"
+
+			service, err := MakeKnativeService(app, &v1alpha1.Space{}, injector)
+			testutil.AssertNil(t, "err", err)
+
+			container := service.Spec.Template.Spec.Containers[0]
+			testutil.AssertEqual(t, "command", tc.expectedCommand, container.Command)
+			testutil.AssertEqual(t, "args", tc.expectedArgs, container.Args)
+		})
+	}
+}
+
+func TestMakeKnativeService_scaling(t *testing.T) {
+	t.Parallel()
+
+	two := 2
+	zero := 0
+
+	for tn, tc := range map[string]struct {
+		instances      v1alpha1.AppSpecInstances
+		allowedSources []string
+		routes         []v1alpha1.RouteSpecFields
+		expectedMin    string
+	}{
+		"autoscaled app can scale to zero": {
+			expectedMin: "",
+		},
+		"allow list keeps an instance running": {
+			allowedSources: []string{"frontend"},
+			expectedMin:    "1",
+		},
+		"allow list raises min of zero": {
+			instances:      v1alpha1.AppSpecInstances{Min: &zero},
+			allowedSources: []string{"frontend"},
+			expectedMin:    "1",
+		},
+		"allow list keeps larger min": {
+			instances:      v1alpha1.AppSpecInstances{Exactly: &two},
+			allowedSources: []string{"frontend"},
+			expectedMin:    "2",
+		},
+		"http routes can scale to zero": {
+			routes:      []v1alpha1.RouteSpecFields{{Domain: "example.com"}},
+			expectedMin: "",
+		},
+		"tcp route keeps an instance running": {
+			routes:      []v1alpha1.RouteSpecFields{{Domain: "tcp.example.com", Port: 1024}},
+			expectedMin: "1",
+		},
+		"stopped app with allow list": {
+			instances:      v1alpha1.AppSpecInstances{Stopped: true},
+			allowedSources: []string{"frontend"},
+			expectedMin:    "0",
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			injector := systemenvinjectorfake.NewFakeSystemEnvInjector(ctrl)
+			injector.EXPECT().ComputeSystemEnv(gomock.Any()).Return(nil, nil)
+
+			app := &v1alpha1.App{}
+			app.Spec.Instances = tc.instances
+			app.Spec.NetworkPolicy.AllowedSourceApps = tc.allowedSources
+			app.Spec.Routes = tc.routes
+			app.Spec.Template.Spec.Containers = []corev1.Container{{}}
+			app.Status.Image = "some-image"
+
+			service, err := MakeKnativeService(app, &v1alpha1.Space{}, injector)
+			testutil.AssertNil(t, "err", err)
+
+			annotations := service.Spec.Template.Annotations
+			testutil.AssertEqual(t, "minScale", tc.expectedMin, annotations[autoscaling.MinScaleAnnotationKey])
+		})
+	}
+}
+
+func TestMakeRuntimeEnv(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	injector := systemenvinjectorfake.NewFakeSystemEnvInjector(ctrl)
+	injector.EXPECT().ComputeSystemEnv(gomock.Any()).Return([]corev1.EnvVar{
+		{Name: "VCAP_APPLICATION", Value: "{}"},
+	}, nil)
+
+	space := &v1alpha1.Space{}
+	space.Spec.Execution.Env = []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "info"},
+		{Name: "VCAP_APPLICATION", Value: "overridden"},
+	}
+
+	env, err := MakeRuntimeEnv(&v1alpha1.App{}, space, injector, []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+	})
+	testutil.AssertNil(t, "err", err)
+
+	testutil.AssertEqual(t, "env", []corev1.EnvVar{
+		{Name: "LOG_LEVEL", Value: "debug"},
+		{Name: "VCAP_APPLICATION", Value: "{}"},
+	}, env)
+}
+
+func TestMakeTraffic(t *testing.T) {
+	t.Parallel()
+
+	canary := v1alpha1.AppSpec{
+		Rollout: v1alpha1.AppSpecRollout{Strategy: v1alpha1.RolloutStrategyCanary},
+	}
+
+	for tn, tc := range map[string]struct {
+		spec     v1alpha1.AppSpec
+		rollout  v1alpha1.AppStatusRollout
+		expected []serving.TrafficTarget
+	}{
+		"immediate sends traffic to latest": {
+			spec: v1alpha1.AppSpec{},
+			rollout: v1alpha1.AppStatusRollout{
+				Phase:              v1alpha1.RolloutPhaseStable,
+				StableRevisionName: "rev-1",
+			},
+			expected: nil,
+		},
+		"canary without stable revision sends traffic to latest": {
+			spec:     canary,
+			expected: nil,
+		},
+		"stable gets all traffic": {
+			spec: canary,
+			rollout: v1alpha1.AppStatusRollout{
+				Phase:              v1alpha1.RolloutPhaseStable,
+				StableRevisionName: "rev-1",
+			},
+			expected: []serving.TrafficTarget{
+				makeRevisionTarget("", "rev-1", 100),
+			},
+		},
+		"canary splits traffic": {
+			spec: canary,
+			rollout: v1alpha1.AppStatusRollout{
+				Phase:              v1alpha1.RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+				CanaryPercent:      10,
+			},
+			expected: []serving.TrafficTarget{
+				makeRevisionTarget("", "rev-1", 90),
+				makeRevisionTarget(CanaryTrafficTag, "rev-2", 10),
+			},
+		},
+		"blue green canary is tagged without traffic": {
+			spec: v1alpha1.AppSpec{
+				Rollout: v1alpha1.AppSpecRollout{Strategy: v1alpha1.RolloutStrategyBlueGreen},
+			},
+			rollout: v1alpha1.AppStatusRollout{
+				Phase:              v1alpha1.RolloutPhaseCanary,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+			},
+			expected: []serving.TrafficTarget{
+				makeRevisionTarget("", "rev-1", 100),
+				makeRevisionTarget(CanaryTrafficTag, "rev-2", 0),
+			},
+		},
+		"rolled back revision gets all traffic": {
+			spec: v1alpha1.AppSpec{
+				Rollout: v1alpha1.AppSpecRollout{RollbackRevisionName: "rev-1"},
+			},
+			rollout: v1alpha1.AppStatusRollout{
+				Phase:              v1alpha1.RolloutPhaseStable,
+				StableRevisionName: "rev-2",
+			},
+			expected: []serving.TrafficTarget{
+				makeRevisionTarget("", "rev-1", 100),
+			},
+		},
+		"aborted canary gets no traffic": {
+			spec: canary,
+			rollout: v1alpha1.AppStatusRollout{
+				Phase:              v1alpha1.RolloutPhaseAborted,
+				StableRevisionName: "rev-1",
+				CanaryRevisionName: "rev-2",
+				CanaryPercent:      10,
+			},
+			expected: []serving.TrafficTarget{
+				makeRevisionTarget("", "rev-1", 100),
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			app := &v1alpha1.App{Spec: tc.spec}
+			app.Status.Rollout = tc.rollout
+
+			testutil.AssertEqual(t, "traffic", tc.expected, MakeTraffic(app))
+		})
+	}
+}
@@ -0,0 +1,102 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/kmeta"
+)
+
+// queueMetricsPort is the port the Knative autoscaler scrapes on the
+// queue-proxy of every instance.
+const queueMetricsPort = 9090
+
+// NetworkPolicyName gets the name of the NetworkPolicy that limits the
+// sources that can reach the App.
+func NetworkPolicyName(app *v1alpha1.App) string {
+	return app.Name
+}
+
+// MakeNetworkPolicy creates a NetworkPolicy that only lets the App's allowed
+// sources, the ingress gateway serving its public routes and the Knative
+// autoscaler reach the App's instances. The VirtualServices of internal
+// routes only check the source in the caller's sidecar, without the policy
+// any App could skip the check by calling the Knative Service directly.
+// Apps without an allow list don't need one.
+func MakeNetworkPolicy(app *v1alpha1.App) *networkingv1.NetworkPolicy {
+	allowed := app.Spec.NetworkPolicy.AllowedSourceApps
+	if len(allowed) == 0 {
+		return nil
+	}
+
+	anyNamespace := &metav1.LabelSelector{}
+	metricsPort := intstr.FromInt(queueMetricsPort)
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      NetworkPolicyName(app),
+			Namespace: app.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(app),
+			},
+			Labels: resources.UnionMaps(app.GetLabels(), app.ComponentLabels("network-policy")),
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: app.ComponentLabels("app-server"),
+			},
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+			Ingress: []networkingv1.NetworkPolicyIngressRule{
+				{
+					From: []networkingv1.NetworkPolicyPeer{
+						{
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{
+									v1alpha1.ManagedByLabel: "kf",
+								},
+								MatchExpressions: []metav1.LabelSelectorRequirement{{
+									Key:      v1alpha1.NameLabel,
+									Operator: metav1.LabelSelectorOpIn,
+									Values:   append([]string{}, allowed...),
+								}},
+							},
+						},
+						{
+							NamespaceSelector: anyNamespace,
+							PodSelector: &metav1.LabelSelector{
+								MatchLabels: map[string]string{"istio": "ingressgateway"},
+							},
+						},
+					},
+				},
+				{
+					From: []networkingv1.NetworkPolicyPeer{{
+						NamespaceSelector: anyNamespace,
+						PodSelector: &metav1.LabelSelector{
+							MatchLabels: map[string]string{"app": "autoscaler"},
+						},
+					}},
+					Ports: []networkingv1.NetworkPolicyPort{{
+						Port: &metricsPort,
+					}},
+				},
+			},
+		},
+	}
+}
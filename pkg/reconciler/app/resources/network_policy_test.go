@@ -0,0 +1,56 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+)
+
+func ExampleMakeNetworkPolicy() {
+	app := &v1alpha1.App{}
+	app.Name = "backend"
+	app.Namespace = "my-space"
+	app.Spec.NetworkPolicy.AllowedSourceApps = []string{"frontend", "admin"}
+
+	policy := MakeNetworkPolicy(app)
+	sources := policy.Spec.Ingress[0].From
+
+	fmt.Println("Name:", policy.Name == NetworkPolicyName(app))
+	fmt.Println("Namespace:", policy.Namespace)
+	fmt.Println("Owner:", policy.OwnerReferences[0].Kind, policy.OwnerReferences[0].Name)
+	fmt.Println("Selects:", policy.Spec.PodSelector.MatchLabels[v1alpha1.NameLabel], policy.Spec.PodSelector.MatchLabels[v1alpha1.ComponentLabel])
+	fmt.Println("Allowed Apps:", sources[0].PodSelector.MatchExpressions[0].Values)
+	fmt.Println("Gateway:", sources[1].PodSelector.MatchLabels["istio"])
+	fmt.Println("Autoscaler port:", policy.Spec.Ingress[1].Ports[0].Port.String())
+
+	// Output: Name: true
+	// Namespace: my-space
+	// Owner: App backend
+	// Selects: backend app-server
+	// Allowed Apps: [frontend admin]
+	// Gateway: ingressgateway
+	// Autoscaler port: 9090
+}
+
+func ExampleMakeNetworkPolicy_noAllowList() {
+	app := &v1alpha1.App{}
+	app.Name = "backend"
+
+	fmt.Println("Policy:", MakeNetworkPolicy(app))
+
+	// Output: Policy: <nil>
+}
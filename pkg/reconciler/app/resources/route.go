@@ -38,7 +38,7 @@ func MakeRoutes(app *v1alpha1.App, space *v1alpha1.Space) ([]v1alpha1.Route, err
 
 		routes = append(routes, v1alpha1.Route{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      v1alpha1.GenerateRouteName(appRoute.Hostname, appRoute.Domain, appRoute.Path),
+				Name:      v1alpha1.GenerateRouteNameFromSpec(*appRoute),
 				Namespace: space.Name,
 				Labels:    resources.UnionMaps(app.GetLabels(), MakeRouteLabels()),
 			},
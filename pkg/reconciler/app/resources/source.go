@@ -16,6 +16,7 @@ package resources
 
 import (
 	"fmt"
+	"sort"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/knative/serving/pkg/resources"
@@ -34,6 +35,10 @@ func MakeSource(app *v1alpha1.App, space *v1alpha1.Space) (*v1alpha1.Source, err
 
 	source.SetSpaceDefaults(space)
 
+	if source.IsBuildpackBuild() {
+		source.BuildpackBuild.CacheClaim = BuildCacheName(app)
+	}
+
 	return &v1alpha1.Source{
 		ObjectMeta: metav1.ObjectMeta{
 			GenerateName: fmt.Sprintf("%s-", app.Name),
@@ -46,3 +51,63 @@ func MakeSource(app *v1alpha1.App, space *v1alpha1.Space) (*v1alpha1.Source, err
 		Spec: *source,
 	}, nil
 }
+
+// ExpiredSources returns the App's Sources that are past the retention limits
+// of the space, newest first. Sources that are still building, are the latest
+// created or ready for the App, or are in the App's revision history count
+// towards the limits but are never returned.
+func ExpiredSources(app *v1alpha1.App, sources []*v1alpha1.Source, retention v1alpha1.SpaceSpecBuildRetention) []*v1alpha1.Source {
+	successfulLimit := v1alpha1.DefaultSuccessfulBuildsLimit
+	if retention.SuccessfulBuildsLimit != nil {
+		successfulLimit = *retention.SuccessfulBuildsLimit
+	}
+
+	failedLimit := v1alpha1.DefaultFailedBuildsLimit
+	if retention.FailedBuildsLimit != nil {
+		failedLimit = *retention.FailedBuildsLimit
+	}
+
+	inUse := map[string]bool{
+		app.Status.LatestCreatedSourceName: true,
+		app.Status.LatestReadySourceName:   true,
+	}
+	for _, rev := range app.Status.History {
+		inUse[rev.SourceName] = true
+	}
+
+	// Don't modify the lister's slice.
+	sorted := append([]*v1alpha1.Source{}, sources...)
+
+	// sort descending
+	sort.SliceStable(sorted, func(i int, j int) bool {
+		return sorted[j].CreationTimestamp.Before(&sorted[i].CreationTimestamp)
+	})
+
+	var expired []*v1alpha1.Source
+	successful, failed := 0, 0
+	for _, source := range sorted {
+		if !metav1.IsControlledBy(source, app) {
+			continue
+		}
+
+		cond := source.Status.GetCondition(v1alpha1.SourceConditionSucceeded)
+		if cond == nil || cond.IsUnknown() {
+			continue
+		}
+
+		var overLimit bool
+		if cond.IsTrue() {
+			successful++
+			overLimit = successful > successfulLimit
+		} else {
+			failed++
+			overLimit = failed > failedLimit
+		}
+
+		if overLimit && !inUse[source.Name] {
+			expired = append(expired, source)
+		}
+	}
+
+	return expired
+}
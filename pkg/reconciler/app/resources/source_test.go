@@ -0,0 +1,150 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/apis"
+	"knative.dev/pkg/kmeta"
+)
+
+func TestExpiredSources(t *testing.T) {
+	t.Parallel()
+
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.UID = "my-app-uid"
+
+	// makeSource creates a Source for the App that was created minute minutes
+	// after the first one.
+	makeSource := func(name string, minute int64, status corev1.ConditionStatus) *v1alpha1.Source {
+		source := &v1alpha1.Source{}
+		source.Name = name
+		source.CreationTimestamp = metav1.Unix(minute*60, 0)
+		source.OwnerReferences = []metav1.OwnerReference{*kmeta.NewControllerRef(app)}
+		source.Status.Conditions = []apis.Condition{{
+			Type:   v1alpha1.SourceConditionSucceeded,
+			Status: status,
+		}}
+		return source
+	}
+
+	limit := func(n int) *int {
+		return &n
+	}
+
+	cases := map[string]struct {
+		sources   []*v1alpha1.Source
+		retention v1alpha1.SpaceSpecBuildRetention
+		status    v1alpha1.AppStatus
+		expected  []string
+	}{
+		"under limits": {
+			sources: []*v1alpha1.Source{
+				makeSource("ok-1", 1, corev1.ConditionTrue),
+				makeSource("fail-1", 2, corev1.ConditionFalse),
+			},
+			retention: v1alpha1.SpaceSpecBuildRetention{
+				SuccessfulBuildsLimit: limit(1),
+				FailedBuildsLimit:     limit(1),
+			},
+		},
+		"oldest over limits": {
+			sources: []*v1alpha1.Source{
+				makeSource("ok-1", 1, corev1.ConditionTrue),
+				makeSource("ok-3", 3, corev1.ConditionTrue),
+				makeSource("fail-2", 2, corev1.ConditionFalse),
+				makeSource("ok-4", 4, corev1.ConditionTrue),
+				makeSource("fail-5", 5, corev1.ConditionFalse),
+			},
+			retention: v1alpha1.SpaceSpecBuildRetention{
+				SuccessfulBuildsLimit: limit(2),
+				FailedBuildsLimit:     limit(1),
+			},
+			expected: []string{"fail-2", "ok-1"},
+		},
+		"builds in progress are kept": {
+			sources: []*v1alpha1.Source{
+				makeSource("building-1", 1, corev1.ConditionUnknown),
+				makeSource("fail-2", 2, corev1.ConditionFalse),
+			},
+			retention: v1alpha1.SpaceSpecBuildRetention{
+				SuccessfulBuildsLimit: limit(0),
+				FailedBuildsLimit:     limit(0),
+			},
+			expected: []string{"fail-2"},
+		},
+		"sources in use are kept": {
+			sources: []*v1alpha1.Source{
+				makeSource("ok-1", 1, corev1.ConditionTrue),
+				makeSource("ok-2", 2, corev1.ConditionTrue),
+				makeSource("ok-3", 3, corev1.ConditionTrue),
+				makeSource("fail-4", 4, corev1.ConditionFalse),
+			},
+			status: v1alpha1.AppStatus{
+				LatestCreatedSourceName: "fail-4",
+				LatestReadySourceName:   "ok-3",
+				History: []v1alpha1.AppRevision{
+					{RevisionName: "rev-1", SourceName: "ok-1"},
+				},
+			},
+			retention: v1alpha1.SpaceSpecBuildRetention{
+				SuccessfulBuildsLimit: limit(0),
+				FailedBuildsLimit:     limit(0),
+			},
+			expected: []string{"ok-2"},
+		},
+		"other owners are ignored": {
+			sources: func() []*v1alpha1.Source {
+				orphan := makeSource("orphan-1", 1, corev1.ConditionTrue)
+				orphan.OwnerReferences = nil
+				return []*v1alpha1.Source{orphan}
+			}(),
+			retention: v1alpha1.SpaceSpecBuildRetention{
+				SuccessfulBuildsLimit: limit(0),
+			},
+		},
+		"default limits": {
+			sources: func() []*v1alpha1.Source {
+				var sources []*v1alpha1.Source
+				for i := int64(1); i <= 12; i++ {
+					sources = append(sources, makeSource(fmt.Sprintf("ok-%d", i), i, corev1.ConditionTrue))
+				}
+				return sources
+			}(),
+			expected: []string{"ok-2", "ok-1"},
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			app := app.DeepCopy()
+			app.Status = tc.status
+
+			var actual []string
+			for _, source := range ExpiredSources(app, tc.sources, tc.retention) {
+				actual = append(actual, source.Name)
+			}
+
+			testutil.AssertEqual(t, "expired", tc.expected, actual)
+		})
+	}
+}
@@ -20,9 +20,11 @@ import (
 	kfclientset "github.com/google/kf/pkg/client/clientset/versioned"
 	kfscheme "github.com/google/kf/pkg/client/clientset/versioned/scheme"
 	kfclient "github.com/google/kf/pkg/client/injection/client"
+	"github.com/google/kf/pkg/client/injection/dynamicclient"
 	knativeclientset "github.com/knative/serving/pkg/client/clientset/versioned"
 	knativeclient "github.com/knative/serving/pkg/client/injection/client"
 	"go.uber.org/zap"
+	"k8s.io/client-go/dynamic"
 	"k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/kubernetes/scheme"
 	sharedclientset "knative.dev/pkg/client/clientset/versioned"
@@ -47,6 +49,9 @@ type Base struct {
 	// ServingClientSet allows us to configure Knative Serving objects
 	ServingClientSet knativeclientset.Interface
 
+	// DynamicClientSet allows us to configure objects without a typed client
+	DynamicClientSet dynamic.Interface
+
 	// ConfigMapWatcher allows us to watch for ConfigMap changes.
 	ConfigMapWatcher configmap.Watcher
 
@@ -72,6 +77,7 @@ func NewBase(ctx context.Context, controllerAgentName string, cmw configmap.Watc
 		SharedClientSet:  sharedclient.Get(ctx),
 		KfClientSet:      kfclient.Get(ctx),
 		ServingClientSet: knativeclient.Get(ctx),
+		DynamicClientSet: dynamicclient.Get(ctx),
 		ConfigMapWatcher: cmw,
 		Logger:           logger,
 	}
@@ -21,9 +21,12 @@ import (
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	appinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/app"
 	routeinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/route"
+	spaceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/space"
 	"github.com/google/kf/pkg/reconciler"
 	virtualserviceinformer "knative.dev/pkg/client/injection/informers/istio/v1alpha3/virtualservice"
+	serviceinformer "knative.dev/pkg/injection/informers/kubeinformers/corev1/service"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 
 	"knative.dev/pkg/configmap"
@@ -39,13 +42,17 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	vsInformer := virtualserviceinformer.Get(ctx)
 	routeInformer := routeinformer.Get(ctx)
 	appInformer := appinformer.Get(ctx)
+	spaceInformer := spaceinformer.Get(ctx)
+	serviceInformer := serviceinformer.Get(ctx)
 
 	// Create reconciler
 	c := &Reconciler{
 		Base:                 reconciler.NewBase(ctx, "route-controller", cmw),
 		routeLister:          routeInformer.Lister(),
 		appLister:            appInformer.Lister(),
+		spaceLister:          spaceInformer.Lister(),
 		virtualServiceLister: vsInformer.Lister(),
+		serviceLister:        serviceInformer.Lister(),
 	}
 
 	impl := controller.NewImpl(c, logger, "Routes")
@@ -55,12 +62,87 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	// Watch for changes in sub-resources so we can sync accordingly
 	routeInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
+	// The access policies of every route on a host are enforced by one
+	// EnvoyFilter, so the remaining routes rebuild it when one is deleted.
+	routeInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+
+			deleted, ok := obj.(*v1alpha1.Route)
+			if !ok {
+				return
+			}
+
+			routes, err := c.routeLister.Routes(deleted.GetNamespace()).List(labels.Everything())
+			if err != nil {
+				c.Logger.Warnf("failed to list routes for space %s: %s", deleted.GetNamespace(), err)
+				return
+			}
+
+			for _, route := range routes {
+				if route.Spec.Hostname == deleted.Spec.Hostname && route.Spec.Domain == deleted.Spec.Domain {
+					impl.Enqueue(route)
+				}
+			}
+		},
+	})
+
 	vsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Route")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	serviceInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Route")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	enqueueRoutesForApp := func(obj interface{}) {
+		app := obj.(*v1alpha1.App)
+
+		routes, err := c.routeLister.Routes(app.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			c.Logger.Warnf("failed to list routes for app %s/%s: %s", app.GetNamespace(), app.GetName(), err)
+			return
+		}
+
+		for _, route := range routes {
+			for _, appName := range route.Spec.AppNames {
+				if appName == app.GetName() {
+					impl.Enqueue(route)
+					break
+				}
+			}
+		}
+	}
+
+	// Routes are served over HTTPS if their space has a certificate for the
+	// domain.
+	spaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			space := new.(*v1alpha1.Space)
+
+			routes, err := c.routeLister.Routes(space.Name).List(labels.Everything())
+			if err != nil {
+				c.Logger.Warnf("failed to list routes for space %s: %s", space.Name, err)
+				return
+			}
+
+			for _, route := range routes {
+				impl.Enqueue(route)
+			}
+		},
+	})
+
 	appInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		// Routes report whether their Apps exist and enforce their network
+		// policies, so they need to be reconciled when a bound App changes.
+		AddFunc: enqueueRoutesForApp,
+		UpdateFunc: func(old, new interface{}) {
+			enqueueRoutesForApp(new)
+		},
 		DeleteFunc: func(obj interface{}) {
 			start := time.Now()
 			app := obj.(*v1alpha1.App)
@@ -17,6 +17,7 @@ package route
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"sort"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
@@ -25,9 +26,14 @@ import (
 	"github.com/google/kf/pkg/reconciler"
 	"github.com/google/kf/pkg/reconciler/route/resources"
 	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	corev1listers "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/tools/cache"
 	networking "knative.dev/pkg/apis/istio/v1alpha3"
 	istiolisters "knative.dev/pkg/client/listers/istio/v1alpha3"
@@ -43,7 +49,9 @@ type Reconciler struct {
 	// listers index properties about resources
 	routeLister          kflisters.RouteLister
 	appLister            kflisters.AppLister
+	spaceLister          kflisters.SpaceLister
 	virtualServiceLister istiolisters.VirtualServiceLister
+	serviceLister        corev1listers.ServiceLister
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -79,8 +87,21 @@ func (r *Reconciler) reconcileRoute(ctx context.Context, namespace, name string,
 	// Don't modify the informers copy
 	toReconcile := original.DeepCopy()
 
-	// Reconcile this copy of the route.
-	return r.ApplyChanges(ctx, toReconcile, deleted, logger)
+	// Reconcile this copy of the route and then write back any status
+	// updates regardless of whether the reconciliation errored out.
+	reconcileErr := r.ApplyChanges(ctx, toReconcile, deleted, logger)
+	if deleted || equality.Semantic.DeepEqual(original.Status, toReconcile.Status) {
+		// If we didn't change anything then don't call updateStatus.
+		// This is important because the copy we loaded from the informer's
+		// cache may be stale and we don't want to overwrite a prior update
+		// to status with this stale state.
+
+	} else if _, uErr := r.updateStatus(toReconcile); uErr != nil {
+		logger.Warnw("Failed to update Route status", zap.Error(uErr))
+		return uErr
+	}
+
+	return reconcileErr
 }
 
 func (r *Reconciler) ReconcileAppDeletion(ctx context.Context, app *v1alpha1.App) error {
@@ -117,12 +138,102 @@ func (r *Reconciler) ReconcileAppDeletion(ctx context.Context, app *v1alpha1.App
 // status of the Route .
 func (r *Reconciler) ApplyChanges(ctx context.Context, route *v1alpha1.Route, deleted bool, logger *zap.SugaredLogger) error {
 	route.SetDefaults(ctx)
+	route.Status.InitializeConditions()
+
+	// Check bound Apps
+	allowedSources := make(map[string][]string)
+	{
+		var missingApps []string
+		for _, appName := range route.Spec.AppNames {
+			app, err := r.appLister.Apps(route.GetNamespace()).Get(appName)
+			if apierrs.IsNotFound(err) {
+				missingApps = append(missingApps, appName)
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			allowedSources[appName] = app.Spec.NetworkPolicy.AllowedSourceApps
+		}
+
+		route.Status.PropagateBoundApps(route.Spec, missingApps)
+	}
+
+	space, err := r.spaceLister.Get(route.GetNamespace())
+	switch {
+	case apierrs.IsNotFound(err):
+		// Routes in namespaces that aren't spaces use the space defaults.
+		space = &v1alpha1.Space{}
+		space.SetDefaults(context.Background())
+	case err != nil:
+		return err
+	}
+
+	// TCP routes can only use the ports the space reserved for them.
+	if route.Spec.IsTCP() && !space.Spec.Execution.ReservesTCPPort(route.Spec.Domain, route.Spec.Port) {
+		route.Status.MarkPortNotReserved(route.Spec.RouteSpecFields)
+		return nil
+	}
+
+	// Sync Services
+	{
+		condition := route.Status.ServicesCondition()
+		desired := resources.MakeServices(route, allowedSources)
+
+		for _, svc := range desired {
+			actual, err := r.serviceLister.Services(svc.GetNamespace()).Get(svc.Name)
+			if errors.IsNotFound(err) {
+				// Service doesn't exist, make one.
+				if _, err := r.KubeClientSet.CoreV1().Services(svc.GetNamespace()).Create(svc); err != nil {
+					return condition.MarkReconciliationError("creating", err)
+				}
+			} else if err != nil {
+				return condition.MarkReconciliationError("getting latest", err)
+			} else if !metav1.IsControlledBy(actual, route) {
+				return condition.MarkChildNotOwned(svc.Name)
+			} else if _, err := r.reconcileService(svc, actual); err != nil {
+				return condition.MarkReconciliationError("updating existing", err)
+			}
+		}
+
+		if err := r.deleteStaleServices(route, desired); err != nil {
+			return condition.MarkReconciliationError("deleting stale", err)
+		}
+
+		route.Status.MarkServicesReady()
+	}
+
+	// Sync ServiceEntry
+	if desired := resources.MakeServiceEntry(route); desired != nil {
+		condition := route.Status.ServiceEntryCondition()
+
+		// ServiceEntries don't have an informer, so they're read from the
+		// API server.
+		client := r.DynamicClientSet.Resource(resources.ServiceEntryResource).Namespace(desired.GetNamespace())
+		actual, err := client.Get(desired.GetName(), metav1.GetOptions{})
+		if errors.IsNotFound(err) {
+			// ServiceEntry doesn't exist, make one.
+			if _, err := client.Create(desired, metav1.CreateOptions{}); err != nil {
+				return condition.MarkReconciliationError("creating", err)
+			}
+		} else if err != nil {
+			return condition.MarkReconciliationError("getting latest", err)
+		} else if actual.GetAnnotations()["space"] == route.GetNamespace() {
+			// Hosts that belong to another space are left alone, the
+			// VirtualService reports the conflict.
+			if err := r.reconcileServiceEntry(desired, actual, deleted); err != nil {
+				return condition.MarkReconciliationError("updating existing", err)
+			}
+		}
+	}
+	route.Status.MarkServiceEntryReady()
 
 	// Sync VirtualService
 	{
-		desired, err := resources.MakeVirtualService(route)
+		condition := route.Status.VirtualServiceCondition()
+		desired, err := resources.MakeVirtualService(route, allowedSources, space)
 		if err != nil {
-			return err
+			return condition.MarkTemplateError(err)
 		}
 
 		actual, err := r.virtualServiceLister.VirtualServices(desired.GetNamespace()).Get(desired.Name)
@@ -130,19 +241,79 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, route *v1alpha1.Route, de
 			// VirtualService doesn't exist, make one.
 			actual, err = r.SharedClientSet.Networking().VirtualServices(desired.GetNamespace()).Create(desired)
 			if err != nil {
-				return err
+				return condition.MarkReconciliationError("creating", err)
 			}
 		} else if err != nil {
-			return err
-		} else if actual, err = r.reconcile(desired, actual, deleted, logger); err != nil {
-			return err
+			return condition.MarkReconciliationError("getting latest", err)
+		} else if actual.Annotations["space"] != route.GetNamespace() {
+			// The VirtualService belongs to another space, leave it alone.
+			route.Status.PropagateVirtualServiceStatus(route, actual)
+			return nil
+		} else if actual, err = r.reconcile(route, desired, actual, deleted, logger); err != nil {
+			return condition.MarkReconciliationError("updating existing", err)
+		}
+
+		route.Status.PropagateVirtualServiceStatus(route, actual)
+	}
+
+	// Sync EnvoyFilter
+	{
+		condition := route.Status.AccessPolicyCondition()
+
+		// Every route on the host shares the EnvoyFilter, and wildcard hosts
+		// need to know the hosts with their own routes.
+		routes, err := r.routeLister.List(labels.Everything())
+		if err != nil {
+			return condition.MarkReconciliationError("listing routes for", err)
 		}
+
+		desired, err := resources.MakeAccessPolicy(route, routes)
+		if err != nil {
+			return condition.MarkTemplateError(err)
+		}
+
+		// EnvoyFilters don't have an informer, so they're read from the API
+		// server.
+		name := resources.AccessPolicyName(route)
+		client := r.DynamicClientSet.Resource(resources.EnvoyFilterResource).Namespace(route.GetNamespace())
+		actual, err := client.Get(name, metav1.GetOptions{})
+		switch {
+		case errors.IsNotFound(err):
+			if desired != nil {
+				if _, err := client.Create(desired, metav1.CreateOptions{}); err != nil {
+					return condition.MarkReconciliationError("creating", err)
+				}
+			}
+		case err != nil:
+			return condition.MarkReconciliationError("getting latest", err)
+		case actual.GetLabels()[resources.ManagedByLabel] != "kf":
+			return condition.MarkChildNotOwned(name)
+		case desired == nil:
+			// None of the routes on the host restrict clients anymore.
+			if err := client.Delete(name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+				return condition.MarkReconciliationError("deleting", err)
+			}
+		default:
+			if err := r.reconcileAccessPolicy(desired, actual); err != nil {
+				return condition.MarkReconciliationError("updating existing", err)
+			}
+		}
+
+		route.Status.PropagateAccessPolicy(route.Spec)
+	}
+
+	route.Status.PropagateURL(route.Spec.RouteSpecFields)
+	if resources.ServesHTTPS(route, space) {
+		route.Status.URL.Scheme = "https"
 	}
 
+	// Making it to the bottom of the reconciler means we've synchronized.
+	route.Status.ObservedGeneration = route.Generation
+
 	return nil
 }
 
-func (r *Reconciler) reconcile(desired, actual *networking.VirtualService, deleted bool, logger *zap.SugaredLogger) (*networking.VirtualService, error) {
+func (r *Reconciler) reconcile(route *v1alpha1.Route, desired, actual *networking.VirtualService, deleted bool, logger *zap.SugaredLogger) (*networking.VirtualService, error) {
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
 	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
@@ -162,6 +333,17 @@ func (r *Reconciler) reconcile(desired, actual *networking.VirtualService, delet
 	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
 	existing.ObjectMeta.Annotations = desired.ObjectMeta.Annotations
 
+	// The gateways depend on the domain, which every route on the host
+	// shares.
+	existing.Spec.Gateways = desired.Spec.Gateways
+
+	// TCP routes are matched by port, so the route for the port is replaced
+	// rather than merged. It's missing from desired if no Apps receive
+	// traffic.
+	if route.Spec.IsTCP() {
+		existing.Spec.TCP = removePort(existing.Spec.TCP, route.Spec.Port)
+	}
+
 	if deleted {
 		existing.OwnerReferences = algorithms.Delete(
 			v1alpha1.OwnerReferences(existing.OwnerReferences),
@@ -173,6 +355,10 @@ func (r *Reconciler) reconcile(desired, actual *networking.VirtualService, delet
 			v1alpha1.HTTPRoutes(desired.Spec.HTTP),
 		).(v1alpha1.HTTPRoutes)
 	} else {
+		// Drop the routes previously generated for this path so sources that
+		// are no longer allowed by a network policy stop matching.
+		existing.Spec.HTTP = removePaths(existing.Spec.HTTP, desired.Spec.HTTP)
+
 		existing.OwnerReferences = algorithms.Merge(
 			v1alpha1.OwnerReferences(existing.OwnerReferences),
 			v1alpha1.OwnerReferences(desired.OwnerReferences),
@@ -183,12 +369,197 @@ func (r *Reconciler) reconcile(desired, actual *networking.VirtualService, delet
 			v1alpha1.HTTPRoutes(desired.Spec.HTTP),
 		).(v1alpha1.HTTPRoutes)
 
-		// Sort by reverse to defer to the longest matchers.
-		sort.Sort(sort.Reverse(v1alpha1.HTTPRoutes(existing.Spec.HTTP)))
+		existing.Spec.TCP = algorithms.Merge(
+			v1alpha1.TCPRoutes(existing.Spec.TCP),
+			v1alpha1.TCPRoutes(desired.Spec.TCP),
+		).(v1alpha1.TCPRoutes)
 	}
 
+	// Istio uses the first route that matches a request, so order the routes
+	// of every path on the host by precedence.
+	sort.Sort(v1alpha1.HTTPRoutes(existing.Spec.HTTP))
+
 	return r.SharedClientSet.
 		Networking().
 		VirtualServices(existing.GetNamespace()).
 		Update(existing)
 }
+
+// removePaths returns the routes in existing that don't match the URI of any
+// route in desired.
+func removePaths(existing, desired []networking.HTTPRoute) []networking.HTTPRoute {
+	paths := make(map[string]bool)
+	for _, route := range desired {
+		paths[uriKey(route)] = true
+	}
+
+	var remaining []networking.HTTPRoute
+	for _, route := range existing {
+		if !paths[uriKey(route)] {
+			remaining = append(remaining, route)
+		}
+	}
+
+	return remaining
+}
+
+// removePort returns the routes in existing that don't match the port.
+func removePort(existing []networking.TCPRoute, port int32) []networking.TCPRoute {
+	var remaining []networking.TCPRoute
+	for _, route := range existing {
+		matches := false
+		for _, m := range route.Match {
+			if m.Port == int(port) {
+				matches = true
+			}
+		}
+
+		if !matches {
+			remaining = append(remaining, route)
+		}
+	}
+
+	return remaining
+}
+
+func uriKey(route networking.HTTPRoute) string {
+	var key string
+	for _, m := range route.Match {
+		if m.URI != nil {
+			key += m.URI.Exact + m.URI.Prefix + m.URI.Suffix + m.URI.Regex
+		}
+	}
+	return key
+}
+
+func (r *Reconciler) reconcileServiceEntry(desired, actual *unstructured.Unstructured, deleted bool) error {
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Every route on the host owns the ServiceEntry.
+	var ownerRefs v1alpha1.OwnerReferences
+	if deleted {
+		ownerRefs = algorithms.Delete(
+			v1alpha1.OwnerReferences(existing.GetOwnerReferences()),
+			v1alpha1.OwnerReferences(desired.GetOwnerReferences()),
+		).(v1alpha1.OwnerReferences)
+	} else {
+		ownerRefs = algorithms.Merge(
+			v1alpha1.OwnerReferences(existing.GetOwnerReferences()),
+			v1alpha1.OwnerReferences(desired.GetOwnerReferences()),
+		).(v1alpha1.OwnerReferences)
+	}
+
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.GetLabels(), actual.GetLabels())
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Object["spec"], actual.Object["spec"])
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual([]metav1.OwnerReference(ownerRefs), actual.GetOwnerReferences())
+
+	if semanticEqual {
+		return nil
+	}
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.SetLabels(desired.GetLabels())
+	existing.SetOwnerReferences(ownerRefs)
+	existing.Object["spec"] = desired.Object["spec"]
+
+	_, err := r.DynamicClientSet.
+		Resource(resources.ServiceEntryResource).
+		Namespace(existing.GetNamespace()).
+		Update(existing, metav1.UpdateOptions{})
+	return err
+}
+
+func (r *Reconciler) reconcileAccessPolicy(desired, actual *unstructured.Unstructured) error {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.GetLabels(), actual.GetLabels())
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Object["spec"], actual.Object["spec"])
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.GetOwnerReferences(), actual.GetOwnerReferences())
+
+	if semanticEqual {
+		return nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.SetLabels(desired.GetLabels())
+	existing.SetOwnerReferences(desired.GetOwnerReferences())
+	existing.Object["spec"] = desired.Object["spec"]
+
+	_, err := r.DynamicClientSet.
+		Resource(resources.EnvoyFilterResource).
+		Namespace(existing.GetNamespace()).
+		Update(existing, metav1.UpdateOptions{})
+	return err
+}
+
+func (r *Reconciler) reconcileService(desired, actual *corev1.Service) (*corev1.Service, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec.Selector, actual.Spec.Selector)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec.Ports, actual.Spec.Ports)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. the ClusterIP).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec.Selector = desired.Spec.Selector
+	existing.Spec.Ports = desired.Spec.Ports
+
+	return r.KubeClientSet.CoreV1().Services(existing.GetNamespace()).Update(existing)
+}
+
+// deleteStaleServices removes the Services of the route for Apps that are no
+// longer bound to it.
+func (r *Reconciler) deleteStaleServices(route *v1alpha1.Route, desired []*corev1.Service) error {
+	selector := labels.SelectorFromSet(labels.Set{resources.RouteNameLabel: route.Name})
+	actual, err := r.serviceLister.Services(route.GetNamespace()).List(selector)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[string]bool)
+	for _, svc := range desired {
+		wanted[svc.Name] = true
+	}
+
+	for _, svc := range actual {
+		if wanted[svc.Name] || !metav1.IsControlledBy(svc, route) {
+			continue
+		}
+
+		if err := r.KubeClientSet.
+			CoreV1().
+			Services(svc.GetNamespace()).
+			Delete(svc.Name, &metav1.DeleteOptions{}); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) updateStatus(desired *v1alpha1.Route) (*v1alpha1.Route, error) {
+	actual, err := r.routeLister.Routes(desired.GetNamespace()).Get(desired.Name)
+	if err != nil {
+		return nil, err
+	}
+	// If there's nothing to update, just return.
+	if reflect.DeepEqual(actual.Status, desired.Status) {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Status = desired.Status
+
+	return r.KfClientSet.KfV1alpha1().Routes(existing.GetNamespace()).UpdateStatus(existing)
+}
@@ -0,0 +1,323 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"net"
+	"path"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/kmeta"
+)
+
+// EnvoyFilterResource is Istio's EnvoyFilter resource. There's no typed
+// client for it, so EnvoyFilters are managed with the dynamic client.
+var EnvoyFilterResource = schema.GroupVersionResource{
+	Group:    "networking.istio.io",
+	Version:  "v1alpha3",
+	Resource: "envoyfilters",
+}
+
+// AccessPolicyName returns the name of the EnvoyFilter that enforces the
+// access policies of the routes on the route's host.
+func AccessPolicyName(route *v1alpha1.Route) string {
+	return v1alpha1.GenerateName(route.Spec.Hostname, route.Spec.Domain)
+}
+
+// MakeAccessPolicy creates an EnvoyFilter that adds an RBAC filter to the
+// ingress gateway. The filter rejects requests for the routes on the route's
+// host that come from addresses their access policies don't allow with a
+// 403. Addresses are taken from the connection rather than from headers so
+// clients can't forge them. routes contains every Route in the cluster, it
+// returns nil if none of the routes on the host restrict clients.
+func MakeAccessPolicy(route *v1alpha1.Route, routes []*v1alpha1.Route) (*unstructured.Unstructured, error) {
+	host := routeHost(route)
+
+	// The routes on the host all belong to the space that owns its
+	// VirtualService. A wildcard host doesn't receive the requests for hosts
+	// that have their own routes.
+	var hostRoutes []*v1alpha1.Route
+	var specificHosts []string
+	restricted := false
+	for _, r := range routes {
+		if r.GetDeletionTimestamp() != nil || r.Spec.Internal || r.Spec.IsTCP() {
+			continue
+		}
+
+		switch {
+		case r.GetNamespace() == route.GetNamespace() && routeHost(r) == host:
+			hostRoutes = append(hostRoutes, r)
+			restricted = restricted || r.Spec.AccessPolicy.HasSourceCIDRs()
+		case route.Spec.Hostname == "*" && r.Spec.Domain == route.Spec.Domain && r.Spec.Hostname != "*":
+			specificHosts = append(specificHosts, routeHost(r))
+		}
+	}
+
+	if !restricted {
+		return nil, nil
+	}
+
+	// Requests are matched by the first route in the order Istio uses.
+	sort.Slice(hostRoutes, func(i, j int) bool {
+		return pathPrecedes(hostRoutes[i], hostRoutes[j])
+	})
+
+	authority := hostPermission(host)
+	if len(specificHosts) > 0 {
+		sort.Strings(specificHosts)
+
+		var others []interface{}
+		for _, h := range specificHosts {
+			others = append(others, hostPermission(h))
+		}
+		authority = andRules(authority, notRule(orRules(others...)))
+	}
+
+	policies := make(map[string]interface{})
+	var ownerRefs []metav1.OwnerReference
+	var claimed, restrictedRequests []interface{}
+	for _, r := range hostRoutes {
+		// Each route will own the EnvoyFilter. Therefore none of them can be
+		// a controller.
+		ownerRef := *kmeta.NewControllerRef(r)
+		ownerRef.Controller = nil
+		ownerRef.BlockOwnerDeletion = nil
+		ownerRefs = append(ownerRefs, ownerRef)
+
+		pathMatch, err := pathPermission(r)
+		if err != nil {
+			return nil, err
+		}
+
+		if r.Spec.AccessPolicy.HasSourceCIDRs() {
+			principal, err := sourcePrincipal(r.Spec.AccessPolicy)
+			if err != nil {
+				return nil, err
+			}
+
+			// Paths that a route with a higher precedence matches belong to
+			// that route.
+			requests := []interface{}{authority, pathMatch}
+			if len(claimed) > 0 {
+				requests = append(requests, notRule(orRules(claimed...)))
+			}
+			permission := andRules(requests...)
+
+			policies["route-"+r.Name] = map[string]interface{}{
+				"permissions": []interface{}{permission},
+				"principals":  []interface{}{principal},
+			}
+			restrictedRequests = append(restrictedRequests, permission)
+		}
+
+		claimed = append(claimed, pathMatch)
+	}
+
+	// Every filter sees all of the gateway's requests, the ones for other
+	// hosts or unrestricted routes go through.
+	policies["other-requests"] = map[string]interface{}{
+		"permissions": []interface{}{notRule(orRules(restrictedRequests...))},
+		"principals":  []interface{}{map[string]interface{}{"any": true}},
+	}
+
+	envoyFilter := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "networking.istio.io/v1alpha3",
+			"kind":       "EnvoyFilter",
+			"spec": map[string]interface{}{
+				"workloadLabels": map[string]interface{}{
+					"istio": "ingressgateway",
+				},
+				"filters": []interface{}{
+					map[string]interface{}{
+						"listenerMatch": map[string]interface{}{
+							"listenerType":     "GATEWAY",
+							"listenerProtocol": "HTTP",
+						},
+						"insertPosition": map[string]interface{}{
+							"index": "FIRST",
+						},
+						"filterType": "HTTP",
+						"filterName": "envoy.filters.http.rbac",
+						"filterConfig": map[string]interface{}{
+							"rules": map[string]interface{}{
+								"action":   "ALLOW",
+								"policies": policies,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	envoyFilter.SetName(AccessPolicyName(route))
+	envoyFilter.SetNamespace(route.GetNamespace())
+	envoyFilter.SetOwnerReferences(ownerRefs)
+	envoyFilter.SetLabels(resources.UnionMaps(route.GetLabels(), map[string]string{
+		ManagedByLabel: "kf",
+	}))
+
+	return envoyFilter, nil
+}
+
+// pathPrecedes returns true if Istio matches requests to route a before b:
+// exact paths before prefixes and longer paths before shorter ones.
+func pathPrecedes(a, b *v1alpha1.Route) bool {
+	if a.Spec.IsExactPath() != b.Spec.IsExactPath() {
+		return a.Spec.IsExactPath()
+	}
+
+	pathA, pathB := path.Join("/", a.Spec.Path), path.Join("/", b.Spec.Path)
+	if len(pathA) != len(pathB) {
+		return len(pathA) > len(pathB)
+	}
+
+	if pathA != pathB {
+		return pathA < pathB
+	}
+
+	return a.Name < b.Name
+}
+
+// hostPermission matches requests for the host on any port. Hosts are case
+// insensitive and Envoy's regexes aren't, so each letter matches both cases.
+func hostPermission(host string) map[string]interface{} {
+	pattern := regexp.QuoteMeta(host)
+	if strings.HasPrefix(host, "*.") {
+		pattern = "[^:]+" + regexp.QuoteMeta(strings.TrimPrefix(host, "*"))
+	}
+
+	var caseless strings.Builder
+	for _, c := range pattern {
+		if lower, upper := strings.ToLower(string(c)), strings.ToUpper(string(c)); lower != upper {
+			caseless.WriteString("[" + lower + upper + "]")
+		} else {
+			caseless.WriteRune(c)
+		}
+	}
+
+	return headerPermission(":authority", caseless.String()+"(:[0-9]+)?")
+}
+
+// pathPermission matches requests for the route's path the way its
+// VirtualService does. The :path header includes the query string.
+func pathPermission(route *v1alpha1.Route) (map[string]interface{}, error) {
+	urlPath := path.Join("/", route.Spec.Path, "/")
+
+	switch {
+	case route.Spec.IsExactPath():
+		return headerPermission(":path", regexp.QuoteMeta(urlPath)+`(\?.*)?`), nil
+	case route.Spec.Path != "":
+		regexpPath, err := buildPathRegex(urlPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert path to regexp: %s", err)
+		}
+		return headerPermission(":path", regexpPath+`(\?.*)?`), nil
+	default:
+		return map[string]interface{}{"any": true}, nil
+	}
+}
+
+// sourcePrincipal matches connections from the addresses the access policy
+// allows.
+func sourcePrincipal(policy *v1alpha1.RouteSpecAccessPolicy) (map[string]interface{}, error) {
+	allowed, err := sourceIPs(policy.AllowedSourceCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	denied, err := sourceIPs(policy.DeniedSourceCIDRs)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := []interface{}{map[string]interface{}{"any": true}}
+	if len(allowed) > 0 {
+		ids = []interface{}{map[string]interface{}{
+			"or_ids": map[string]interface{}{"ids": allowed},
+		}}
+	}
+
+	if len(denied) > 0 {
+		ids = append(ids, map[string]interface{}{
+			"not_id": map[string]interface{}{
+				"or_ids": map[string]interface{}{"ids": denied},
+			},
+		})
+	}
+
+	return map[string]interface{}{
+		"and_ids": map[string]interface{}{"ids": ids},
+	}, nil
+}
+
+func sourceIPs(cidrs []string) ([]interface{}, error) {
+	var out []interface{}
+	for _, cidr := range cidrs {
+		_, ipNet, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse CIDR: %s", err)
+		}
+
+		ip := ipNet.IP.To4()
+		if ip == nil {
+			return nil, fmt.Errorf("CIDR %s isn't an IPv4 range", cidr)
+		}
+
+		ones, _ := ipNet.Mask.Size()
+		out = append(out, map[string]interface{}{
+			"source_ip": map[string]interface{}{
+				"address_prefix": ip.String(),
+				"prefix_len":     int64(ones),
+			},
+		})
+	}
+
+	return out, nil
+}
+
+func headerPermission(name, regex string) map[string]interface{} {
+	return map[string]interface{}{
+		"header": map[string]interface{}{
+			"name":        name,
+			"regex_match": regex,
+		},
+	}
+}
+
+func andRules(rules ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"and_rules": map[string]interface{}{"rules": rules},
+	}
+}
+
+func orRules(rules ...interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"or_rules": map[string]interface{}{"rules": rules},
+	}
+}
+
+func notRule(rule map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{"not_rule": rule}
+}
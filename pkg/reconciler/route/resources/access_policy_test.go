@@ -0,0 +1,330 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"fmt"
+	"net"
+	"regexp"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/google/kf/pkg/reconciler/route/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func accessPolicyRoute(name, hostname, path string, policy *v1alpha1.RouteSpecAccessPolicy) *v1alpha1.Route {
+	return &v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha1.RouteSpec{
+			AppNames:     []string{"some-app"},
+			AccessPolicy: policy,
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Hostname: hostname,
+				Domain:   "example.com",
+				Path:     path,
+			},
+		},
+	}
+}
+
+type rbacRequest struct {
+	authority string
+	path      string
+	sourceIP  string
+}
+
+// rbacAllows evaluates the EnvoyFilter's RBAC rules for the request the way
+// Envoy does.
+func rbacAllows(t *testing.T, filter *unstructured.Unstructured, req rbacRequest) bool {
+	t.Helper()
+
+	spec := filter.Object["spec"].(map[string]interface{})
+	config := spec["filters"].([]interface{})[0].(map[string]interface{})["filterConfig"].(map[string]interface{})
+	rules := config["rules"].(map[string]interface{})
+	testutil.AssertEqual(t, "action", "ALLOW", rules["action"])
+
+	for _, p := range rules["policies"].(map[string]interface{}) {
+		policy := p.(map[string]interface{})
+
+		permitted := false
+		for _, permission := range policy["permissions"].([]interface{}) {
+			permitted = permitted || rbacPermission(permission.(map[string]interface{}), req)
+		}
+
+		for _, principal := range policy["principals"].([]interface{}) {
+			if permitted && rbacPrincipal(principal.(map[string]interface{}), req) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func rbacPermission(permission map[string]interface{}, req rbacRequest) bool {
+	switch {
+	case permission["any"] != nil:
+		return true
+	case permission["header"] != nil:
+		header := permission["header"].(map[string]interface{})
+		value := map[string]string{":authority": req.authority, ":path": req.path}[header["name"].(string)]
+		return regexp.MustCompile("^(?:" + header["regex_match"].(string) + ")$").MatchString(value)
+	case permission["not_rule"] != nil:
+		return !rbacPermission(permission["not_rule"].(map[string]interface{}), req)
+	case permission["and_rules"] != nil:
+		for _, r := range permission["and_rules"].(map[string]interface{})["rules"].([]interface{}) {
+			if !rbacPermission(r.(map[string]interface{}), req) {
+				return false
+			}
+		}
+		return true
+	case permission["or_rules"] != nil:
+		for _, r := range permission["or_rules"].(map[string]interface{})["rules"].([]interface{}) {
+			if rbacPermission(r.(map[string]interface{}), req) {
+				return true
+			}
+		}
+		return false
+	default:
+		panic(fmt.Sprintf("unknown permission: %v", permission))
+	}
+}
+
+func rbacPrincipal(principal map[string]interface{}, req rbacRequest) bool {
+	switch {
+	case principal["any"] != nil:
+		return true
+	case principal["source_ip"] != nil:
+		cidr := principal["source_ip"].(map[string]interface{})
+		_, ipNet, err := net.ParseCIDR(fmt.Sprintf("%s/%d", cidr["address_prefix"], cidr["prefix_len"]))
+		if err != nil {
+			panic(err)
+		}
+		return ipNet.Contains(net.ParseIP(req.sourceIP))
+	case principal["not_id"] != nil:
+		return !rbacPrincipal(principal["not_id"].(map[string]interface{}), req)
+	case principal["and_ids"] != nil:
+		for _, id := range principal["and_ids"].(map[string]interface{})["ids"].([]interface{}) {
+			if !rbacPrincipal(id.(map[string]interface{}), req) {
+				return false
+			}
+		}
+		return true
+	case principal["or_ids"] != nil:
+		for _, id := range principal["or_ids"].(map[string]interface{})["ids"].([]interface{}) {
+			if rbacPrincipal(id.(map[string]interface{}), req) {
+				return true
+			}
+		}
+		return false
+	default:
+		panic(fmt.Sprintf("unknown principal: %v", principal))
+	}
+}
+
+func TestMakeAccessPolicy_sourceCIDRs(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		Policy    v1alpha1.RouteSpecAccessPolicy
+		Allowed   []string
+		Forbidden []string
+	}{
+		"allowed CIDRs": {
+			Policy: v1alpha1.RouteSpecAccessPolicy{
+				AllowedSourceCIDRs: []string{"10.0.0.0/8", "192.168.1.128/25"},
+			},
+			Allowed:   []string{"10.0.0.1", "10.255.255.255", "192.168.1.128", "192.168.1.255"},
+			Forbidden: []string{"11.0.0.1", "9.255.255.255", "192.168.1.127", "192.168.2.200"},
+		},
+		"denied CIDRs": {
+			Policy: v1alpha1.RouteSpecAccessPolicy{
+				DeniedSourceCIDRs: []string{"203.0.113.0/24", "198.51.100.7/32"},
+			},
+			Allowed:   []string{"203.0.112.255", "203.0.114.0", "198.51.100.6", "198.51.100.8", "8.8.8.8"},
+			Forbidden: []string{"203.0.113.0", "203.0.113.255", "198.51.100.7"},
+		},
+		"denied CIDRs inside allowed CIDRs": {
+			Policy: v1alpha1.RouteSpecAccessPolicy{
+				AllowedSourceCIDRs: []string{"10.0.0.0/8"},
+				DeniedSourceCIDRs:  []string{"10.1.2.0/23"},
+			},
+			Allowed:   []string{"10.1.1.255", "10.1.4.0", "10.200.0.1"},
+			Forbidden: []string{"10.1.2.0", "10.1.3.255", "11.1.2.1"},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			policy := tc.Policy
+			route := accessPolicyRoute("some-route", "some-host", "/some-path", &policy)
+
+			filter, err := resources.MakeAccessPolicy(route, []*v1alpha1.Route{route})
+			testutil.AssertNil(t, "err", err)
+
+			for _, ip := range tc.Allowed {
+				req := rbacRequest{authority: "some-host.example.com", path: "/some-path/x?y=z", sourceIP: ip}
+				testutil.AssertEqual(t, ip, true, rbacAllows(t, filter, req))
+			}
+
+			for _, ip := range tc.Forbidden {
+				req := rbacRequest{authority: "some-host.example.com", path: "/some-path/x?y=z", sourceIP: ip}
+				testutil.AssertEqual(t, ip, false, rbacAllows(t, filter, req))
+
+				// The same address can reach other hosts and paths.
+				req.path = "/some-other-path"
+				testutil.AssertEqual(t, ip+" other path", true, rbacAllows(t, filter, req))
+
+				req.authority = "other-host.example.com"
+				req.path = "/some-path"
+				testutil.AssertEqual(t, ip+" other host", true, rbacAllows(t, filter, req))
+			}
+		})
+	}
+}
+
+func TestMakeAccessPolicy_requests(t *testing.T) {
+	t.Parallel()
+
+	corporate := &v1alpha1.RouteSpecAccessPolicy{AllowedSourceCIDRs: []string{"10.0.0.0/8"}}
+
+	for tn, tc := range map[string]struct {
+		Route     *v1alpha1.Route
+		Others    []*v1alpha1.Route
+		Allowed   []rbacRequest
+		Forbidden []rbacRequest
+	}{
+		"hosts are case insensitive and may have a port": {
+			Route: accessPolicyRoute("admin", "admin", "", corporate),
+			Forbidden: []rbacRequest{
+				{authority: "admin.example.com", path: "/", sourceIP: "8.8.8.8"},
+				{authority: "ADMIN.Example.COM", path: "/", sourceIP: "8.8.8.8"},
+				{authority: "admin.example.com:80", path: "/x", sourceIP: "8.8.8.8"},
+			},
+			Allowed: []rbacRequest{
+				{authority: "admin.example.com", path: "/", sourceIP: "10.0.0.1"},
+				{authority: "admin.example.com.evil.com", path: "/", sourceIP: "8.8.8.8"},
+				{authority: "adminxexample.com", path: "/", sourceIP: "8.8.8.8"},
+			},
+		},
+		"more specific paths belong to their own route": {
+			Route: accessPolicyRoute("admin", "www", "", corporate),
+			Others: []*v1alpha1.Route{
+				accessPolicyRoute("public", "www", "/public", nil),
+			},
+			Forbidden: []rbacRequest{
+				{authority: "www.example.com", path: "/", sourceIP: "8.8.8.8"},
+				{authority: "www.example.com", path: "/publicity", sourceIP: "8.8.8.8"},
+			},
+			Allowed: []rbacRequest{
+				{authority: "www.example.com", path: "/public", sourceIP: "8.8.8.8"},
+				{authority: "www.example.com", path: "/public/index.html?q=1", sourceIP: "8.8.8.8"},
+			},
+		},
+		"restricted path on an open host": {
+			Route: accessPolicyRoute("admin", "www", "/admin", corporate),
+			Others: []*v1alpha1.Route{
+				accessPolicyRoute("root", "www", "", nil),
+			},
+			Forbidden: []rbacRequest{
+				{authority: "www.example.com", path: "/admin", sourceIP: "8.8.8.8"},
+				{authority: "www.example.com", path: "/admin/users?id=1", sourceIP: "8.8.8.8"},
+			},
+			Allowed: []rbacRequest{
+				{authority: "www.example.com", path: "/", sourceIP: "8.8.8.8"},
+				{authority: "www.example.com", path: "/administrator", sourceIP: "8.8.8.8"},
+				{authority: "www.example.com", path: "/admin", sourceIP: "10.1.2.3"},
+			},
+		},
+		"wildcard hosts skip hosts with their own routes": {
+			Route: accessPolicyRoute("wildcard", "*", "", corporate),
+			Others: []*v1alpha1.Route{
+				accessPolicyRoute("www", "www", "", nil),
+			},
+			Forbidden: []rbacRequest{
+				{authority: "anything.example.com", path: "/", sourceIP: "8.8.8.8"},
+			},
+			Allowed: []rbacRequest{
+				{authority: "www.example.com", path: "/", sourceIP: "8.8.8.8"},
+				{authority: "example.com", path: "/", sourceIP: "8.8.8.8"},
+			},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			filter, err := resources.MakeAccessPolicy(tc.Route, append(tc.Others, tc.Route))
+			testutil.AssertNil(t, "err", err)
+
+			for _, req := range tc.Allowed {
+				testutil.AssertEqual(t, fmt.Sprintf("%+v", req), true, rbacAllows(t, filter, req))
+			}
+
+			for _, req := range tc.Forbidden {
+				testutil.AssertEqual(t, fmt.Sprintf("%+v", req), false, rbacAllows(t, filter, req))
+			}
+		})
+	}
+}
+
+func ExampleMakeAccessPolicy() {
+	restricted := &v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: "admin", Namespace: "some-namespace"},
+		Spec: v1alpha1.RouteSpec{
+			AccessPolicy: &v1alpha1.RouteSpecAccessPolicy{
+				AllowedSourceCIDRs: []string{"10.0.0.0/8"},
+			},
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Hostname: "some-host",
+				Domain:   "example.com",
+				Path:     "/admin",
+			},
+		},
+	}
+	open := &v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{Name: "root", Namespace: "some-namespace"},
+		Spec: v1alpha1.RouteSpec{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Hostname: "some-host",
+				Domain:   "example.com",
+			},
+		},
+	}
+
+	filter, err := resources.MakeAccessPolicy(open, []*v1alpha1.Route{restricted, open})
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Name:", filter.GetName())
+	fmt.Println("Namespace:", filter.GetNamespace())
+	for _, ref := range filter.GetOwnerReferences() {
+		fmt.Println("Owner:", ref.Name)
+	}
+
+	unrestricted, err := resources.MakeAccessPolicy(open, []*v1alpha1.Route{open})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println("Without restricted routes:", unrestricted == nil)
+
+	// Output: Name: some-host-example-com-3mzbcw0hwnnep
+	// Namespace: some-namespace
+	// Owner: admin
+	// Owner: root
+	// Without restricted routes: true
+}
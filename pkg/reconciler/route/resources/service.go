@@ -0,0 +1,116 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// RouteNameLabel is the label on the Services of a route that holds the
+	// route's name.
+	RouteNameLabel = "kf.dev/route"
+
+	// InternalServicePort is the port the Services of internal routes
+	// listen on.
+	InternalServicePort = 80
+
+	// userPortName is the name Knative gives to the port of an App's
+	// container.
+	userPortName = "user-port"
+
+	// queueProxyPort is the port of the Knative queue-proxy in front of the
+	// App's container.
+	queueProxyPort = 8012
+)
+
+// ServiceName gets the name of the Service that forwards a route's traffic
+// to one of its Apps.
+func ServiceName(route *v1alpha1.Route, appName string) string {
+	return v1alpha1.GenerateName(route.Name, appName)
+}
+
+// connectsDirectly returns true if the route reaches the App through a Service
+// of its own rather than the Knative gateways. Knative only serves HTTP, so
+// TCP routes connect directly to the App's container. Internal routes connect
+// directly to Apps that only allow some sources so the gateways, which every
+// App can reach, don't need to be allowed.
+func connectsDirectly(route *v1alpha1.Route, allowedSources map[string][]string, appName string) bool {
+	switch {
+	case route.Spec.IsTCP():
+		return true
+	case route.Spec.Internal:
+		return len(allowedSources[appName]) > 0
+	default:
+		return false
+	}
+}
+
+// MakeServices creates a Service for each App the route connects to
+// directly. allowedSources maps the names of bound Apps to the Apps that are
+// allowed to reach them.
+func MakeServices(route *v1alpha1.Route, allowedSources map[string][]string) []*corev1.Service {
+	port := corev1.ServicePort{
+		Name:       "http",
+		Protocol:   corev1.ProtocolTCP,
+		Port:       InternalServicePort,
+		TargetPort: intstr.FromInt(queueProxyPort),
+	}
+	if route.Spec.IsTCP() {
+		port = corev1.ServicePort{
+			Name:       "tcp",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       route.Spec.Port,
+			TargetPort: intstr.FromString(userPortName),
+		}
+	}
+
+	var services []*corev1.Service
+	for _, appName := range route.Spec.AppNames {
+		if !connectsDirectly(route, allowedSources, appName) {
+			continue
+		}
+
+		services = append(services, &corev1.Service{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      ServiceName(route, appName),
+				Namespace: route.GetNamespace(),
+				OwnerReferences: []metav1.OwnerReference{
+					*kmeta.NewControllerRef(route),
+				},
+				Labels: resources.UnionMaps(route.GetLabels(), map[string]string{
+					ManagedByLabel: "kf",
+					RouteNameLabel: route.Name,
+				}),
+			},
+			Spec: corev1.ServiceSpec{
+				Type: corev1.ServiceTypeClusterIP,
+				Selector: map[string]string{
+					v1alpha1.NameLabel:      appName,
+					v1alpha1.ManagedByLabel: "kf",
+					v1alpha1.ComponentLabel: "app-server",
+				},
+				Ports: []corev1.ServicePort{port},
+			},
+		})
+	}
+
+	return services
+}
@@ -0,0 +1,97 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"knative.dev/pkg/kmeta"
+)
+
+// ServiceEntryResource is Istio's ServiceEntry resource. There's no typed
+// client for it, so ServiceEntries are managed with the dynamic client.
+var ServiceEntryResource = schema.GroupVersionResource{
+	Group:    "networking.istio.io",
+	Version:  "v1alpha3",
+	Resource: "serviceentries",
+}
+
+// ServiceEntryAddress returns the virtual IP an internal host resolves to.
+// The address is taken from the reserved 240.240.0.0/16 block so it can't
+// clash with a real endpoint, sidecars match HTTP requests to the route by
+// their Host header rather than the address.
+func ServiceEntryAddress(host string) string {
+	h := fnv.New32a()
+	h.Write([]byte(host))
+	sum := h.Sum32()
+
+	return fmt.Sprintf("240.240.%d.%d", (sum>>8)&0xff, sum&0xff)
+}
+
+// MakeServiceEntry creates a ServiceEntry that adds an internal route's host
+// to the mesh. Istio's CoreDNS plugin answers DNS queries for the host with
+// the ServiceEntry's address and sidecars send the requests to the route's
+// VirtualService. Other routes don't need one.
+func MakeServiceEntry(route *v1alpha1.Route) *unstructured.Unstructured {
+	if !route.Spec.Internal {
+		return nil
+	}
+
+	host := routeHost(route)
+
+	// Each route will own the ServiceEntry like the VirtualService.
+	// Therefore none of them can be a controller.
+	ownerRef := *kmeta.NewControllerRef(route)
+	ownerRef.Controller = nil
+	ownerRef.BlockOwnerDeletion = nil
+
+	serviceEntry := &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "networking.istio.io/v1alpha3",
+			"kind":       "ServiceEntry",
+			"spec": map[string]interface{}{
+				"hosts":      []interface{}{host},
+				"addresses":  []interface{}{ServiceEntryAddress(host)},
+				"location":   "MESH_INTERNAL",
+				"resolution": "NONE",
+				"ports": []interface{}{
+					map[string]interface{}{
+						"number":   int64(InternalServicePort),
+						"name":     "http",
+						"protocol": "HTTP",
+					},
+				},
+			},
+		},
+	}
+
+	serviceEntry.SetName(v1alpha1.GenerateName(route.Spec.Hostname, route.Spec.Domain))
+	serviceEntry.SetNamespace(v1alpha1.KfNamespace)
+	serviceEntry.SetOwnerReferences([]metav1.OwnerReference{ownerRef})
+	serviceEntry.SetLabels(resources.UnionMaps(route.GetLabels(), map[string]string{
+		ManagedByLabel: "kf",
+	}))
+	serviceEntry.SetAnnotations(map[string]string{
+		"space": route.GetNamespace(),
+	})
+
+	return serviceEntry
+}
@@ -0,0 +1,83 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"net"
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/google/kf/pkg/reconciler/route/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestMakeServiceEntry(t *testing.T) {
+	t.Parallel()
+
+	internalRoute := &v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-route",
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha1.RouteSpec{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Hostname: "some-host",
+				Domain:   "apps.internal",
+				Internal: true,
+			},
+		},
+	}
+
+	t.Run("public routes don't need one", func(t *testing.T) {
+		route := internalRoute.DeepCopy()
+		route.Spec.Domain = "example.com"
+		route.Spec.Internal = false
+
+		testutil.AssertEqual(t, "ServiceEntry", (*unstructured.Unstructured)(nil), resources.MakeServiceEntry(route))
+	})
+
+	t.Run("internal routes", func(t *testing.T) {
+		se := resources.MakeServiceEntry(internalRoute)
+
+		testutil.AssertEqual(t, "name", v1alpha1.GenerateName("some-host", "apps.internal"), se.GetName())
+		testutil.AssertEqual(t, "namespace", v1alpha1.KfNamespace, se.GetNamespace())
+		testutil.AssertEqual(t, "space", "some-namespace", se.GetAnnotations()["space"])
+		testutil.AssertEqual(t, "owner", "some-route", se.GetOwnerReferences()[0].Name)
+		testutil.AssertEqual(t, "controller", (*bool)(nil), se.GetOwnerReferences()[0].Controller)
+
+		hosts, _, _ := unstructured.NestedStringSlice(se.Object, "spec", "hosts")
+		testutil.AssertEqual(t, "hosts", []string{"some-host.apps.internal"}, hosts)
+
+		addresses, _, _ := unstructured.NestedStringSlice(se.Object, "spec", "addresses")
+		testutil.AssertEqual(t, "addresses", []string{resources.ServiceEntryAddress("some-host.apps.internal")}, addresses)
+
+		location, _, _ := unstructured.NestedString(se.Object, "spec", "location")
+		testutil.AssertEqual(t, "location", "MESH_INTERNAL", location)
+	})
+}
+
+func TestServiceEntryAddress(t *testing.T) {
+	t.Parallel()
+
+	_, reserved, _ := net.ParseCIDR("240.240.0.0/16")
+
+	for _, host := range []string{"a.apps.internal", "b.apps.internal", "apps.internal"} {
+		addr := resources.ServiceEntryAddress(host)
+		testutil.AssertEqual(t, host+" in range", true, reserved.Contains(net.ParseIP(addr)))
+		testutil.AssertEqual(t, host+" stable", addr, resources.ServiceEntryAddress(host))
+	}
+}
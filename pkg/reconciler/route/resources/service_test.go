@@ -0,0 +1,107 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources_test
+
+import (
+	"testing"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	"github.com/google/kf/pkg/reconciler/route/resources"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+func TestMakeServices(t *testing.T) {
+	t.Parallel()
+
+	tcpRoute := &v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "some-route",
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha1.RouteSpec{
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Domain: "tcp.example.com",
+				Port:   1024,
+			},
+			AppNames: []string{"app-1", "app-2"},
+		},
+	}
+
+	t.Run("http routes don't need services", func(t *testing.T) {
+		route := tcpRoute.DeepCopy()
+		route.Spec.Port = 0
+
+		testutil.AssertEqual(t, "services", 0, len(resources.MakeServices(route, nil)))
+	})
+
+	t.Run("public routes ignore allow lists", func(t *testing.T) {
+		route := tcpRoute.DeepCopy()
+		route.Spec.Port = 0
+		allowedSources := map[string][]string{"app-1": {"frontend"}}
+
+		testutil.AssertEqual(t, "services", 0, len(resources.MakeServices(route, allowedSources)))
+	})
+
+	t.Run("internal routes connect to apps with allow lists", func(t *testing.T) {
+		route := tcpRoute.DeepCopy()
+		route.Spec.Port = 0
+		route.Spec.Domain = "apps.internal"
+		route.Spec.Internal = true
+		allowedSources := map[string][]string{"app-2": {"frontend"}}
+
+		services := resources.MakeServices(route, allowedSources)
+
+		testutil.AssertEqual(t, "len", 1, len(services))
+		testutil.AssertEqual(t, "name", resources.ServiceName(route, "app-2"), services[0].Name)
+		testutil.AssertEqual(t, "selector", map[string]string{
+			v1alpha1.NameLabel:      "app-2",
+			v1alpha1.ManagedByLabel: "kf",
+			v1alpha1.ComponentLabel: "app-server",
+		}, services[0].Spec.Selector)
+		testutil.AssertEqual(t, "ports", []corev1.ServicePort{{
+			Name:       "http",
+			Protocol:   corev1.ProtocolTCP,
+			Port:       resources.InternalServicePort,
+			TargetPort: intstr.FromInt(8012),
+		}}, services[0].Spec.Ports)
+	})
+
+	t.Run("one service per app", func(t *testing.T) {
+		services := resources.MakeServices(tcpRoute, nil)
+
+		testutil.AssertEqual(t, "len", 2, len(services))
+		for i, appName := range tcpRoute.Spec.AppNames {
+			svc := services[i]
+			testutil.AssertEqual(t, "name", resources.ServiceName(tcpRoute, appName), svc.Name)
+			testutil.AssertEqual(t, "namespace", "some-namespace", svc.Namespace)
+			testutil.AssertEqual(t, "route label", "some-route", svc.Labels[resources.RouteNameLabel])
+			testutil.AssertEqual(t, "owner", "some-route", svc.OwnerReferences[0].Name)
+			testutil.AssertEqual(t, "selector", map[string]string{
+				v1alpha1.NameLabel:      appName,
+				v1alpha1.ManagedByLabel: "kf",
+				v1alpha1.ComponentLabel: "app-server",
+			}, svc.Spec.Selector)
+			testutil.AssertEqual(t, "ports", []corev1.ServicePort{{
+				Name:       "tcp",
+				Protocol:   corev1.ProtocolTCP,
+				Port:       1024,
+				TargetPort: intstr.FromString("user-port"),
+			}}, svc.Spec.Ports)
+		}
+	})
+}
@@ -18,6 +18,8 @@ import (
 	"fmt"
 	"net/http"
 	"path"
+	"sort"
+	"strconv"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/gorilla/mux"
@@ -33,18 +35,33 @@ const (
 	ManagedByLabel        = "app.kubernetes.io/managed-by"
 	KnativeIngressGateway = "knative-ingress-gateway.knative-serving.svc.cluster.local"
 	GatewayHost           = "istio-ingressgateway.istio-system.svc.cluster.local"
+
+	// MeshGateway is the reserved Istio gateway for traffic between sidecars
+	// in the mesh. Internal routes are attached to it.
+	MeshGateway = "mesh"
+	// ClusterLocalGatewayHost serves Knative Services inside the cluster.
+	ClusterLocalGatewayHost = "cluster-local-gateway.istio-system.svc.cluster.local"
 )
 
 // MakeVirtualService creates a VirtualService from a Route object.
-func MakeVirtualService(route *v1alpha1.Route) (*networking.VirtualService, error) {
-	hostDomain := route.Spec.Domain
-	if route.Spec.Hostname != "" {
-		hostDomain = route.Spec.Hostname + "." + route.Spec.Domain
-	}
+// allowedSources maps the names of bound Apps to the Apps that are allowed to
+// reach them, it's only enforced for internal routes. The route's space
+// decides whether it's served over HTTPS.
+func MakeVirtualService(route *v1alpha1.Route, allowedSources map[string][]string, space *v1alpha1.Space) (*networking.VirtualService, error) {
+	var httpRoute []networking.HTTPRoute
+	var tcpRoute []networking.TCPRoute
+	if route.Spec.IsTCP() {
+		tcpRoute = buildTCPRoute(route)
+	} else {
+		var err error
+		httpRoute, err = buildHTTPRoute(route, allowedSources)
+		if err != nil {
+			return nil, err
+		}
 
-	httpRoute, err := buildHTTPRoute(route)
-	if err != nil {
-		return nil, err
+		for i := range httpRoute {
+			applyPolicies(route, &httpRoute[i])
+		}
 	}
 
 	// Each route will own the VirtualService. Therefore none of them can be a
@@ -72,18 +89,64 @@ func MakeVirtualService(route *v1alpha1.Route) (*networking.VirtualService, erro
 				"domain":   route.Spec.Domain,
 				"hostname": route.Spec.Hostname,
 				"space":    route.GetNamespace(),
+				"internal": strconv.FormatBool(route.Spec.Internal),
 			},
 		},
 		Spec: networking.VirtualServiceSpec{
-			Gateways: []string{KnativeIngressGateway},
-			Hosts:    []string{hostDomain},
+			Gateways: buildGateways(route, space),
+			Hosts:    []string{routeHost(route)},
 			HTTP:     httpRoute,
+			TCP:      tcpRoute,
 		},
 	}, nil
 }
 
-func buildHTTPRoute(route *v1alpha1.Route) ([]networking.HTTPRoute, error) {
-	var pathMatchers []networking.HTTPMatchRequest
+// routeHost returns the host the route serves.
+func routeHost(route *v1alpha1.Route) string {
+	if route.Spec.Hostname == "" {
+		return route.Spec.Domain
+	}
+	return route.Spec.Hostname + "." + route.Spec.Domain
+}
+
+// ServesHTTPS returns true if the route's domain has a certificate in the
+// space that the space's Gateway serves.
+func ServesHTTPS(route *v1alpha1.Route, space *v1alpha1.Space) bool {
+	if space == nil || route.Spec.Internal || route.Spec.IsTCP() {
+		return false
+	}
+
+	domain := space.Spec.Execution.FindDomain(route.Spec.Domain)
+	return domain != nil && domain.HasCertificate() && space.Status.HasCertificate(domain.Domain)
+}
+
+// buildGateways returns the gateways that serve the route. Routes on domains
+// with a certificate are also served by the space's Gateway and skip the
+// Knative gateway if the domain redirects HTTP to HTTPS. TCP routes are only
+// served by the space's Gateway, which listens on the space's TCP ports.
+func buildGateways(route *v1alpha1.Route, space *v1alpha1.Space) []string {
+	if route.Spec.Internal {
+		return []string{MeshGateway}
+	}
+
+	if route.Spec.IsTCP() {
+		return []string{v1alpha1.SpaceGatewayName(route.GetNamespace())}
+	}
+
+	if !ServesHTTPS(route, space) {
+		return []string{KnativeIngressGateway}
+	}
+
+	spaceGateway := v1alpha1.SpaceGatewayName(space.Name)
+	if space.Spec.Execution.FindDomain(route.Spec.Domain).RedirectHTTP {
+		return []string{spaceGateway}
+	}
+
+	return []string{KnativeIngressGateway, spaceGateway}
+}
+
+func buildHTTPRoute(route *v1alpha1.Route, allowedSources map[string][]string) ([]networking.HTTPRoute, error) {
+	var uriMatch *istio.StringMatch
 
 	urlPath := path.Join("/", route.Spec.Path, "/")
 	regexpPath, err := buildPathRegex(urlPath)
@@ -91,44 +154,290 @@ func buildHTTPRoute(route *v1alpha1.Route) ([]networking.HTTPRoute, error) {
 		return nil, fmt.Errorf("failed to convert path to regexp: %s", err)
 	}
 
-	if route.Spec.Path != "" {
-		pathMatchers = append(pathMatchers, networking.HTTPMatchRequest{
-			URI: &istio.StringMatch{
-				Regex: regexpPath,
-			},
-		})
+	switch {
+	case route.Spec.IsExactPath():
+		uriMatch = &istio.StringMatch{
+			Exact: urlPath,
+		}
+	case route.Spec.Path != "":
+		uriMatch = &istio.StringMatch{
+			Regex: regexpPath,
+		}
 	}
 
+	// If there aren't any services bound to the route, we just want to
+	// serve a 503.
+	if len(route.Spec.AppNames) == 0 {
+		return []networking.HTTPRoute{
+			buildFaultRoute(route, buildMatch(uriMatch, nil), http.StatusServiceUnavailable),
+		}, nil
+	}
+
+	// Sidecars can only tell which App sent a request on internal routes, so
+	// public routes accept traffic from everywhere.
+	openApps := route.Spec.AppNames
+	sourceApps := make(map[string][]string)
+	if route.Spec.Internal {
+		openApps = nil
+		for _, appName := range route.Spec.AppNames {
+			if len(allowedSources[appName]) == 0 {
+				openApps = append(openApps, appName)
+				continue
+			}
+
+			for _, source := range allowedSources[appName] {
+				sourceApps[source] = append(sourceApps[source], appName)
+			}
+		}
+	}
+
+	// Each allowed source gets a route to the Apps that allow it along with
+	// the Apps open to everyone.
+	var sources []string
+	for source := range sourceApps {
+		sources = append(sources, source)
+	}
+	sort.Strings(sources)
+
 	var httpRoutes []networking.HTTPRoute
+	for _, source := range sources {
+		sourceLabels := map[string]string{
+			v1alpha1.NameLabel:      source,
+			v1alpha1.ManagedByLabel: "kf",
+		}
+
+		httpRoutes = append(httpRoutes, buildAppsRoute(
+			route,
+			buildMatch(uriMatch, sourceLabels),
+			append(append([]string{}, openApps...), sourceApps[source]...),
+			allowedSources,
+		))
+	}
+
+	// Other sources can only reach the open Apps.
+	if len(openApps) == 0 {
+		httpRoutes = append(httpRoutes, buildFaultRoute(route, buildMatch(uriMatch, nil), http.StatusForbidden))
+	} else {
+		httpRoutes = append(httpRoutes, buildAppsRoute(route, buildMatch(uriMatch, nil), openApps, allowedSources))
+	}
+
+	return httpRoutes, nil
+}
+
+// applyPolicies sets the route's timeout, retry policy and header changes on
+// an HTTPRoute.
+func applyPolicies(route *v1alpha1.Route, httpRoute *networking.HTTPRoute) {
+	if route.Spec.Timeout != nil {
+		httpRoute.Timeout = route.Spec.Timeout.Duration.String()
+	}
+
+	if retries := route.Spec.Retries; retries != nil {
+		httpRoute.Retries = &networking.HTTPRetry{
+			Attempts: retries.Attempts,
+		}
 
-	for _, ksvcName := range route.Spec.AppNames {
-		httpRoutes = append(httpRoutes, networking.HTTPRoute{
-			Match: pathMatchers,
-			Route: buildRouteDestination(),
+		if retries.PerTryTimeout != nil {
+			httpRoute.Retries.PerTryTimeout = retries.PerTryTimeout.Duration.String()
+		}
+	}
+
+	if headers := route.Spec.Headers; headers != nil {
+		httpRoute.Headers = &networking.Headers{
+			Request:  buildHeaderOperations(headers.Request),
+			Response: buildHeaderOperations(headers.Response),
+		}
+	}
+}
+
+func buildHeaderOperations(ops *v1alpha1.RouteSpecHeaderOperations) *networking.HeaderOperations {
+	if ops == nil {
+		return nil
+	}
+
+	return &networking.HeaderOperations{
+		Set:    ops.Set,
+		Add:    ops.Add,
+		Remove: ops.Remove,
+	}
+}
+
+func buildMatch(uriMatch *istio.StringMatch, sourceLabels map[string]string) []networking.HTTPMatchRequest {
+	if uriMatch == nil && sourceLabels == nil {
+		return nil
+	}
+
+	return []networking.HTTPMatchRequest{
+		{
+			URI:          uriMatch,
+			SourceLabels: sourceLabels,
+		},
+	}
+}
+
+func buildFaultRoute(route *v1alpha1.Route, match []networking.HTTPMatchRequest, status int) networking.HTTPRoute {
+	return networking.HTTPRoute{
+		Match: match,
+		Route: buildRouteDestination(route),
+		Fault: &networking.HTTPFaultInjection{
+			Abort: &networking.InjectAbort{
+				Percent:    100,
+				HTTPStatus: status,
+			},
+		},
+	}
+}
+
+// buildAppsRoute creates a route that splits traffic between the given Apps.
+func buildAppsRoute(route *v1alpha1.Route, match []networking.HTTPMatchRequest, appNames []string, allowedSources map[string][]string) networking.HTTPRoute {
+	splits := splitTraffic(route.Spec, appNames)
+
+	// If none of the Apps should receive traffic, we just want to serve a
+	// 503.
+	if len(splits) == 0 {
+		return buildFaultRoute(route, match, http.StatusServiceUnavailable)
+	}
+
+	if len(splits) == 1 {
+		return networking.HTTPRoute{
+			Match: match,
+			Route: []networking.HTTPRouteDestination{{
+				Destination: buildAppDestination(route, allowedSources, splits[0].appName),
+				Weight:      100,
+			}},
 			Rewrite: &networking.HTTPRewrite{
-				Authority: network.GetServiceHostname(ksvcName, route.GetNamespace()),
+				Authority: network.GetServiceHostname(splits[0].appName, route.GetNamespace()),
 			},
-		})
+		}
 	}
 
-	// If there aren't any services bound to the route, we just want to
-	// serve a 503.
-	if len(httpRoutes) == 0 {
-		return []networking.HTTPRoute{
-			{
-				Match: pathMatchers,
-				Route: buildRouteDestination(),
-				Fault: &networking.HTTPFaultInjection{
-					Abort: &networking.InjectAbort{
-						Percent:    100,
-						HTTPStatus: http.StatusServiceUnavailable,
+	// Istio only allows one rewrite per route, so when several Apps share
+	// the route each weighted destination sets the Host instead.
+	var destinations []networking.HTTPRouteDestination
+	for _, split := range splits {
+		destinations = append(destinations, networking.HTTPRouteDestination{
+			Destination: buildAppDestination(route, allowedSources, split.appName),
+			Weight:      split.percent,
+			Headers: &networking.Headers{
+				Request: &networking.HeaderOperations{
+					Set: map[string]string{
+						"Host": network.GetServiceHostname(split.appName, route.GetNamespace()),
 					},
 				},
 			},
-		}, nil
+		})
 	}
 
-	return httpRoutes, nil
+	return networking.HTTPRoute{
+		Match: match,
+		Route: destinations,
+	}
+}
+
+// buildTCPRoute creates a route that splits the connections on the TCP
+// route's port between its Apps.
+func buildTCPRoute(route *v1alpha1.Route) []networking.TCPRoute {
+	splits := splitTraffic(route.Spec, route.Spec.AppNames)
+
+	// TCP connections can't be answered with an error like HTTP requests, so
+	// the port is closed if none of the Apps should receive traffic.
+	if len(splits) == 0 {
+		return nil
+	}
+
+	var destinations []networking.RouteDestination
+	for _, split := range splits {
+		destinations = append(destinations, networking.RouteDestination{
+			Destination: networking.Destination{
+				Host: network.GetServiceHostname(ServiceName(route, split.appName), route.GetNamespace()),
+				Port: networking.PortSelector{
+					Number: uint32(route.Spec.Port),
+				},
+			},
+			Weight: split.percent,
+		})
+	}
+
+	return []networking.TCPRoute{
+		{
+			Match: []networking.L4MatchAttributes{
+				{Port: int(route.Spec.Port)},
+			},
+			Route: destinations,
+		},
+	}
+}
+
+type trafficSplit struct {
+	appName string
+	percent int
+}
+
+// splitTraffic converts the weights of the given Apps into percentages that
+// sum to 100. Apps that shouldn't receive any traffic are left out.
+func splitTraffic(spec v1alpha1.RouteSpec, appNames []string) []trafficSplit {
+	var splits []trafficSplit
+	var remainders []int
+	total := 0
+	for _, appName := range appNames {
+		if weight := spec.WeightFor(appName); weight > 0 {
+			splits = append(splits, trafficSplit{appName: appName, percent: weight})
+			total += weight
+		}
+	}
+
+	if total == 0 {
+		return nil
+	}
+
+	remaining := 100
+	for i := range splits {
+		remainders = append(remainders, splits[i].percent*100%total)
+		splits[i].percent = splits[i].percent * 100 / total
+		remaining -= splits[i].percent
+	}
+
+	// Hand out the percent lost to rounding to the Apps with the largest
+	// remainders, ties go to the App listed first.
+	order := make([]int, len(splits))
+	for i := range order {
+		order[i] = i
+	}
+	sort.SliceStable(order, func(i, j int) bool {
+		return remainders[order[i]] > remainders[order[j]]
+	})
+	for i := 0; i < remaining; i++ {
+		splits[order[i]].percent++
+	}
+
+	return splits
+}
+
+// gatewayHost returns the gateway that serves the Knative Services for the
+// route. Internal routes use the cluster-local gateway so the Apps don't need
+// to be public.
+func gatewayHost(route *v1alpha1.Route) string {
+	if route.Spec.Internal {
+		return ClusterLocalGatewayHost
+	}
+	return GatewayHost
+}
+
+// buildAppDestination returns where the route sends the App's traffic.
+// Apps the route connects to directly are reached through their own Service,
+// the rest through the gateway serving their Knative Service.
+func buildAppDestination(route *v1alpha1.Route, allowedSources map[string][]string, appName string) networking.Destination {
+	if !connectsDirectly(route, allowedSources, appName) {
+		return networking.Destination{
+			Host: gatewayHost(route),
+		}
+	}
+
+	return networking.Destination{
+		Host: network.GetServiceHostname(ServiceName(route, appName), route.GetNamespace()),
+		Port: networking.PortSelector{
+			Number: InternalServicePort,
+		},
+	}
 }
 
 func buildPathRegex(path string) (string, error) {
@@ -139,11 +448,11 @@ func buildPathRegex(path string) (string, error) {
 	return p + `(/.*)?`, nil
 }
 
-func buildRouteDestination() []networking.HTTPRouteDestination {
+func buildRouteDestination(route *v1alpha1.Route) []networking.HTTPRouteDestination {
 	return []networking.HTTPRouteDestination{
 		{
 			Destination: networking.Destination{
-				Host: GatewayHost,
+				Host: gatewayHost(route),
 			},
 			Weight: 100,
 		},
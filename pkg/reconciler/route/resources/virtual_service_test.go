@@ -19,6 +19,7 @@ import (
 	"net/http"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	"github.com/google/kf/pkg/kf/algorithms"
@@ -35,8 +36,10 @@ func TestMakeVirtualService(t *testing.T) {
 	t.Parallel()
 
 	for tn, tc := range map[string]struct {
-		Route  *v1alpha1.Route
-		Assert func(t *testing.T, v *networking.VirtualService, err error)
+		Route          *v1alpha1.Route
+		AllowedSources map[string][]string
+		Space          *v1alpha1.Space
+		Assert         func(t *testing.T, v *networking.VirtualService, err error)
 	}{
 		"proper Meta": {
 			Route: &v1alpha1.Route{
@@ -81,6 +84,7 @@ func TestMakeVirtualService(t *testing.T) {
 						"domain":   "example.com",
 						"hostname": "some-host",
 						"space":    "some-namespace",
+						"internal": "false",
 					},
 					OwnerReferences: []metav1.OwnerReference{
 						ownerRef,
@@ -109,6 +113,47 @@ func TestMakeVirtualService(t *testing.T) {
 				}, v.Spec.HTTP[0].Match[0])
 			},
 		},
+		"exact Path Matchers": {
+			Route: &v1alpha1.Route{
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname:  "some-host",
+						Domain:    "example.com",
+						Path:      "/some-path/",
+						PathMatch: v1alpha1.PathMatchExact,
+					},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "HTTP Match", networking.HTTPMatchRequest{
+					URI: &istio.StringMatch{
+						Exact: "/some-path",
+					},
+				}, v.Spec.HTTP[0].Match[0])
+			},
+		},
+		"wildcard hostname": {
+			Route: &v1alpha1.Route{
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: v1alpha1.WildcardHostname,
+						Domain:   "example.com",
+						Path:     "/",
+					},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Hosts", []string{"*.example.com"}, v.Spec.Hosts)
+				testutil.AssertEqual(t, "Name", v1alpha1.GenerateName("*", "example.com"), v.Name)
+
+				if v.Name == v1alpha1.GenerateName("", "example.com") {
+					t.Fatal("wildcard VirtualService collides with the domain's VirtualService")
+				}
+			},
+		},
 		"Route": {
 			Route: &v1alpha1.Route{
 				Spec: v1alpha1.RouteSpec{
@@ -176,6 +221,373 @@ func TestMakeVirtualService(t *testing.T) {
 				testutil.AssertEqual(t, "HTTP Match", "^/some-path(/.*)?", v.Spec.HTTP[0].Match[0].URI.Regex)
 			},
 		},
+		"timeout, retries and headers": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+					},
+					AppNames: []string{"ksvc-1"},
+					Timeout:  &metav1.Duration{Duration: 90 * time.Second},
+					Retries: &v1alpha1.RouteSpecRetries{
+						Attempts:      3,
+						PerTryTimeout: &metav1.Duration{Duration: 500 * time.Millisecond},
+					},
+					Headers: &v1alpha1.RouteSpecHeaders{
+						Request: &v1alpha1.RouteSpecHeaderOperations{
+							Remove: []string{"X-Forwarded-Host"},
+						},
+						Response: &v1alpha1.RouteSpecHeaderOperations{
+							Set: map[string]string{"X-Frame-Options": "DENY"},
+						},
+					},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "Timeout", "1m30s", v.Spec.HTTP[0].Timeout)
+				testutil.AssertEqual(t, "Retries", &networking.HTTPRetry{
+					Attempts:      3,
+					PerTryTimeout: "500ms",
+				}, v.Spec.HTTP[0].Retries)
+				testutil.AssertEqual(t, "Headers", &networking.Headers{
+					Request: &networking.HeaderOperations{
+						Remove: []string{"X-Forwarded-Host"},
+					},
+					Response: &networking.HeaderOperations{
+						Set: map[string]string{"X-Frame-Options": "DENY"},
+					},
+				}, v.Spec.HTTP[0].Headers)
+			},
+		},
+		"routes without policies use the Istio defaults": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Domain: "example.com",
+					},
+					AppNames: []string{"ksvc-1"},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Timeout", "", v.Spec.HTTP[0].Timeout)
+				testutil.AssertEqual(t, "Retries", (*networking.HTTPRetry)(nil), v.Spec.HTTP[0].Retries)
+				testutil.AssertEqual(t, "Headers", (*networking.Headers)(nil), v.Spec.HTTP[0].Headers)
+			},
+		},
+		"setup weighted routes to multiple bound services": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+						Path:     "/some-path",
+					},
+					AppNames:   []string{"ksvc-1", "ksvc-2"},
+					AppWeights: map[string]int{"ksvc-1": 3, "ksvc-2": 1},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "HTTP Rewrite", (*networking.HTTPRewrite)(nil), v.Spec.HTTP[0].Rewrite)
+				testutil.AssertEqual(t, "HTTP Route", []networking.HTTPRouteDestination{
+					{
+						Destination: networking.Destination{Host: resources.GatewayHost},
+						Weight:      75,
+						Headers: &networking.Headers{
+							Request: &networking.HeaderOperations{
+								Set: map[string]string{"Host": network.GetServiceHostname("ksvc-1", "some-namespace")},
+							},
+						},
+					},
+					{
+						Destination: networking.Destination{Host: resources.GatewayHost},
+						Weight:      25,
+						Headers: &networking.Headers{
+							Request: &networking.HeaderOperations{
+								Set: map[string]string{"Host": network.GetServiceHostname("ksvc-2", "some-namespace")},
+							},
+						},
+					},
+				}, v.Spec.HTTP[0].Route)
+			},
+		},
+		"apps with zero weight don't get traffic": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Domain: "example.com",
+					},
+					AppNames:   []string{"ksvc-1", "ksvc-2"},
+					AppWeights: map[string]int{"ksvc-1": 0},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "HTTP Rewrite", &networking.HTTPRewrite{
+					Authority: network.GetServiceHostname("ksvc-2", "some-namespace"),
+				}, v.Spec.HTTP[0].Rewrite)
+			},
+		},
+		"all apps with zero weight setup fault to 503": {
+			Route: &v1alpha1.Route{
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Domain: "example.com",
+					},
+					AppNames:   []string{"ksvc-1"},
+					AppWeights: map[string]int{"ksvc-1": 0},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP Fault", &networking.HTTPFaultInjection{
+					Abort: &networking.InjectAbort{
+						Percent:    100,
+						HTTPStatus: http.StatusServiceUnavailable,
+					},
+				}, v.Spec.HTTP[0].Fault)
+			},
+		},
+		"domains with certificates use the space gateway": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "some-space"},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+					},
+				},
+			},
+			Space: spaceWithDomain(v1alpha1.SpaceDomain{Domain: "example.com", CertificateSecret: "some-cert"}),
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{
+					resources.KnativeIngressGateway,
+					v1alpha1.SpaceGatewayName("some-space"),
+				}, v.Spec.Gateways)
+			},
+		},
+		"domains that redirect to https skip the knative gateway": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "some-space"},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+					},
+				},
+			},
+			Space: spaceWithDomain(v1alpha1.SpaceDomain{Domain: "example.com", CertificateSecret: "some-cert", RedirectHTTP: true}),
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{v1alpha1.SpaceGatewayName("some-space")}, v.Spec.Gateways)
+			},
+		},
+		"certificates the space doesn't serve use the knative gateway": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "some-space"},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+					},
+				},
+			},
+			Space: func() *v1alpha1.Space {
+				space := spaceWithDomain(v1alpha1.SpaceDomain{Domain: "example.com", CertificateSecret: "some-cert", RedirectHTTP: true})
+				space.Status.Certificates = nil
+				return space
+			}(),
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{resources.KnativeIngressGateway}, v.Spec.Gateways)
+			},
+		},
+		"domains without certificates use the knative gateway": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "some-space"},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "example.com",
+					},
+				},
+			},
+			Space: spaceWithDomain(v1alpha1.SpaceDomain{Domain: "other.example.com", CertificateSecret: "some-cert"}),
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{resources.KnativeIngressGateway}, v.Spec.Gateways)
+			},
+		},
+		"internal routes use the mesh": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "apps.internal",
+						Path:     "/",
+						Internal: true,
+					},
+					AppNames: []string{"ksvc-1"},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{resources.MeshGateway}, v.Spec.Gateways)
+				testutil.AssertEqual(t, "Hosts", []string{"some-host.apps.internal"}, v.Spec.Hosts)
+				testutil.AssertEqual(t, "internal annotation", "true", v.Annotations["internal"])
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "HTTP Route", []networking.HTTPRouteDestination{{
+					Destination: networking.Destination{Host: resources.ClusterLocalGatewayHost},
+					Weight:      100,
+				}}, v.Spec.HTTP[0].Route)
+			},
+		},
+		"internal routes only allow sources from network policy": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "some-route",
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Hostname: "some-host",
+						Domain:   "apps.internal",
+						Path:     "/some-path",
+						Internal: true,
+					},
+					AppNames: []string{"backend"},
+				},
+			},
+			AllowedSources: map[string][]string{
+				"backend": {"frontend"},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 2, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "allowed match", []networking.HTTPMatchRequest{{
+					URI: &istio.StringMatch{Regex: "^/some-path(/.*)?"},
+					SourceLabels: map[string]string{
+						v1alpha1.NameLabel:      "frontend",
+						v1alpha1.ManagedByLabel: "kf",
+					},
+				}}, v.Spec.HTTP[0].Match)
+				testutil.AssertEqual(t, "allowed rewrite", &networking.HTTPRewrite{
+					Authority: network.GetServiceHostname("backend", "some-namespace"),
+				}, v.Spec.HTTP[0].Rewrite)
+				testutil.AssertEqual(t, "allowed route skips the gateway", []networking.HTTPRouteDestination{{
+					Destination: networking.Destination{
+						Host: network.GetServiceHostname(v1alpha1.GenerateName("some-route", "backend"), "some-namespace"),
+						Port: networking.PortSelector{Number: resources.InternalServicePort},
+					},
+					Weight: 100,
+				}}, v.Spec.HTTP[0].Route)
+				testutil.AssertEqual(t, "denied match", []networking.HTTPMatchRequest{{
+					URI: &istio.StringMatch{Regex: "^/some-path(/.*)?"},
+				}}, v.Spec.HTTP[1].Match)
+				testutil.AssertEqual(t, "denied fault", &networking.HTTPFaultInjection{
+					Abort: &networking.InjectAbort{
+						Percent:    100,
+						HTTPStatus: http.StatusForbidden,
+					},
+				}, v.Spec.HTTP[1].Fault)
+			},
+		},
+		"public routes ignore network policy": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Domain: "example.com",
+						Path:   "/",
+					},
+					AppNames: []string{"backend"},
+				},
+			},
+			AllowedSources: map[string][]string{
+				"backend": {"frontend"},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{resources.KnativeIngressGateway}, v.Spec.Gateways)
+				testutil.AssertEqual(t, "HTTP len", 1, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "HTTP Rewrite", &networking.HTTPRewrite{
+					Authority: network.GetServiceHostname("backend", "some-namespace"),
+				}, v.Spec.HTTP[0].Rewrite)
+			},
+		},
+		"tcp routes": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "some-route",
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+					AppNames: []string{"broker"},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "Gateways", []string{v1alpha1.SpaceGatewayName("some-namespace")}, v.Spec.Gateways)
+				testutil.AssertEqual(t, "Hosts", []string{"tcp.example.com"}, v.Spec.Hosts)
+				testutil.AssertEqual(t, "HTTP len", 0, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "TCP", []networking.TCPRoute{{
+					Match: []networking.L4MatchAttributes{{Port: 1024}},
+					Route: []networking.RouteDestination{{
+						Destination: networking.Destination{
+							Host: network.GetServiceHostname(v1alpha1.GenerateName("some-route", "broker"), "some-namespace"),
+							Port: networking.PortSelector{Number: 1024},
+						},
+						Weight: 100,
+					}},
+				}}, v.Spec.TCP)
+			},
+		},
+		"tcp routes without apps": {
+			Route: &v1alpha1.Route{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "some-namespace",
+				},
+				Spec: v1alpha1.RouteSpec{
+					RouteSpecFields: v1alpha1.RouteSpecFields{
+						Domain: "tcp.example.com",
+						Port:   1024,
+					},
+				},
+			},
+			Assert: func(t *testing.T, v *networking.VirtualService, err error) {
+				testutil.AssertNil(t, "err", err)
+				testutil.AssertEqual(t, "HTTP len", 0, len(v.Spec.HTTP))
+				testutil.AssertEqual(t, "TCP len", 0, len(v.Spec.TCP))
+			},
+		},
 		"Hosts with subdomain": {
 			Route: &v1alpha1.Route{
 				Spec: v1alpha1.RouteSpec{
@@ -210,12 +622,25 @@ func TestMakeVirtualService(t *testing.T) {
 		},
 	} {
 		t.Run(tn, func(t *testing.T) {
-			s, err := resources.MakeVirtualService(tc.Route)
+			s, err := resources.MakeVirtualService(tc.Route, tc.AllowedSources, tc.Space)
 			tc.Assert(t, s, err)
 		})
 	}
 }
 
+func spaceWithDomain(domain v1alpha1.SpaceDomain) *v1alpha1.Space {
+	space := &v1alpha1.Space{}
+	space.Name = "some-space"
+	space.Spec.Execution.Domains = []v1alpha1.SpaceDomain{domain}
+	if domain.HasCertificate() {
+		space.Status.Certificates = []v1alpha1.SpaceStatusCertificate{{
+			Domain:     domain.Domain,
+			SecretName: domain.CertificateSecret,
+		}}
+	}
+	return space
+}
+
 func ExampleMakeVirtualService() {
 	vs1, err := resources.MakeVirtualService(&v1alpha1.Route{
 		Spec: v1alpha1.RouteSpec{
@@ -225,7 +650,7 @@ func ExampleMakeVirtualService() {
 				Path:     "/some-path-1",
 			},
 		},
-	})
+	}, nil, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -238,7 +663,7 @@ func ExampleMakeVirtualService() {
 				Path:     "/some-path-2",
 			},
 		},
-	})
+	}, nil, nil)
 	if err != nil {
 		panic(err)
 	}
@@ -258,3 +683,59 @@ func ExampleMakeVirtualService() {
 	// Output: Regex 0: ^/some-path-1(/.*)?
 	// Regex 1: ^/some-path-2(/.*)?
 }
+
+func ExampleMakeVirtualService_precedence() {
+	var r v1alpha1.HTTPRoutes
+	for _, spec := range []v1alpha1.RouteSpecFields{
+		{Domain: "example.com", Path: "/"},
+		{Domain: "example.com", Path: "/api"},
+		{Domain: "example.com", Path: "/api/v1"},
+		{Domain: "example.com", Path: "/api", PathMatch: v1alpha1.PathMatchExact},
+	} {
+		vs, err := resources.MakeVirtualService(&v1alpha1.Route{
+			Spec: v1alpha1.RouteSpec{RouteSpecFields: spec},
+		}, nil, nil)
+		if err != nil {
+			panic(err)
+		}
+
+		r = append(r, vs.Spec.HTTP...)
+	}
+
+	// Istio uses the first route that matches.
+	sort.Sort(r)
+
+	for i, h := range r {
+		fmt.Printf("Route %d: %q %q\n", i, h.Match[0].URI.Exact, h.Match[0].URI.Regex)
+	}
+
+	// Output: Route 0: "/api" ""
+	// Route 1: "" "^/api/v1(/.*)?"
+	// Route 2: "" "^/api(/.*)?"
+	// Route 3: "" "^/(/.*)?"
+}
+
+func ExampleMakeVirtualService_weights() {
+	vs, err := resources.MakeVirtualService(&v1alpha1.Route{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "some-namespace",
+		},
+		Spec: v1alpha1.RouteSpec{
+			AppNames: []string{"blue", "green", "red"},
+			RouteSpecFields: v1alpha1.RouteSpecFields{
+				Domain: "example.com",
+			},
+		},
+	}, nil, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, d := range vs.Spec.HTTP[0].Route {
+		fmt.Printf("%s: %d\n", d.Headers.Request.Set["Host"], d.Weight)
+	}
+
+	// Output: blue.some-namespace.svc.cluster.local: 34
+	// green.some-namespace.svc.cluster.local: 33
+	// red.some-namespace.svc.cluster.local: 33
+}
@@ -16,6 +16,7 @@ package source
 
 import (
 	"context"
+	"os"
 
 	kfv1alpha1 "github.com/google/kf/pkg/apis/kf/v1alpha1"
 	buildclient "github.com/google/kf/pkg/client/build/injection/client"
@@ -37,14 +38,24 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	buildInformer := buildinformer.Get(ctx)
 	buildClient := buildclient.Get(ctx)
 
+	// The clone image runs with the git credentials, so it's pinned by the
+	// controller's config rather than defaulted.
+	gitCloneImage := os.Getenv("GIT_CLONE_IMAGE")
+	if gitCloneImage == "" {
+		logger.Fatal("GIT_CLONE_IMAGE must be set to the image used to clone git sources")
+	}
+
 	// Create reconciler
 	c := &Reconciler{
-		Base:         reconciler.NewBase(ctx, "source-controller", cmw),
-		sourceLister: sourceInformer.Lister(),
-		buildLister:  buildInformer.Lister(),
-		buildClient:  buildClient.BuildV1alpha1(),
+		Base:          reconciler.NewBase(ctx, "source-controller", cmw),
+		sourceLister:  sourceInformer.Lister(),
+		buildLister:   buildInformer.Lister(),
+		buildClient:   buildClient.BuildV1alpha1(),
+		gitCloneImage: gitCloneImage,
 	}
 
+	c.fetchImage = c.fetchRemoteImage
+
 	impl := controller.NewImpl(c, logger, "sources")
 
 	c.Logger.Info("Setting up event handlers")
@@ -0,0 +1,163 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/go-containerregistry/pkg/authn/k8schain"
+	"github.com/google/go-containerregistry/pkg/name"
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	build "github.com/knative/build/pkg/apis/build/v1alpha1"
+)
+
+// lifecycleMetadataLabel is set by the buildpack lifecycle on the images it
+// exports.
+const lifecycleMetadataLabel = "io.buildpacks.lifecycle.metadata"
+
+// fetchRemoteImage fetches the image from its container registry using the
+// credentials of the Source's service account and its image pull secrets.
+func (r *Reconciler) fetchRemoteImage(source *v1alpha1.Source, image string) (gcrv1.Image, error) {
+	ref, err := name.ParseReference(image, name.WeakValidation)
+	if err != nil {
+		return nil, err
+	}
+
+	keychain, err := k8schain.New(r.KubeClientSet, k8schain.Options{
+		Namespace:          source.Namespace,
+		ServiceAccountName: source.Spec.ServiceAccount,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read registry credentials: %s", err)
+	}
+
+	auth, err := keychain.Resolve(ref.Context().Registry)
+	if err != nil {
+		return nil, err
+	}
+
+	return remote.Image(ref, remote.WithAuth(auth))
+}
+
+// resolveProvenance records the digests of the images that went into the
+// Source's latest successful build.
+func (r *Reconciler) resolveProvenance(source *v1alpha1.Source, b *build.Build) error {
+	image, err := r.fetchImage(source, source.Status.Image)
+	if err != nil {
+		return fmt.Errorf("failed to fetch image %s: %s", source.Status.Image, err)
+	}
+
+	imageDigest, err := image.Digest()
+	if err != nil {
+		return err
+	}
+
+	provenance := &v1alpha1.SourceProvenance{}
+
+	if source.Spec.IsBuildpackBuild() {
+		provenance.Buildpacks, err = buildpacksFromImage(image)
+		if err != nil {
+			return err
+		}
+
+		// The builder image is only known if it isn't the build template's
+		// default.
+		if builder := v1alpha1.GetBuildArg(b, v1alpha1.BuildArgBuildpackBuilder); builder != "" {
+			provenance.BuilderImage = builder
+			provenance.BuilderImageDigest, err = r.imageDigest(source, builder)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	if sourceImage := sourceCodeImage(&source.Spec); sourceImage != "" {
+		provenance.SourceImage = sourceImage
+		provenance.SourceImageDigest, err = r.imageDigest(source, sourceImage)
+		if err != nil {
+			return err
+		}
+	}
+
+	source.Status.ImageDigest = imageDigest.String()
+	source.Status.Provenance = provenance
+
+	return nil
+}
+
+// imageDigest fetches the digest of the image.
+func (r *Reconciler) imageDigest(source *v1alpha1.Source, image string) (string, error) {
+	img, err := r.fetchImage(source, image)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch image %s: %s", image, err)
+	}
+
+	digest, err := img.Digest()
+	if err != nil {
+		return "", err
+	}
+
+	return digest.String(), nil
+}
+
+// sourceCodeImage returns the container image the source code was uploaded
+// as or an empty string if the code came from elsewhere.
+func sourceCodeImage(spec *v1alpha1.SourceSpec) string {
+	switch {
+	case spec.IsBuildpackBuild():
+		return spec.BuildpackBuild.Source
+	case spec.IsDockerfileBuild():
+		return spec.Dockerfile.Source
+	default:
+		return ""
+	}
+}
+
+// buildpacksFromImage reads the buildpacks that contributed to an image from
+// the metadata the buildpack lifecycle leaves on it.
+func buildpacksFromImage(image gcrv1.Image) ([]v1alpha1.SourceProvenanceBuildpack, error) {
+	cfg, err := image.ConfigFile()
+	if err != nil {
+		return nil, err
+	}
+
+	raw, ok := cfg.Config.Labels[lifecycleMetadataLabel]
+	if !ok {
+		return nil, nil
+	}
+
+	var metadata struct {
+		Buildpacks []struct {
+			Key     string `json:"key"`
+			Version string `json:"version"`
+		} `json:"buildpacks"`
+	}
+	if err := json.Unmarshal([]byte(raw), &metadata); err != nil {
+		return nil, fmt.Errorf("failed to parse %s label: %s", lifecycleMetadataLabel, err)
+	}
+
+	var buildpacks []v1alpha1.SourceProvenanceBuildpack
+	for _, bp := range metadata.Buildpacks {
+		buildpacks = append(buildpacks, v1alpha1.SourceProvenanceBuildpack{
+			ID:      bp.Key,
+			Version: bp.Version,
+		})
+	}
+
+	return buildpacks, nil
+}
@@ -0,0 +1,121 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package source
+
+import (
+	"errors"
+	"testing"
+
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/random"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	build "github.com/knative/build/pkg/apis/build/v1alpha1"
+)
+
+func labeledImage(t *testing.T, labels map[string]string) gcrv1.Image {
+	t.Helper()
+
+	image, err := random.Image(64, 1)
+	testutil.AssertNil(t, "random image err", err)
+
+	image, err = mutate.Config(image, gcrv1.Config{Labels: labels})
+	testutil.AssertNil(t, "mutate config err", err)
+
+	return image
+}
+
+func digestOf(t *testing.T, image gcrv1.Image) string {
+	t.Helper()
+
+	digest, err := image.Digest()
+	testutil.AssertNil(t, "digest err", err)
+
+	return digest.String()
+}
+
+func TestReconciler_resolveProvenance(t *testing.T) {
+	t.Parallel()
+
+	appImage := labeledImage(t, map[string]string{
+		lifecycleMetadataLabel: `{"buildpacks":[{"key":"org.cloudfoundry.nodejs","version":"0.0.2"},{"key":"org.cloudfoundry.python","version":"0.1.0"}]}`,
+	})
+	builderImage := labeledImage(t, nil)
+	sourceImage := labeledImage(t, nil)
+
+	images := map[string]gcrv1.Image{
+		"gcr.io/my-project/app:1":     appImage,
+		"gcr.io/my-project/builder":   builderImage,
+		"gcr.io/my-project/src-app:1": sourceImage,
+	}
+
+	r := &Reconciler{
+		fetchImage: func(_ *v1alpha1.Source, image string) (gcrv1.Image, error) {
+			if img, ok := images[image]; ok {
+				return img, nil
+			}
+			return nil, errors.New("not found")
+		},
+	}
+
+	b := &build.Build{}
+	b.Spec.Template = &build.TemplateInstantiationSpec{
+		Arguments: []build.ArgumentSpec{
+			{Name: v1alpha1.BuildArgBuildpackBuilder, Value: "gcr.io/my-project/builder"},
+		},
+	}
+
+	t.Run("buildpack build", func(t *testing.T) {
+		source := &v1alpha1.Source{}
+		source.Spec.BuildpackBuild.Source = "gcr.io/my-project/src-app:1"
+		source.Status.Image = "gcr.io/my-project/app:1"
+
+		testutil.AssertNil(t, "err", r.resolveProvenance(source, b))
+
+		testutil.AssertEqual(t, "ImageDigest", digestOf(t, appImage), source.Status.ImageDigest)
+		testutil.AssertEqual(t, "Provenance", &v1alpha1.SourceProvenance{
+			BuilderImage:       "gcr.io/my-project/builder",
+			BuilderImageDigest: digestOf(t, builderImage),
+			SourceImage:        "gcr.io/my-project/src-app:1",
+			SourceImageDigest:  digestOf(t, sourceImage),
+			Buildpacks: []v1alpha1.SourceProvenanceBuildpack{
+				{ID: "org.cloudfoundry.nodejs", Version: "0.0.2"},
+				{ID: "org.cloudfoundry.python", Version: "0.1.0"},
+			},
+		}, source.Status.Provenance)
+	})
+
+	t.Run("container build", func(t *testing.T) {
+		source := &v1alpha1.Source{}
+		source.Spec.ContainerImage.Image = "gcr.io/my-project/app:1"
+		source.Status.Image = "gcr.io/my-project/app:1"
+
+		testutil.AssertNil(t, "err", r.resolveProvenance(source, &build.Build{}))
+
+		testutil.AssertEqual(t, "ImageDigest", digestOf(t, appImage), source.Status.ImageDigest)
+		testutil.AssertEqual(t, "Provenance", &v1alpha1.SourceProvenance{}, source.Status.Provenance)
+	})
+
+	t.Run("missing image", func(t *testing.T) {
+		source := &v1alpha1.Source{}
+		source.Status.Image = "gcr.io/my-project/deleted:1"
+
+		err := r.resolveProvenance(source, b)
+
+		testutil.AssertErrorsEqual(t, errors.New("failed to fetch image gcr.io/my-project/deleted:1: not found"), err)
+		testutil.AssertEqual(t, "ImageDigest", "", source.Status.ImageDigest)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"reflect"
 
+	gcrv1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	buildclient "github.com/google/kf/pkg/client/build/clientset/versioned/typed/build/v1alpha1"
 	buildlisters "github.com/google/kf/pkg/client/build/listers/build/v1alpha1"
@@ -43,6 +44,13 @@ type Reconciler struct {
 	// listers index properties about resources
 	sourceLister kflisters.SourceLister
 	buildLister  buildlisters.BuildLister
+
+	// gitCloneImage is the image Builds use to clone git sources.
+	gitCloneImage string
+
+	// fetchImage fetches a container image from its registry to resolve
+	// its digest and metadata.
+	fetchImage func(source *v1alpha1.Source, image string) (gcrv1.Image, error)
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -95,7 +103,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 func (r *Reconciler) ApplyChanges(ctx context.Context, source *v1alpha1.Source) error {
 	// Sync build
 	{
-		desired, err := resources.MakeBuild(source)
+		desired, err := resources.MakeBuild(source, r.gitCloneImage)
 		if err != nil {
 			return err
 		}
@@ -109,9 +117,30 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, source *v1alpha1.Source)
 		} else if !metav1.IsControlledBy(actual, source) {
 			source.Status.MarkBuildNotOwned(desired.Name)
 			return fmt.Errorf("source: %q does not own build: %q", source.Name, desired.Name)
+		} else if actual.Spec.Status != desired.Spec.Status {
+			// Builds can't be changed once they start, cancelling is the
+			// only update.
+			existing := actual.DeepCopy()
+			existing.Spec.Status = desired.Spec.Status
+
+			actual, err = r.buildClient.Builds(existing.Namespace).Update(existing)
+			if err != nil {
+				return err
+			}
 		}
 
 		source.Status.PropagateBuildStatus(actual)
+
+		// Record the digests once so Apps can be deployed by digest. The
+		// Source isn't ready until they are.
+		if source.Status.GetCondition(v1alpha1.SourceConditionBuildSucceeded).IsTrue() && source.Status.ImageDigest == "" {
+			if err := r.resolveProvenance(source, actual); err != nil {
+				source.Status.MarkImageUnresolved(err)
+				return err
+			}
+
+			source.Status.MarkImageResolved()
+		}
 	}
 
 	return nil
@@ -16,6 +16,8 @@ package resources
 
 import (
 	"fmt"
+	"sort"
+	"strings"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	build "github.com/knative/build/pkg/apis/build/v1alpha1"
@@ -29,8 +31,49 @@ const (
 	managedByLabel         = "app.kubernetes.io/managed-by"
 	buildpackBuildTemplate = "buildpack"
 	containerImageTemplate = "container"
+	dockerfileTemplate     = "dockerfile"
+
+	// buildCacheVolume is the name of the volume that caches buildpack
+	// layers.
+	buildCacheVolume = "build-cache"
+
+	// gitCredentialsVolume is the name of the volume holding the credentials
+	// for a git source.
+	gitCredentialsVolume = "git-credentials"
+
+	// gitCredentialsPath is where the credentials for a git source are
+	// mounted.
+	gitCredentialsPath = "/var/run/secrets/kf/git"
 )
 
+// gitCloneScript fetches GIT_REVISION of the repository at GIT_URL into the
+// workspace. It uses the basic-auth or ssh-auth Secret mounted at
+// gitCredentialsPath if there is one. ssh-auth Secrets must include the
+// known_hosts of the git server so the key is only sent to a trusted host.
+const gitCloneScript = `set -e
+CREDS=` + gitCredentialsPath + `
+if [ -f "$CREDS/ssh-privatekey" ]; then
+  if [ ! -f "$CREDS/known_hosts" ]; then
+    echo "The ssh-auth Secret must have a known_hosts key with the git server's host keys" >&2
+    exit 1
+  fi
+  mkdir -p "$HOME/.ssh"
+  cp "$CREDS/ssh-privatekey" "$HOME/.ssh/id_git"
+  chmod 600 "$HOME/.ssh/id_git"
+  cp "$CREDS/known_hosts" "$HOME/.ssh/known_hosts"
+  export GIT_SSH_COMMAND="ssh -i $HOME/.ssh/id_git -o StrictHostKeyChecking=yes -o UserKnownHostsFile=$HOME/.ssh/known_hosts"
+fi
+if [ -f "$CREDS/username" ]; then
+  git config --global credential.helper "!f() { echo username=\$(cat $CREDS/username); echo password=\$(cat $CREDS/password); }; f"
+fi
+cd /workspace
+git init -q
+git remote add origin "$GIT_URL"
+git fetch -q --depth=1 origin "${GIT_REVISION:-HEAD}"
+git checkout -q FETCH_HEAD
+git log -1 --format="Building commit %H"
+`
+
 // BuildName gets the name of a Build for a Source.
 func BuildName(source *v1alpha1.Source) string {
 	return fmt.Sprintf("%s-%d", source.Name, source.Generation)
@@ -46,6 +89,53 @@ func JoinRepositoryImage(repository, imageName string) string {
 	return fmt.Sprintf("%s/%s", repository, imageName)
 }
 
+// makeBuildSource creates the source for a Build from either a container
+// image or a git repository. Git repositories are cloned with gitCloneImage
+// when the Build runs so branches resolve to their latest commit.
+func makeBuildSource(image string, git *v1alpha1.SourceSpecGit, gitCloneImage string) (*build.SourceSpec, []corev1.Volume) {
+	if git == nil {
+		return &build.SourceSpec{
+			Custom: &corev1.Container{
+				Image: image,
+			},
+		}, nil
+	}
+
+	clone := &corev1.Container{
+		Image:   gitCloneImage,
+		Command: []string{"/bin/sh", "-c", gitCloneScript},
+		Env: []corev1.EnvVar{
+			{Name: "GIT_URL", Value: git.URL},
+			{Name: "GIT_REVISION", Value: git.Revision},
+		},
+		// Report clone failures in the Source's status.
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+	}
+
+	var volumes []corev1.Volume
+	if git.CredentialsSecret != "" {
+		volumes = append(volumes, corev1.Volume{
+			Name: gitCredentialsVolume,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName: git.CredentialsSecret,
+				},
+			},
+		})
+
+		clone.VolumeMounts = append(clone.VolumeMounts, corev1.VolumeMount{
+			Name:      gitCredentialsVolume,
+			MountPath: gitCredentialsPath,
+			ReadOnly:  true,
+		})
+	}
+
+	return &build.SourceSpec{
+		Custom:  clone,
+		SubPath: git.SubPath,
+	}, volumes
+}
+
 func makeContainerImageBuild(source *v1alpha1.Source) (*build.Build, error) {
 	buildName := BuildName(source)
 
@@ -80,16 +170,12 @@ func makeContainerImageBuild(source *v1alpha1.Source) (*build.Build, error) {
 	}, nil
 }
 
-func makeBuildpackBuild(source *v1alpha1.Source) (*build.Build, error) {
+func makeBuildpackBuild(source *v1alpha1.Source, gitCloneImage string) (*build.Build, error) {
 	buildName := BuildName(source)
 	appImageName := AppImageName(source)
 	imageDestination := JoinRepositoryImage(source.Spec.BuildpackBuild.Registry, appImageName)
 
-	buildSource := &build.SourceSpec{
-		Custom: &corev1.Container{
-			Image: source.Spec.BuildpackBuild.Source,
-		},
-	}
+	buildSource, volumes := makeBuildSource(source.Spec.BuildpackBuild.Source, source.Spec.BuildpackBuild.Git, gitCloneImage)
 
 	args := []build.ArgumentSpec{
 		{
@@ -101,11 +187,52 @@ func makeBuildpackBuild(source *v1alpha1.Source) (*build.Build, error) {
 			Value: source.Spec.BuildpackBuild.BuildpackBuilder,
 		},
 		{
+			// The build template writes the buildpacks to the group in the
+			// order they're listed.
 			Name:  v1alpha1.BuildArgBuildpack,
-			Value: source.Spec.BuildpackBuild.Buildpack,
+			Value: strings.Join(source.Spec.BuildpackBuild.BuildpackGroup(), ","),
 		},
 	}
 
+	// The build template's default run image is used if there's no stack.
+	if stack := source.Spec.BuildpackBuild.Stack; stack != "" {
+		args = append(args, build.ArgumentSpec{
+			Name:  v1alpha1.BuildArgRunImage,
+			Value: stack,
+		})
+	}
+
+	// Reuse the layers of previous builds if the App has a cache.
+	if claim := source.Spec.BuildpackBuild.CacheClaim; claim != "" {
+		args = append(args, build.ArgumentSpec{
+			Name:  v1alpha1.BuildArgCache,
+			Value: buildCacheVolume,
+		})
+
+		volumes = append(volumes, corev1.Volume{
+			Name: buildCacheVolume,
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{
+					ClaimName: claim,
+				},
+			},
+		})
+	}
+
+	template := &build.TemplateInstantiationSpec{
+		Name:      buildpackBuildTemplate,
+		Kind:      "ClusterBuildTemplate",
+		Arguments: args,
+		Env:       source.Spec.BuildpackBuild.Env,
+	}
+
+	// Spaces can substitute their own template, e.g. one that trusts
+	// additional certificates.
+	if name := source.Spec.BuildpackBuild.BuildTemplate; name != "" {
+		template.Name = name
+		template.Kind = "BuildTemplate"
+	}
+
 	return &build.Build{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      buildName,
@@ -122,21 +249,112 @@ func makeBuildpackBuild(source *v1alpha1.Source) (*build.Build, error) {
 		Spec: build.BuildSpec{
 			Source:             buildSource,
 			ServiceAccountName: source.Spec.ServiceAccount,
+			Volumes:            volumes,
+			Template:           template,
+		},
+	}, nil
+}
+
+func makeDockerfileBuild(source *v1alpha1.Source, gitCloneImage string) (*build.Build, error) {
+	buildName := BuildName(source)
+	appImageName := AppImageName(source)
+	dockerfile := source.Spec.Dockerfile
+	imageDestination := JoinRepositoryImage(dockerfile.Registry, appImageName)
+
+	buildSource, volumes := makeBuildSource(dockerfile.Source, dockerfile.Git, gitCloneImage)
+
+	args := []build.ArgumentSpec{
+		{
+			Name:  v1alpha1.BuildArgImage,
+			Value: imageDestination,
+		},
+		{
+			Name:  v1alpha1.BuildArgDockerfile,
+			Value: dockerfile.Path,
+		},
+		{
+			Name:  v1alpha1.BuildArgTarget,
+			Value: dockerfile.Target,
+		},
+		{
+			Name:  v1alpha1.BuildArgBuildArgs,
+			Value: buildArgFlags(dockerfile.BuildArgs),
+		},
+	}
+
+	return &build.Build{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      buildName,
+			Namespace: source.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(source),
+			},
+			// Copy labels from the parent
+			Labels: resources.UnionMaps(
+				source.GetLabels(), map[string]string{
+					managedByLabel: "kf",
+				}),
+		},
+		Spec: build.BuildSpec{
+			Source:             buildSource,
+			ServiceAccountName: source.Spec.ServiceAccount,
+			Volumes:            volumes,
 			Template: &build.TemplateInstantiationSpec{
-				Name:      buildpackBuildTemplate,
+				Name:      dockerfileTemplate,
 				Kind:      "ClusterBuildTemplate",
 				Arguments: args,
-				Env:       source.Spec.BuildpackBuild.Env,
 			},
 		},
 	}, nil
 }
 
-// MakeBuild creates a Build for a Source.
-func MakeBuild(source *v1alpha1.Source) (*build.Build, error) {
-	if source.Spec.IsContainerBuild() {
-		return makeContainerImageBuild(source)
-	} else {
-		return makeBuildpackBuild(source)
+// buildArgFlags converts the build args into --build-arg flags that are
+// quoted so the template can pass them through the shell as-is.
+func buildArgFlags(buildArgs map[string]string) string {
+	var names []string
+	for name := range buildArgs {
+		names = append(names, name)
 	}
+	sort.Strings(names)
+
+	var flags []string
+	for _, name := range names {
+		flags = append(flags, "--build-arg "+shellQuote(name+"="+buildArgs[name]))
+	}
+
+	return strings.Join(flags, " ")
+}
+
+// shellQuote wraps the value in single quotes, escaping any it contains.
+func shellQuote(value string) string {
+	return "'" + strings.Replace(value, "'", `'\''`, -1) + "'"
+}
+
+// MakeBuild creates a Build for a Source. Git sources are cloned with
+// gitCloneImage.
+func MakeBuild(source *v1alpha1.Source, gitCloneImage string) (*build.Build, error) {
+	var (
+		b   *build.Build
+		err error
+	)
+
+	switch {
+	case source.Spec.IsContainerBuild():
+		b, err = makeContainerImageBuild(source)
+	case source.Spec.IsDockerfileBuild():
+		b, err = makeDockerfileBuild(source, gitCloneImage)
+	default:
+		b, err = makeBuildpackBuild(source, gitCloneImage)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	b.Spec.Timeout = source.Spec.Timeout.DeepCopy()
+	if source.IsCancelled() {
+		b.Spec.Status = build.BuildSpecStatusCancelled
+	}
+
+	return b, nil
 }
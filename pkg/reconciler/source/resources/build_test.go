@@ -16,9 +16,11 @@ package resources
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 func ExampleBuildName() {
@@ -63,7 +65,7 @@ func ExampleMakeBuild() {
 		},
 	}
 
-	build, err := MakeBuild(source)
+	build, err := MakeBuild(source, "git-clone-image")
 	if err != nil {
 		panic(err)
 	}
@@ -84,3 +86,185 @@ func ExampleMakeBuild() {
 	// Output Image: some-registry/app-my-namespace-my-source:5
 	// Env: some = variable
 }
+
+func ExampleMakeBuild_dockerfile() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Namespace = "my-namespace"
+	source.Generation = 5
+	source.Spec.Dockerfile.Source = "some-source"
+	source.Spec.Dockerfile.Registry = "some-registry"
+	source.Spec.Dockerfile.Path = "build/Dockerfile"
+	source.Spec.Dockerfile.Target = "release"
+	source.Spec.Dockerfile.BuildArgs = map[string]string{
+		"VERSION": "1.0",
+		"MOTD":    "it's alive",
+	}
+
+	build, err := MakeBuild(source, "git-clone-image")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Template:", build.Spec.Template.Name)
+	fmt.Println("Source:", build.Spec.Source.Custom.Image)
+	for _, arg := range build.Spec.Template.Arguments {
+		fmt.Printf("%s: %s\n", arg.Name, arg.Value)
+	}
+
+	// Output: Template: dockerfile
+	// Source: some-source
+	// IMAGE: some-registry/app-my-namespace-my-source:5
+	// DOCKERFILE: build/Dockerfile
+	// TARGET: release
+	// BUILD_ARGS: --build-arg 'MOTD=it'\''s alive' --build-arg 'VERSION=1.0'
+}
+
+func ExampleMakeBuild_git() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Namespace = "my-namespace"
+	source.Generation = 5
+	source.Spec.BuildpackBuild.Registry = "some-registry"
+	source.Spec.BuildpackBuild.Git = &v1alpha1.SourceSpecGit{
+		URL:               "https://github.com/google/kf.git",
+		Revision:          "master",
+		SubPath:           "samples/apps/helloworld",
+		CredentialsSecret: "git-credentials",
+	}
+
+	build, err := MakeBuild(source, "git-clone-image")
+	if err != nil {
+		panic(err)
+	}
+
+	clone := build.Spec.Source.Custom
+	fmt.Println("Template:", build.Spec.Template.Name)
+	fmt.Println("Clone Image:", clone.Image)
+	for _, env := range clone.Env {
+		fmt.Println("Env:", env.Name, "=", env.Value)
+	}
+	fmt.Println("Sub Path:", build.Spec.Source.SubPath)
+	fmt.Println("Credentials:", build.Spec.Volumes[0].Secret.SecretName)
+	fmt.Println("Credentials Mount:", clone.VolumeMounts[0].MountPath)
+
+	// Output: Template: buildpack
+	// Clone Image: git-clone-image
+	// Env: GIT_URL = https://github.com/google/kf.git
+	// Env: GIT_REVISION = master
+	// Sub Path: samples/apps/helloworld
+	// Credentials: git-credentials
+	// Credentials Mount: /var/run/secrets/kf/git
+}
+
+func ExampleMakeBuild_cache() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.BuildpackBuild.Registry = "some-registry"
+	source.Spec.BuildpackBuild.CacheClaim = "my-app-build-cache"
+
+	build, err := MakeBuild(source, "git-clone-image")
+	if err != nil {
+		panic(err)
+	}
+
+	args := build.Spec.Template.Arguments
+	fmt.Println("Cache Arg:", args[len(args)-1].Name, "=", args[len(args)-1].Value)
+	fmt.Println("Cache Volume:", build.Spec.Volumes[0].Name)
+	fmt.Println("Cache Claim:", build.Spec.Volumes[0].PersistentVolumeClaim.ClaimName)
+
+	// Output: Cache Arg: CACHE = build-cache
+	// Cache Volume: build-cache
+	// Cache Claim: my-app-build-cache
+}
+
+func ExampleMakeBuild_stack() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.BuildpackBuild.Stack = "gcr.io/my-company/run:bionic"
+
+	build, err := MakeBuild(source, "git-clone-image")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, arg := range build.Spec.Template.Arguments {
+		if arg.Name == v1alpha1.BuildArgRunImage {
+			fmt.Println("Run Image:", arg.Value)
+		}
+	}
+
+	// Output: Run Image: gcr.io/my-company/run:bionic
+}
+
+func ExampleMakeBuild_buildpacks() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.BuildpackBuild.Buildpacks = []string{"org.cloudfoundry.nodejs", "org.cloudfoundry.python"}
+
+	build, err := MakeBuild(source, "git-clone-image")
+	if err != nil {
+		panic(err)
+	}
+
+	for _, arg := range build.Spec.Template.Arguments {
+		if arg.Name == v1alpha1.BuildArgBuildpack {
+			fmt.Println("Buildpacks:", arg.Value)
+		}
+	}
+
+	// Output: Buildpacks: org.cloudfoundry.nodejs,org.cloudfoundry.python
+}
+
+func ExampleMakeBuild_buildTemplate() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.BuildpackBuild.BuildTemplate = "corporate-buildpack"
+
+	build, err := MakeBuild(source, "git-clone-image")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Template:", build.Spec.Template.Kind, build.Spec.Template.Name)
+
+	// Output: Template: BuildTemplate corporate-buildpack
+}
+
+func ExampleMakeBuild_timeout() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Spec.Timeout = &metav1.Duration{Duration: 45 * time.Minute}
+
+	build, err := MakeBuild(source, "git-clone-image")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Timeout:", build.Spec.Timeout.Duration)
+
+	// Output: Timeout: 45m0s
+}
+
+func ExampleMakeBuild_cancelled() {
+	source := &v1alpha1.Source{}
+	source.Name = "my-source"
+	source.Spec.BuildpackBuild.Source = "some-source"
+	source.Annotations = map[string]string{
+		v1alpha1.SourceCancelledAnnotation: "true",
+	}
+
+	build, err := MakeBuild(source, "git-clone-image")
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Status:", build.Spec.Status)
+
+	// Output: Status: BuildCancelled
+}
@@ -16,17 +16,25 @@ package space
 
 import (
 	"context"
+	"reflect"
 
 	"github.com/google/kf/pkg/apis/kf/v1alpha1"
 	spaceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/space"
 	"github.com/google/kf/pkg/reconciler"
+	"github.com/google/kf/pkg/reconciler/space/resources"
+	gatewayinformer "knative.dev/pkg/client/injection/informers/istio/v1alpha3/gateway"
 	namespaceinformer "knative.dev/pkg/injection/informers/kubeinformers/corev1/namespace"
+	secretinformer "knative.dev/pkg/injection/informers/kubeinformers/corev1/secret"
 	roleinformer "knative.dev/pkg/injection/informers/kubeinformers/rbacv1/role"
 
 	// TODO (juliaguo): replace with knative informer pkgs once they are merged in
 	limitrangeinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/limitrange"
 	quotainformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/resourcequota"
 
+	kflisters "github.com/google/kf/pkg/client/listers/kf/v1alpha1"
+	"go.uber.org/zap"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/client-go/tools/cache"
 
 	"knative.dev/pkg/configmap"
@@ -44,6 +52,8 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	roleInformer := roleinformer.Get(ctx)
 	quotaInformer := quotainformer.Get(ctx)
 	limitRangeInformer := limitrangeinformer.Get(ctx)
+	secretInformer := secretinformer.Get(ctx)
+	gatewayInformer := gatewayinformer.Get(ctx)
 
 	// Create reconciler
 	c := &Reconciler{
@@ -53,6 +63,8 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		roleLister:          roleInformer.Lister(),
 		resourceQuotaLister: quotaInformer.Lister(),
 		limitRangeLister:    limitRangeInformer.Lister(),
+		secretLister:        secretInformer.Lister(),
+		gatewayLister:       gatewayInformer.Lister(),
 	}
 
 	impl := controller.NewImpl(c, logger, "Spaces")
@@ -61,6 +73,20 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 	// Watch for changes in sub-resources so we can sync accordingly
 	spaceInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
 
+	// Spaces compete for TCP ports and HTTPS domains on the ingress gateway,
+	// so changes to one space can free up a port or domain for the others.
+	spaceInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		UpdateFunc: func(old, new interface{}) {
+			oldSpace, newSpace := old.(*v1alpha1.Space), new.(*v1alpha1.Space)
+			if !reflect.DeepEqual(oldSpace.Spec.Execution.Domains, newSpace.Spec.Execution.Domains) {
+				enqueueSpaces(impl, spaceInformer.Lister(), logger)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			enqueueSpaces(impl, spaceInformer.Lister(), logger)
+		},
+	})
+
 	nsInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
 		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Space")),
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
@@ -81,5 +107,40 @@ func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl
 		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
 	})
 
+	gatewayInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Space")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Certificates live in the space's namespace, which has the same name as
+	// the space. Their copies are owned by the space.
+	secretInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: func(obj interface{}) bool {
+			secret, ok := obj.(*corev1.Secret)
+			return ok && secret.Type == corev1.SecretTypeTLS
+		},
+		Handler: controller.HandleAll(func(obj interface{}) {
+			secret := obj.(*corev1.Secret)
+			if secret.Namespace == resources.IngressGatewayNamespace {
+				impl.EnqueueControllerOf(secret)
+				return
+			}
+			impl.EnqueueKey(secret.Namespace)
+		}),
+	})
+
 	return impl
 }
+
+// enqueueSpaces queues every space to be reconciled.
+func enqueueSpaces(impl *controller.Impl, lister kflisters.SpaceLister, logger *zap.SugaredLogger) {
+	spaces, err := lister.List(labels.Everything())
+	if err != nil {
+		logger.Warnw("Failed to list spaces", zap.Error(err))
+		return
+	}
+
+	for _, space := range spaces {
+		impl.Enqueue(space)
+	}
+}
@@ -30,9 +30,13 @@ import (
 	"k8s.io/apimachinery/pkg/api/errors"
 	apierrs "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	v1listers "k8s.io/client-go/listers/core/v1"
 	rbacv1listers "k8s.io/client-go/listers/rbac/v1"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/retry"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+	istiolisters "knative.dev/pkg/client/listers/istio/v1alpha3"
 	"knative.dev/pkg/controller"
 	"knative.dev/pkg/kmp"
 	"knative.dev/pkg/logging"
@@ -48,6 +52,8 @@ type Reconciler struct {
 	roleLister          rbacv1listers.RoleLister
 	resourceQuotaLister v1listers.ResourceQuotaLister
 	limitRangeLister    v1listers.LimitRangeLister
+	secretLister        v1listers.SecretLister
+	gatewayLister       istiolisters.GatewayLister
 }
 
 // Check that our Reconciler implements controller.Reconciler
@@ -66,7 +72,9 @@ func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
 	switch {
 	case apierrs.IsNotFound(err):
 		logger.Errorf("space %q no longer exists\n", name)
-		return nil
+
+		// Close the TCP ports the space reserved.
+		return r.syncIngressPorts()
 
 	case err != nil:
 		return err
@@ -237,9 +245,170 @@ func (r *Reconciler) ApplyChanges(ctx context.Context, space *v1alpha1.Space) er
 		space.Status.PropagateLimitRangeStatus(actual)
 	}
 
+	spaces, err := r.spaceLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	takenDomains := resources.TakenDomains(space, spaces)
+
+	// Sync certificates
+	var certificates []v1alpha1.SpaceStatusCertificate
+	invalidCertificates := make(map[string]error)
+	{
+		var desired []*v1.Secret
+		for _, domain := range space.Spec.Execution.Domains {
+			if _, taken := takenDomains[domain.Domain]; taken || !domain.HasCertificate() {
+				continue
+			}
+
+			// Domains with a certificate that can't be used are left out of
+			// the Gateway so the other domains are still served.
+			source, err := r.secretLister.Secrets(namespaceName).Get(domain.CertificateSecret)
+			if errors.IsNotFound(err) {
+				invalidCertificates[domain.Domain] = err
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			notAfter, err := resources.CertificateExpiry(source)
+			if err != nil {
+				invalidCertificates[domain.Domain] = err
+				continue
+			}
+
+			certificates = append(certificates, v1alpha1.SpaceStatusCertificate{
+				Domain:     domain.Domain,
+				SecretName: domain.CertificateSecret,
+				NotAfter:   metav1.NewTime(notAfter),
+			})
+			desired = append(desired, resources.MakeCertificateSecret(space, domain.Domain, source))
+		}
+
+		for _, secret := range desired {
+			actual, err := r.secretLister.Secrets(secret.Namespace).Get(secret.Name)
+			if errors.IsNotFound(err) {
+				if _, err := r.KubeClientSet.CoreV1().Secrets(secret.Namespace).Create(secret); err != nil {
+					return err
+				}
+			} else if err != nil {
+				return err
+			} else if !metav1.IsControlledBy(actual, space) {
+				space.Status.MarkCertificateNotOwned(secret.Name)
+				return fmt.Errorf("space: %q does not own secret: %q", space.Name, secret.Name)
+			} else if _, err := r.reconcileSecret(secret, actual); err != nil {
+				return err
+			}
+		}
+
+		if err := r.deleteStaleCertificates(space, desired); err != nil {
+			return err
+		}
+	}
+
+	// Sync gateway
+	{
+		desired, err := resources.MakeGateway(space, spaces, certificates)
+		if err != nil {
+			return err
+		}
+
+		name := resources.GatewayName(space)
+		actual, err := r.gatewayLister.Gateways(v1alpha1.KfNamespace).Get(name)
+		switch {
+		case errors.IsNotFound(err):
+			if desired != nil {
+				if _, err := r.SharedClientSet.Networking().Gateways(desired.Namespace).Create(desired); err != nil {
+					return err
+				}
+			}
+		case err != nil:
+			return err
+		case !metav1.IsControlledBy(actual, space):
+			space.Status.MarkGatewayNotOwned(name)
+			return fmt.Errorf("space: %q does not own gateway: %q", space.Name, name)
+		case desired == nil:
+			// There's nothing left for the Gateway to serve.
+			if err := r.SharedClientSet.Networking().Gateways(actual.Namespace).Delete(name, &metav1.DeleteOptions{}); err != nil {
+				return err
+			}
+		default:
+			if _, err := r.reconcileGateway(desired, actual); err != nil {
+				return err
+			}
+		}
+
+		space.Status.PropagateGatewayStatus(certificates)
+
+		// Report what the Gateway had to leave out.
+		for _, domain := range space.Spec.Execution.Domains {
+			if owner, ok := takenDomains[domain.Domain]; ok {
+				space.Status.MarkDomainTaken(domain.Domain, owner)
+			} else if err, ok := invalidCertificates[domain.Domain]; ok {
+				space.Status.MarkCertificateInvalid(domain.Domain, err)
+			}
+		}
+
+		takenPorts := resources.TakenTCPPorts(space, spaces)
+		for _, port := range space.Spec.Execution.TCPPorts() {
+			if owner, ok := takenPorts[port]; ok {
+				space.Status.MarkTCPPortTaken(port, owner)
+			}
+		}
+	}
+
+	// The ingress gateway's Service is shared by every space so failing to
+	// update it is reported on the space rather than failing it.
+	if err := r.syncIngressPorts(); err != nil {
+		logger.Warnf("Failed to sync the ingress gateway's ports: %s", err)
+		space.Status.MarkIngressPortsUnavailable(err)
+		return nil
+	}
+
+	space.Status.PropagateIngressPortsStatus()
 	return nil
 }
 
+// syncIngressPorts exposes the TCP ports reserved by every space on the
+// ingress gateway's Service.
+func (r *Reconciler) syncIngressPorts() error {
+	spaces, err := r.spaceLister.List(labels.Everything())
+	if err != nil {
+		return err
+	}
+
+	var active []*v1alpha1.Space
+	for _, space := range spaces {
+		if space.GetDeletionTimestamp() == nil {
+			active = append(active, space)
+		}
+	}
+
+	// The ingress gateway's Service is installed with Istio, so it's read
+	// from the API server rather than watched. Spaces are reconciled in
+	// parallel so updates that lose a race with another space are retried.
+	return retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		actual, err := r.KubeClientSet.
+			CoreV1().
+			Services(resources.IngressGatewayNamespace).
+			Get(resources.IngressGatewayService, metav1.GetOptions{})
+		if err != nil {
+			return err
+		}
+
+		desired := resources.MakeIngressPorts(actual.Spec.Ports, active)
+		if equality.Semantic.DeepEqual(desired, actual.Spec.Ports) {
+			return nil
+		}
+
+		// Preserve the rest of the Service (e.g. its type and load balancer).
+		actual.Spec.Ports = desired
+
+		_, err = r.KubeClientSet.CoreV1().Services(actual.Namespace).Update(actual)
+		return err
+	})
+}
+
 func (r *Reconciler) reconcileNs(desired, actual *v1.Namespace) (*v1.Namespace, error) {
 	// Check for differences, if none we don't need to reconcile.
 	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
@@ -328,6 +497,74 @@ func (r *Reconciler) reconcileLimitRange(desired, actual *v1.LimitRange) (*v1.Li
 	return r.KubeClientSet.CoreV1().LimitRanges(existing.Namespace).Update(existing)
 }
 
+func (r *Reconciler) reconcileSecret(desired, actual *v1.Secret) (*v1.Secret, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Data, actual.Data)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Data = desired.Data
+	return r.KubeClientSet.CoreV1().Secrets(existing.Namespace).Update(existing)
+}
+
+// deleteStaleCertificates removes the copies of certificates for domains that
+// no longer have one.
+func (r *Reconciler) deleteStaleCertificates(space *v1alpha1.Space, desired []*v1.Secret) error {
+	selector := labels.SelectorFromSet(labels.Set{resources.SpaceLabel: space.Name})
+	actual, err := r.secretLister.Secrets(resources.IngressGatewayNamespace).List(selector)
+	if err != nil {
+		return err
+	}
+
+	keep := make(map[string]bool)
+	for _, secret := range desired {
+		keep[secret.Name] = true
+	}
+
+	for _, secret := range actual {
+		if keep[secret.Name] || !metav1.IsControlledBy(secret, space) {
+			continue
+		}
+
+		err := r.KubeClientSet.CoreV1().Secrets(secret.Namespace).Delete(secret.Name, &metav1.DeleteOptions{})
+		if err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (r *Reconciler) reconcileGateway(desired, actual *networking.Gateway) (*networking.Gateway, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	if _, err := kmp.SafeDiff(desired.Spec, actual.Spec); err != nil {
+		return nil, fmt.Errorf("failed to diff Gateway: %v", err)
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec = desired.Spec
+	return r.SharedClientSet.Networking().Gateways(existing.Namespace).Update(existing)
+}
+
 func (r *Reconciler) updateStatus(desired *v1alpha1.Space) (*v1alpha1.Space, error) {
 	actual, err := r.spaceLister.Get(desired.Name)
 	if err != nil {
@@ -0,0 +1,85 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	v1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+const (
+	// IngressGatewayNamespace is the namespace of Istio's ingress gateway.
+	// The gateway can only load certificates from Secrets in its own
+	// namespace.
+	IngressGatewayNamespace = "istio-system"
+
+	// SpaceLabel is the label on the copies of certificates that holds the
+	// name of the Space they belong to.
+	SpaceLabel = "kf.dev/space"
+)
+
+// CertificateSecretName gets the name of the copy of a domain's certificate
+// in the ingress gateway's namespace.
+func CertificateSecretName(space *v1alpha1.Space, domain string) string {
+	return v1alpha1.GenerateName(space.Name, domain)
+}
+
+// MakeCertificateSecret copies the certificate of a domain from the space
+// into the ingress gateway's namespace.
+func MakeCertificateSecret(space *v1alpha1.Space, domain string, source *v1.Secret) *v1.Secret {
+	return &v1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CertificateSecretName(space, domain),
+			Namespace: IngressGatewayNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(space),
+			},
+			Labels: resources.UnionMaps(space.GetLabels(), map[string]string{
+				managedByLabel: "kf",
+				SpaceLabel:     space.Name,
+			}),
+		},
+		Type: v1.SecretTypeTLS,
+		Data: map[string][]byte{
+			v1.TLSCertKey:       source.Data[v1.TLSCertKey],
+			v1.TLSPrivateKeyKey: source.Data[v1.TLSPrivateKeyKey],
+		},
+	}
+}
+
+// CertificateExpiry returns the time the certificate in a kubernetes.io/tls
+// Secret expires.
+func CertificateExpiry(secret *v1.Secret) (time.Time, error) {
+	block, _ := pem.Decode(secret.Data[v1.TLSCertKey])
+	if block == nil {
+		return time.Time{}, errors.New("secret doesn't contain a PEM encoded certificate")
+	}
+
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to parse certificate: %s", err)
+	}
+
+	return cert.NotAfter, nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/testutil"
+	v1 "k8s.io/api/core/v1"
+)
+
+func makeCertificate(t *testing.T, notAfter time.Time) []byte {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	testutil.AssertNil(t, "GenerateKey err", err)
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "example.com"},
+		NotBefore:    notAfter.Add(-time.Hour),
+		NotAfter:     notAfter,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	testutil.AssertNil(t, "CreateCertificate err", err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+}
+
+func TestCertificateExpiry(t *testing.T) {
+	t.Parallel()
+
+	notAfter := time.Date(2030, time.January, 1, 0, 0, 0, 0, time.UTC)
+	_, parseErr := x509.ParseCertificate([]byte("invalid"))
+
+	cases := map[string]struct {
+		data        []byte
+		expected    time.Time
+		expectedErr error
+	}{
+		"valid certificate": {
+			data:     makeCertificate(t, notAfter),
+			expected: notAfter,
+		},
+		"missing certificate": {
+			expectedErr: errors.New("secret doesn't contain a PEM encoded certificate"),
+		},
+		"invalid certificate": {
+			data:        pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("invalid")}),
+			expectedErr: fmt.Errorf("failed to parse certificate: %s", parseErr),
+		},
+	}
+
+	for tn, tc := range cases {
+		t.Run(tn, func(t *testing.T) {
+			secret := &v1.Secret{
+				Data: map[string][]byte{v1.TLSCertKey: tc.data},
+			}
+
+			actual, err := CertificateExpiry(secret)
+			if tc.expectedErr != nil || err != nil {
+				testutil.AssertErrorsEqual(t, tc.expectedErr, err)
+				return
+			}
+
+			testutil.AssertEqual(t, "expiry", tc.expected, actual.UTC())
+		})
+	}
+}
+
+func ExampleMakeCertificateSecret() {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+
+	source := &v1.Secret{
+		Data: map[string][]byte{
+			v1.TLSCertKey:       []byte("some-cert"),
+			v1.TLSPrivateKeyKey: []byte("some-key"),
+			"ca.crt":            []byte("some-ca"),
+		},
+	}
+
+	secret := MakeCertificateSecret(space, "example.com", source)
+
+	fmt.Println("Namespace:", secret.Namespace)
+	fmt.Println("Managed by:", secret.Labels[managedByLabel])
+	fmt.Println("Space:", secret.Labels[SpaceLabel])
+	fmt.Println("Type:", secret.Type)
+	fmt.Println("Keys:", len(secret.Data))
+
+	// Output: Namespace: istio-system
+	// Managed by: kf
+	// Space: my-space
+	// Type: kubernetes.io/tls
+	// Keys: 2
+}
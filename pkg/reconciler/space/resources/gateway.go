@@ -0,0 +1,183 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/knative/serving/pkg/resources"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	networking "knative.dev/pkg/apis/istio/v1alpha3"
+	"knative.dev/pkg/kmeta"
+)
+
+// GatewayName gets the name of the Gateway given the space.
+func GatewayName(space *v1alpha1.Space) string {
+	return v1alpha1.SpaceGatewayName(space.Name)
+}
+
+// TakenTCPPorts maps the TCP ports of the space that one of the given spaces
+// reserved first to the name of that space. The ingress gateway can only
+// forward a port to one space.
+func TakenTCPPorts(space *v1alpha1.Space, spaces []*v1alpha1.Space) map[int32]string {
+	owners := make(map[int32]*v1alpha1.Space)
+	for _, other := range precedingSpaces(space, spaces) {
+		for _, port := range other.Spec.Execution.TCPPorts() {
+			if owner, ok := owners[port]; !ok || other.Precedes(owner) {
+				owners[port] = other
+			}
+		}
+	}
+
+	taken := make(map[int32]string)
+	for _, port := range space.Spec.Execution.TCPPorts() {
+		if owner, ok := owners[port]; ok {
+			taken[port] = owner.Name
+		}
+	}
+
+	return taken
+}
+
+// TakenDomains maps the domains the space has certificates for that one of
+// the given spaces serves over HTTPS first to the name of that space. The
+// ingress gateway can only serve a host on the HTTPS port from one Gateway.
+func TakenDomains(space *v1alpha1.Space, spaces []*v1alpha1.Space) map[string]string {
+	owners := make(map[string]*v1alpha1.Space)
+	for _, other := range precedingSpaces(space, spaces) {
+		for _, domain := range other.Spec.Execution.Domains {
+			if !domain.HasCertificate() {
+				continue
+			}
+
+			if owner, ok := owners[domain.Domain]; !ok || other.Precedes(owner) {
+				owners[domain.Domain] = other
+			}
+		}
+	}
+
+	taken := make(map[string]string)
+	for _, domain := range space.Spec.Execution.Domains {
+		if owner, ok := owners[domain.Domain]; ok && domain.HasCertificate() {
+			taken[domain.Domain] = owner.Name
+		}
+	}
+
+	return taken
+}
+
+// precedingSpaces returns the spaces whose claims on the ingress gateway win
+// over the space's.
+func precedingSpaces(space *v1alpha1.Space, spaces []*v1alpha1.Space) []*v1alpha1.Space {
+	var preceding []*v1alpha1.Space
+	for _, other := range spaces {
+		if other.Name != space.Name && other.Precedes(space) {
+			preceding = append(preceding, other)
+		}
+	}
+
+	return preceding
+}
+
+// MakeGateway creates a Gateway that serves the space's domains over HTTPS
+// with the given certificates and forwards the space's TCP ports to its TCP
+// routes. Ports taken by one of the given spaces are left out. It returns nil
+// if there are no certificates or TCP ports to serve.
+func MakeGateway(space *v1alpha1.Space, spaces []*v1alpha1.Space, certificates []v1alpha1.SpaceStatusCertificate) (*networking.Gateway, error) {
+	served := make(map[string]bool)
+	for _, cert := range certificates {
+		served[cert.Domain] = true
+	}
+
+	var servers []networking.Server
+	for _, domain := range space.Spec.Execution.Domains {
+		if !domain.HasCertificate() || !served[domain.Domain] {
+			continue
+		}
+
+		hosts := []string{domain.Domain, "*." + domain.Domain}
+
+		servers = append(servers, networking.Server{
+			Hosts: hosts,
+			Port: networking.Port{
+				Name:     v1alpha1.GenerateName("https", domain.Domain),
+				Number:   443,
+				Protocol: networking.ProtocolHTTPS,
+			},
+			TLS: &networking.TLSOptions{
+				Mode:           networking.TLSModeSimple,
+				CredentialName: CertificateSecretName(space, domain.Domain),
+			},
+		})
+
+		// Routes on domains that redirect aren't attached to the Knative
+		// gateway, so this server answers their HTTP requests.
+		if domain.RedirectHTTP {
+			servers = append(servers, networking.Server{
+				Hosts: hosts,
+				Port: networking.Port{
+					Name:     v1alpha1.GenerateName("http", domain.Domain),
+					Number:   80,
+					Protocol: networking.ProtocolHTTP,
+				},
+				TLS: &networking.TLSOptions{
+					HTTPSRedirect: true,
+				},
+			})
+		}
+	}
+
+	// TCP routes are matched by port alone, so the servers accept
+	// connections for any host.
+	taken := TakenTCPPorts(space, spaces)
+	for _, port := range space.Spec.Execution.TCPPorts() {
+		if _, ok := taken[port]; ok {
+			continue
+		}
+
+		servers = append(servers, networking.Server{
+			Hosts: []string{"*"},
+			Port: networking.Port{
+				Name:     fmt.Sprintf("tcp-%d", port),
+				Number:   uint32(port),
+				Protocol: networking.ProtocolTCP,
+			},
+		})
+	}
+
+	if len(servers) == 0 {
+		return nil, nil
+	}
+
+	return &networking.Gateway{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      GatewayName(space),
+			Namespace: v1alpha1.KfNamespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(space),
+			},
+			Labels: resources.UnionMaps(space.GetLabels(), map[string]string{
+				managedByLabel: "kf",
+			}),
+		},
+		Spec: networking.GatewaySpec{
+			Selector: map[string]string{
+				"istio": "ingressgateway",
+			},
+			Servers: servers,
+		},
+	}, nil
+}
@@ -0,0 +1,127 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func ExampleMakeGateway() {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+	space.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "example.com", Default: true, CertificateSecret: "example-cert", RedirectHTTP: true},
+		{Domain: "plain.example.com"},
+	}
+	certificates := []v1alpha1.SpaceStatusCertificate{
+		{Domain: "example.com", SecretName: "example-cert"},
+	}
+
+	gateway, err := MakeGateway(space, nil, certificates)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Name:", GatewayName(space))
+	fmt.Println("Managed by:", gateway.Labels[managedByLabel])
+	for _, server := range gateway.Spec.Servers {
+		fmt.Printf("Server: %s %d %v redirect: %t\n", server.Port.Protocol, server.Port.Number, server.Hosts, server.TLS.HTTPSRedirect)
+	}
+	fmt.Println("Credential:", gateway.Spec.Servers[0].TLS.CredentialName == CertificateSecretName(space, "example.com"))
+
+	// Output: Name: kf-my-space-tls-1t2h6nuh5a6xd
+	// Managed by: kf
+	// Server: HTTPS 443 [example.com *.example.com] redirect: false
+	// Server: HTTP 80 [example.com *.example.com] redirect: true
+	// Credential: true
+}
+
+func ExampleMakeGateway_withoutCertificates() {
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+	space.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "example.com", Default: true},
+	}
+
+	gateway, err := MakeGateway(space, nil, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("Gateway:", gateway == nil)
+
+	// Output: Gateway: true
+}
+
+func ExampleMakeGateway_tcpPorts() {
+	older := &v1alpha1.Space{}
+	older.Name = "older-space"
+	older.CreationTimestamp = metav1.NewTime(time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC))
+	older.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "tcp.example.com", TCPPorts: []int32{1024}},
+	}
+
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+	space.CreationTimestamp = metav1.NewTime(older.CreationTimestamp.Add(time.Hour))
+	space.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "example.com", Default: true},
+		{Domain: "tcp.example.com", TCPPorts: []int32{1025, 1024}},
+	}
+
+	gateway, err := MakeGateway(space, []*v1alpha1.Space{older, space}, nil)
+	if err != nil {
+		panic(err)
+	}
+
+	for _, server := range gateway.Spec.Servers {
+		fmt.Printf("Server: %s %s %d %v\n", server.Port.Name, server.Port.Protocol, server.Port.Number, server.Hosts)
+	}
+	fmt.Println("Taken:", TakenTCPPorts(space, []*v1alpha1.Space{older, space}))
+
+	// Output: Server: tcp-1025 TCP 1025 [*]
+	// Taken: map[1024:older-space]
+}
+
+func ExampleTakenDomains() {
+	created := metav1.NewTime(time.Date(2019, 7, 1, 12, 0, 0, 0, time.UTC))
+
+	older := &v1alpha1.Space{}
+	older.Name = "older-space"
+	older.CreationTimestamp = created
+	older.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "example.com", CertificateSecret: "example-cert"},
+		{Domain: "plain.example.com"},
+	}
+
+	space := &v1alpha1.Space{}
+	space.Name = "my-space"
+	space.CreationTimestamp = metav1.NewTime(created.Add(time.Hour))
+	space.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "example.com", CertificateSecret: "my-cert"},
+		{Domain: "plain.example.com", CertificateSecret: "my-cert"},
+	}
+
+	spaces := []*v1alpha1.Space{older, space}
+	fmt.Println("Mine:", TakenDomains(space, spaces))
+	fmt.Println("Older:", TakenDomains(older, spaces))
+
+	// Output: Mine: map[example.com:older-space]
+	// Older: map[]
+}
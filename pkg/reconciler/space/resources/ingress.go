@@ -0,0 +1,83 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+const (
+	// IngressGatewayService is the name of the Service that exposes Istio's
+	// ingress gateway.
+	IngressGatewayService = "istio-ingressgateway"
+
+	// tcpPortPrefix starts the names of the ingress gateway's ports that Kf
+	// adds for TCP routes. Istio treats ports named tcp-* as raw TCP.
+	tcpPortPrefix = "tcp-kf-"
+)
+
+// MakeIngressPorts returns the ports of the ingress gateway's Service with
+// the TCP ports reserved by the spaces added. Ports Kf added before that are
+// no longer reserved are removed, other ports are kept as they are.
+func MakeIngressPorts(existing []v1.ServicePort, spaces []*v1alpha1.Space) []v1.ServicePort {
+	var ports []v1.ServicePort
+	exposed := make(map[int32]bool)
+	added := make(map[int32]v1.ServicePort)
+	for _, port := range existing {
+		if strings.HasPrefix(port.Name, tcpPortPrefix) {
+			added[port.Port] = port
+			continue
+		}
+
+		ports = append(ports, port)
+		exposed[port.Port] = true
+	}
+
+	reserved := make(map[int32]bool)
+	var tcpPorts []int32
+	for _, space := range spaces {
+		for _, port := range space.Spec.Execution.TCPPorts() {
+			if !reserved[port] && !exposed[port] {
+				reserved[port] = true
+				tcpPorts = append(tcpPorts, port)
+			}
+		}
+	}
+	sort.Slice(tcpPorts, func(i, j int) bool { return tcpPorts[i] < tcpPorts[j] })
+
+	for _, port := range tcpPorts {
+		// Keep ports that are already exposed as they are so fields the API
+		// server fills in (e.g. the NodePort) don't change.
+		if port, ok := added[port]; ok {
+			ports = append(ports, port)
+			continue
+		}
+
+		ports = append(ports, v1.ServicePort{
+			Name:       fmt.Sprintf("%s%d", tcpPortPrefix, port),
+			Protocol:   v1.ProtocolTCP,
+			Port:       port,
+			TargetPort: intstr.FromInt(int(port)),
+		})
+	}
+
+	return ports
+}
@@ -0,0 +1,50 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	v1 "k8s.io/api/core/v1"
+)
+
+func ExampleMakeIngressPorts() {
+	space := &v1alpha1.Space{}
+	space.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "tcp.example.com", TCPPorts: []int32{2048, 1024}},
+	}
+
+	otherSpace := &v1alpha1.Space{}
+	otherSpace.Spec.Execution.Domains = []v1alpha1.SpaceDomain{
+		{Domain: "other.example.com", TCPPorts: []int32{1024, 443}},
+	}
+
+	existing := []v1.ServicePort{
+		{Name: "http2", Port: 80},
+		{Name: "https", Port: 443},
+		{Name: "tcp-kf-1024", Port: 1024, NodePort: 31024},
+		{Name: "tcp-kf-3000", Port: 3000},
+	}
+
+	for _, port := range MakeIngressPorts(existing, []*v1alpha1.Space{space, otherSpace}) {
+		fmt.Printf("Port: %s %d node port: %d\n", port.Name, port.Port, port.NodePort)
+	}
+
+	// Output: Port: http2 80 node port: 0
+	// Port: https 443 node port: 0
+	// Port: tcp-kf-1024 1024 node port: 31024
+	// Port: tcp-kf-2048 2048 node port: 0
+}
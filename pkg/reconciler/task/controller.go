@@ -0,0 +1,124 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	appinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/app"
+	spaceinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/space"
+	taskinformer "github.com/google/kf/pkg/client/injection/informers/kf/v1alpha1/task"
+	cronjobinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/cronjob"
+	jobinformer "github.com/google/kf/pkg/client/injection/informers/kubernetes/job"
+	"github.com/google/kf/pkg/kf/secrets"
+	servicebindings "github.com/google/kf/pkg/kf/service-bindings"
+	"github.com/google/kf/pkg/kf/systemenvinjector"
+	"github.com/google/kf/pkg/reconciler"
+	svccatcv1beta1 "github.com/poy/service-catalog/pkg/client/clientset_generated/clientset/typed/servicecatalog/v1beta1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/configmap"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// NewController creates a new controller capable of reconciling Kf Tasks.
+func NewController(ctx context.Context, cmw configmap.Watcher) *controller.Impl {
+	logger := logging.FromContext(ctx)
+
+	// Get informers off context
+	taskInformer := taskinformer.Get(ctx)
+	appInformer := appinformer.Get(ctx)
+	spaceInformer := spaceinformer.Get(ctx)
+	jobInformer := jobinformer.Get(ctx)
+	cronJobInformer := cronjobinformer.Get(ctx)
+
+	// TODO(#397): replace all of this code which eventually gets the
+	// systemEnvInjector with informers once service-binding creation is server
+	// side.
+	config, err := rest.InClusterConfig()
+	if err != nil {
+		logger.Fatalf("Error getting config: %s", err.Error())
+	}
+	kubeClient, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		logger.Fatalf("Error building kubernetes clientset: %s", err.Error())
+	}
+	svccatClient, err := svccatcv1beta1.NewForConfig(config)
+	if err != nil {
+		logger.Fatalf("Error building service-catalog client: %s", err.Error())
+	}
+	secretsClient := secrets.NewClient(kubeClient)
+	bindingsClient := servicebindings.NewClient(svccatClient, secretsClient)
+	systemEnvInjector := systemenvinjector.NewSystemEnvInjector(bindingsClient)
+
+	// Create reconciler
+	c := &Reconciler{
+		Base:              reconciler.NewBase(ctx, "task-controller", cmw),
+		taskLister:        taskInformer.Lister(),
+		appLister:         appInformer.Lister(),
+		spaceLister:       spaceInformer.Lister(),
+		jobLister:         jobInformer.Lister(),
+		cronJobLister:     cronJobInformer.Lister(),
+		systemEnvInjector: systemEnvInjector,
+	}
+
+	impl := controller.NewImpl(c, logger, "Tasks")
+
+	c.Logger.Info("Setting up event handlers")
+
+	// Watch for changes in sub-resources so we can sync accordingly
+	taskInformer.Informer().AddEventHandler(controller.HandleAll(impl.Enqueue))
+
+	jobInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Task")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	cronJobInformer.Informer().AddEventHandler(cache.FilteringResourceEventHandler{
+		FilterFunc: controller.Filter(v1alpha1.SchemeGroupVersion.WithKind("Task")),
+		Handler:    controller.HandleAll(impl.EnqueueControllerOf),
+	})
+
+	// Tasks wait for their App to be built before they start and scheduled
+	// Tasks follow the App's image.
+	enqueueTasksForApp := func(obj interface{}) {
+		app := obj.(*v1alpha1.App)
+
+		tasks, err := c.taskLister.Tasks(app.GetNamespace()).List(labels.Everything())
+		if err != nil {
+			c.Logger.Warnf("failed to list tasks for app %s/%s: %s", app.GetNamespace(), app.GetName(), err)
+			return
+		}
+
+		for _, task := range tasks {
+			if task.Spec.AppName == app.GetName() && !task.Status.IsFinished() {
+				impl.Enqueue(task)
+			}
+		}
+	}
+
+	appInformer.Informer().AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: enqueueTasksForApp,
+		UpdateFunc: func(old, new interface{}) {
+			enqueueTasksForApp(new)
+		},
+	})
+
+	return impl
+}
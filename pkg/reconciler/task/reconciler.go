@@ -0,0 +1,297 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package task
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	kflisters "github.com/google/kf/pkg/client/listers/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/systemenvinjector"
+	"github.com/google/kf/pkg/reconciler"
+	"github.com/google/kf/pkg/reconciler/task/resources"
+	"go.uber.org/zap"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	batchlisters "k8s.io/client-go/listers/batch/v1"
+	batchv1beta1listers "k8s.io/client-go/listers/batch/v1beta1"
+	"k8s.io/client-go/tools/cache"
+	"knative.dev/pkg/controller"
+	"knative.dev/pkg/logging"
+)
+
+// Reconciler reconciles a Task object with the K8s cluster.
+type Reconciler struct {
+	*reconciler.Base
+
+	// listers index properties about resources
+	taskLister    kflisters.TaskLister
+	appLister     kflisters.AppLister
+	spaceLister   kflisters.SpaceLister
+	jobLister     batchlisters.JobLister
+	cronJobLister batchv1beta1listers.CronJobLister
+
+	systemEnvInjector systemenvinjector.SystemEnvInjectorInterface
+}
+
+// Check that our Reconciler implements controller.Reconciler
+var _ controller.Reconciler = (*Reconciler)(nil)
+
+// Reconcile is called by Kubernetes.
+func (r *Reconciler) Reconcile(ctx context.Context, key string) error {
+	logger := logging.FromContext(ctx)
+
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return err
+	}
+
+	original, err := r.taskLister.Tasks(namespace).Get(name)
+	switch {
+	case errors.IsNotFound(err):
+		logger.Errorf("task %q no longer exists\n", name)
+		return nil
+
+	case err != nil:
+		return err
+
+	case original.GetDeletionTimestamp() != nil:
+		return nil
+	}
+
+	// Don't modify the informers copy
+	toReconcile := original.DeepCopy()
+
+	// Reconcile this copy of the task and then write back any status
+	// updates regardless of whether the reconciliation errored out.
+	reconcileErr := r.ApplyChanges(ctx, toReconcile)
+	if equality.Semantic.DeepEqual(original.Status, toReconcile.Status) {
+		// If we didn't change anything then don't call updateStatus.
+		// This is important because the copy we loaded from the informer's
+		// cache may be stale and we don't want to overwrite a prior update
+		// to status with this stale state.
+
+	} else if _, uErr := r.updateStatus(namespace, toReconcile); uErr != nil {
+		logger.Warnw("Failed to update Task status", zap.Error(uErr))
+		return uErr
+	}
+
+	return reconcileErr
+}
+
+// ApplyChanges updates the linked resources in the cluster with the current
+// status of the task.
+func (r *Reconciler) ApplyChanges(ctx context.Context, task *v1alpha1.Task) error {
+	task.Status.InitializeConditions()
+
+	// Finished Tasks are never run again.
+	if task.Status.IsFinished() {
+		return nil
+	}
+
+	if task.Spec.IsScheduled() {
+		return r.applyScheduledChanges(task)
+	}
+
+	jobName := resources.JobName(task)
+	actual, err := r.jobLister.Jobs(task.Namespace).Get(jobName)
+	switch {
+	case errors.IsNotFound(err):
+		actual = nil
+	case err != nil:
+		return err
+	case !metav1.IsControlledBy(actual, task):
+		task.Status.MarkJobNotOwned(jobName)
+		return fmt.Errorf("task: %q does not own job: %q", task.Name, jobName)
+	}
+
+	if task.Spec.Terminated {
+		if actual != nil {
+			// Remove the Pods with the Job so the command stops.
+			propagation := metav1.DeletePropagationBackground
+			err := r.KubeClientSet.BatchV1().Jobs(task.Namespace).Delete(jobName, &metav1.DeleteOptions{
+				PropagationPolicy: &propagation,
+			})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+
+		task.Status.MarkTerminated()
+		if task.Status.CompletionTime == nil {
+			now := metav1.Now()
+			task.Status.CompletionTime = &now
+		}
+
+		return nil
+	}
+
+	if actual == nil {
+		app, err := r.appLister.Apps(task.Namespace).Get(task.Spec.AppName)
+		switch {
+		case errors.IsNotFound(err):
+			task.Status.MarkAppNotFound(task.Spec.AppName)
+			return nil
+		case err != nil:
+			return err
+		case app.Status.Image == "":
+			task.Status.MarkAppNotReady(task.Spec.AppName)
+			return nil
+		}
+
+		space, err := r.spaceLister.Get(task.Namespace)
+		switch {
+		case errors.IsNotFound(err):
+			// Tasks in namespaces that aren't spaces use the space defaults.
+			space = &v1alpha1.Space{}
+			space.SetDefaults(context.Background())
+		case err != nil:
+			return err
+		}
+
+		desired, err := resources.MakeJob(task, app, space, r.systemEnvInjector)
+		if err != nil {
+			return err
+		}
+
+		actual, err = r.KubeClientSet.BatchV1().Jobs(desired.Namespace).Create(desired)
+		if err != nil {
+			return err
+		}
+	}
+
+	task.Status.Image = actual.Spec.Template.Spec.Containers[0].Image
+	task.Status.PropagateJobStatus(actual)
+
+	return nil
+}
+
+// applyScheduledChanges updates the CronJob that periodically runs a
+// scheduled Task so each run uses the App's latest image.
+func (r *Reconciler) applyScheduledChanges(task *v1alpha1.Task) error {
+	cronJobName := resources.CronJobName(task)
+	actual, err := r.cronJobLister.CronJobs(task.Namespace).Get(cronJobName)
+	switch {
+	case errors.IsNotFound(err):
+		actual = nil
+	case err != nil:
+		return err
+	case !metav1.IsControlledBy(actual, task):
+		task.Status.MarkCronJobNotOwned(cronJobName)
+		return fmt.Errorf("task: %q does not own cronjob: %q", task.Name, cronJobName)
+	}
+
+	if task.Spec.Terminated {
+		if actual != nil {
+			// Remove the running Jobs with the CronJob so they stop too.
+			propagation := metav1.DeletePropagationBackground
+			err := r.KubeClientSet.BatchV1beta1().CronJobs(task.Namespace).Delete(cronJobName, &metav1.DeleteOptions{
+				PropagationPolicy: &propagation,
+			})
+			if err != nil && !errors.IsNotFound(err) {
+				return err
+			}
+		}
+
+		task.Status.MarkTerminated()
+		if task.Status.CompletionTime == nil {
+			now := metav1.Now()
+			task.Status.CompletionTime = &now
+		}
+
+		return nil
+	}
+
+	app, err := r.appLister.Apps(task.Namespace).Get(task.Spec.AppName)
+	switch {
+	case errors.IsNotFound(err):
+		task.Status.MarkAppNotFound(task.Spec.AppName)
+		return nil
+	case err != nil:
+		return err
+	case app.Status.Image == "":
+		task.Status.MarkAppNotReady(task.Spec.AppName)
+		return nil
+	}
+
+	space, err := r.spaceLister.Get(task.Namespace)
+	switch {
+	case errors.IsNotFound(err):
+		// Tasks in namespaces that aren't spaces use the space defaults.
+		space = &v1alpha1.Space{}
+		space.SetDefaults(context.Background())
+	case err != nil:
+		return err
+	}
+
+	desired, err := resources.MakeCronJob(task, app, space, r.systemEnvInjector)
+	if err != nil {
+		return err
+	}
+
+	if actual == nil {
+		actual, err = r.KubeClientSet.BatchV1beta1().CronJobs(desired.Namespace).Create(desired)
+	} else {
+		actual, err = r.reconcileCronJob(desired, actual)
+	}
+	if err != nil {
+		return err
+	}
+
+	task.Status.Image = actual.Spec.JobTemplate.Spec.Template.Spec.Containers[0].Image
+	task.Status.PropagateCronJobStatus(actual)
+
+	return nil
+}
+
+func (r *Reconciler) reconcileCronJob(desired, actual *batchv1beta1.CronJob) (*batchv1beta1.CronJob, error) {
+	// Check for differences, if none we don't need to reconcile.
+	semanticEqual := equality.Semantic.DeepEqual(desired.ObjectMeta.Labels, actual.ObjectMeta.Labels)
+	semanticEqual = semanticEqual && equality.Semantic.DeepEqual(desired.Spec, actual.Spec)
+
+	if semanticEqual {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+
+	// Preserve the rest of the object (e.g. ObjectMeta except for labels).
+	existing.ObjectMeta.Labels = desired.ObjectMeta.Labels
+	existing.Spec = desired.Spec
+	return r.KubeClientSet.BatchV1beta1().CronJobs(existing.Namespace).Update(existing)
+}
+
+func (r *Reconciler) updateStatus(namespace string, desired *v1alpha1.Task) (*v1alpha1.Task, error) {
+	actual, err := r.taskLister.Tasks(namespace).Get(desired.Name)
+	if err != nil {
+		return nil, err
+	}
+
+	// If there's nothing to update, just return.
+	if reflect.DeepEqual(actual.Status, desired.Status) {
+		return actual, nil
+	}
+
+	// Don't modify the informers copy.
+	existing := actual.DeepCopy()
+	existing.Status = desired.Status
+
+	return r.KfClientSet.KfV1alpha1().Tasks(namespace).UpdateStatus(existing)
+}
@@ -0,0 +1,71 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/systemenvinjector"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// CronJobName gets the name of the CronJob that runs a scheduled Task.
+func CronJobName(task *v1alpha1.Task) string {
+	return task.Name
+}
+
+// MakeCronJob creates a CronJob that runs the scheduled Task's command in the
+// latest image of the App. Each run is a Job like the one MakeJob creates
+// for one-off Tasks.
+func MakeCronJob(
+	task *v1alpha1.Task,
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+	systemEnvInjector systemenvinjector.SystemEnvInjectorInterface,
+) (*batchv1beta1.CronJob, error) {
+
+	job, err := MakeJob(task, app, space, systemEnvInjector)
+	if err != nil {
+		return nil, err
+	}
+
+	schedule := task.Spec.Schedule
+	suspend := schedule.Suspend
+
+	return &batchv1beta1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      CronJobName(task),
+			Namespace: task.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(task),
+			},
+			Labels: task.ComponentLabels(),
+		},
+		Spec: batchv1beta1.CronJobSpec{
+			Schedule:                   schedule.Cron,
+			ConcurrencyPolicy:          schedule.ConcurrencyPolicy,
+			Suspend:                    &suspend,
+			SuccessfulJobsHistoryLimit: schedule.SuccessfulRunsHistoryLimit,
+			FailedJobsHistoryLimit:     schedule.FailedRunsHistoryLimit,
+			JobTemplate: batchv1beta1.JobTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: task.ComponentLabels(),
+				},
+				Spec: job.Spec,
+			},
+		},
+	}, nil
+}
@@ -0,0 +1,84 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	systemenvinjectorfake "github.com/google/kf/pkg/kf/systemenvinjector/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	batchv1beta1 "k8s.io/api/batch/v1beta1"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func ExampleCronJobName() {
+	task := &v1alpha1.Task{}
+	task.Name = "my-app-cleanup"
+
+	fmt.Println(CronJobName(task))
+
+	// Output: my-app-cleanup
+}
+
+func TestMakeCronJob(t *testing.T) {
+	t.Parallel()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	injector := systemenvinjectorfake.NewFakeSystemEnvInjector(ctrl)
+	injector.EXPECT().ComputeSystemEnv(gomock.Any()).Return(nil, nil)
+
+	app := &v1alpha1.App{}
+	app.Name = "my-app"
+	app.Spec.Source.ContainerImage.Image = "gcr.io/my-project/app:1"
+	app.Status.Image = "gcr.io/my-project/app:1"
+
+	task := &v1alpha1.Task{}
+	task.Name = "my-app-cleanup"
+	task.Namespace = "my-space"
+	task.Spec.AppName = "my-app"
+	task.Spec.Command = "rake db:cleanup"
+	task.Spec.Schedule = &v1alpha1.TaskSpecSchedule{
+		Cron:              "0 3 * * *",
+		ConcurrencyPolicy: batchv1beta1.ForbidConcurrent,
+	}
+	task.SetDefaults(context.Background())
+
+	cronJob, err := MakeCronJob(task, app, &v1alpha1.Space{}, injector)
+	testutil.AssertNil(t, "err", err)
+
+	testutil.AssertEqual(t, "name", "my-app-cleanup", cronJob.Name)
+	testutil.AssertEqual(t, "namespace", "my-space", cronJob.Namespace)
+	testutil.AssertEqual(t, "labels", task.ComponentLabels(), cronJob.Labels)
+	testutil.AssertEqual(t, "schedule", "0 3 * * *", cronJob.Spec.Schedule)
+	testutil.AssertEqual(t, "concurrency policy", batchv1beta1.ForbidConcurrent, cronJob.Spec.ConcurrencyPolicy)
+	testutil.AssertEqual(t, "suspend", false, *cronJob.Spec.Suspend)
+	testutil.AssertEqual(t, "successful history", int32(v1alpha1.DefaultSuccessfulRunsHistoryLimit), *cronJob.Spec.SuccessfulJobsHistoryLimit)
+	testutil.AssertEqual(t, "failed history", int32(v1alpha1.DefaultFailedRunsHistoryLimit), *cronJob.Spec.FailedJobsHistoryLimit)
+
+	testutil.AssertEqual(t, "annotations", map[string]string{
+		"sidecar.istio.io/inject": "false",
+	}, cronJob.Spec.JobTemplate.Spec.Template.Annotations)
+
+	podSpec := cronJob.Spec.JobTemplate.Spec.Template.Spec
+	testutil.AssertEqual(t, "restart policy", corev1.RestartPolicyNever, podSpec.RestartPolicy)
+	testutil.AssertEqual(t, "image", "gcr.io/my-project/app:1", podSpec.Containers[0].Image)
+	testutil.AssertEqual(t, "args", []string{"rake db:cleanup"}, podSpec.Containers[0].Args)
+}
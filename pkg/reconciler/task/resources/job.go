@@ -0,0 +1,105 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"errors"
+
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	"github.com/google/kf/pkg/kf/systemenvinjector"
+	appresources "github.com/google/kf/pkg/reconciler/app/resources"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"knative.dev/pkg/kmeta"
+)
+
+// TaskContainerName is the name of the container running the Task's
+// command. It matches the name Knative gives App containers so Task logs can
+// be read the same way.
+const TaskContainerName = "user-container"
+
+// JobName gets the name of the Job that runs a Task.
+func JobName(task *v1alpha1.Task) string {
+	return task.Name
+}
+
+// MakeJob creates a Job that runs the Task's command in the latest image of
+// the App with the same environment the App runs with.
+func MakeJob(
+	task *v1alpha1.Task,
+	app *v1alpha1.App,
+	space *v1alpha1.Space,
+	systemEnvInjector systemenvinjector.SystemEnvInjectorInterface,
+) (*batchv1.Job, error) {
+
+	image := app.Status.PinnedImage()
+	if image == "" {
+		return nil, errors.New("waiting for App image")
+	}
+
+	// don't modify the spec on the app
+	podSpec := app.Spec.Template.Spec.DeepCopy()
+	if len(podSpec.Containers) == 0 {
+		podSpec.Containers = append(podSpec.Containers, corev1.Container{})
+	}
+
+	env, err := appresources.MakeRuntimeEnv(app, space, systemEnvInjector, podSpec.Containers[0].Env)
+	if err != nil {
+		return nil, err
+	}
+
+	container := &podSpec.Containers[0]
+	container.Name = TaskContainerName
+	container.Image = image
+	container.Env = env
+
+	// The command runs once so it isn't probed or reachable.
+	container.Ports = nil
+	container.ReadinessProbe = nil
+	container.LivenessProbe = nil
+
+	appresources.SetContainerCommand(app, container, task.Spec.Command)
+
+	podSpec.RestartPolicy = corev1.RestartPolicyNever
+
+	// Commands aren't retried, they may not be idempotent.
+	backoffLimit := int32(0)
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      JobName(task),
+			Namespace: task.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*kmeta.NewControllerRef(task),
+			},
+			Labels: task.ComponentLabels(),
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit: &backoffLimit,
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{
+					Labels: task.ComponentLabels(),
+					// The Istio sidecar never exits so the Job would never
+					// complete with it.
+					Annotations: map[string]string{
+						"sidecar.istio.io/inject": "false",
+					},
+				},
+				Spec: *podSpec,
+			},
+		},
+	}, nil
+}
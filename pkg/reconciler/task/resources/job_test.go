@@ -0,0 +1,119 @@
+// Copyright 2019 Google LLC
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     https://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package resources
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/kf/pkg/apis/kf/v1alpha1"
+	systemenvinjectorfake "github.com/google/kf/pkg/kf/systemenvinjector/fake"
+	"github.com/google/kf/pkg/kf/testutil"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func ExampleJobName() {
+	task := &v1alpha1.Task{}
+	task.Name = "my-app-migrate"
+
+	fmt.Println(JobName(task))
+
+	// Output: my-app-migrate
+}
+
+func TestMakeJob(t *testing.T) {
+	t.Parallel()
+
+	for tn, tc := range map[string]struct {
+		source      v1alpha1.SourceSpec
+		wantCommand []string
+	}{
+		"buildpack app": {
+			source: v1alpha1.SourceSpec{
+				BuildpackBuild: v1alpha1.SourceSpecBuildpackBuild{Source: "some-source"},
+			},
+		},
+		"container app": {
+			source: v1alpha1.SourceSpec{
+				ContainerImage: v1alpha1.SourceSpecContainerImage{Image: "gcr.io/my-project/app:1"},
+			},
+			wantCommand: []string{"/bin/sh", "-c"},
+		},
+	} {
+		t.Run(tn, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			injector := systemenvinjectorfake.NewFakeSystemEnvInjector(ctrl)
+			injector.EXPECT().ComputeSystemEnv(gomock.Any()).Return([]corev1.EnvVar{
+				{Name: "VCAP_SERVICES", Value: "{}"},
+			}, nil)
+
+			app := &v1alpha1.App{}
+			app.Name = "my-app"
+			app.Spec.Source = tc.source
+			app.Spec.Template.Spec.Containers = []corev1.Container{{
+				Env:            []corev1.EnvVar{{Name: "GREETING", Value: "hello"}},
+				ReadinessProbe: &corev1.Probe{},
+			}}
+			app.Status.Image = "gcr.io/my-project/app:1"
+			app.Status.ImageDigest = "sha256:f3a6c8"
+
+			space := &v1alpha1.Space{}
+			space.Spec.Execution.Env = []corev1.EnvVar{{Name: "LOG_LEVEL", Value: "info"}}
+
+			task := &v1alpha1.Task{}
+			task.Name = "my-app-migrate"
+			task.Namespace = "my-space"
+			task.Spec.AppName = "my-app"
+			task.Spec.Command = "rake db:migrate"
+
+			job, err := MakeJob(task, app, space, injector)
+			testutil.AssertNil(t, "err", err)
+
+			testutil.AssertEqual(t, "name", "my-app-migrate", job.Name)
+			testutil.AssertEqual(t, "namespace", "my-space", job.Namespace)
+			testutil.AssertEqual(t, "labels", task.ComponentLabels(), job.Spec.Template.Labels)
+			testutil.AssertEqual(t, "backoff limit", int32(0), *job.Spec.BackoffLimit)
+			testutil.AssertEqual(t, "annotations", map[string]string{
+				"sidecar.istio.io/inject": "false",
+			}, job.Spec.Template.Annotations)
+
+			podSpec := job.Spec.Template.Spec
+			testutil.AssertEqual(t, "restart policy", corev1.RestartPolicyNever, podSpec.RestartPolicy)
+
+			container := podSpec.Containers[0]
+			testutil.AssertEqual(t, "container name", TaskContainerName, container.Name)
+			testutil.AssertEqual(t, "image", "gcr.io/my-project/app@sha256:f3a6c8", container.Image)
+			testutil.AssertEqual(t, "command", tc.wantCommand, container.Command)
+			testutil.AssertEqual(t, "args", []string{"rake db:migrate"}, container.Args)
+			testutil.AssertEqual(t, "readiness probe", (*corev1.Probe)(nil), container.ReadinessProbe)
+			testutil.AssertEqual(t, "env", []corev1.EnvVar{
+				{Name: "GREETING", Value: "hello"},
+				{Name: "LOG_LEVEL", Value: "info"},
+				{Name: "VCAP_SERVICES", Value: "{}"},
+			}, container.Env)
+		})
+	}
+}
+
+func TestMakeJob_noImage(t *testing.T) {
+	t.Parallel()
+
+	_, err := MakeJob(&v1alpha1.Task{}, &v1alpha1.App{}, &v1alpha1.Space{}, nil)
+
+	testutil.AssertErrorsEqual(t, fmt.Errorf("waiting for App image"), err)
+}